@@ -0,0 +1,274 @@
+// Command waveedit previews and edits wave set files played with the game's -waves flag
+//
+// Usage:
+//
+//	waveedit [-file waves.json] init
+//	waveedit [-file waves.json] list
+//	waveedit [-file waves.json] move FROM TO
+//	waveedit [-file waves.json] add NAME
+//	waveedit [-file waves.json] remove WAVE
+//	waveedit [-file waves.json] rename WAVE NAME
+//	waveedit [-file waves.json] set WAVE ENEMY COUNT
+//	waveedit [-file waves.json] pacing WAVE INTERVAL COOLDOWN
+//	waveedit [-file waves.json] growth COUNT
+//
+// Waves are numbered from 1. Every edit is validated and saved, then the wave set is listed again
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"billionslike3/game"
+)
+
+func main() {
+	file := flag.String("file", "waves.json", "wave set file")
+	preview := flag.Int("preview", 0, "also list this many endless waves past the last designed wave")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: waveedit [flags] init|list|move|add|remove|rename|set|pacing|growth [args]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	if err := run(*file, *preview, args[0], args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "waveedit: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run executes one command against the wave set file
+func run(file string, preview int, command string, args []string) error {
+	if command == "init" {
+		if _, err := os.Stat(file); err == nil {
+			return fmt.Errorf("%s already exists", file)
+		}
+		waveSet := game.DefaultWaveSet()
+		if err := game.SaveWaveSet(file, waveSet); err != nil {
+			return err
+		}
+		printWaveSet(waveSet, preview)
+		return nil
+	}
+
+	waveSet, err := game.LoadWaveSet(file)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "list", "preview":
+		printWaveSet(waveSet, preview)
+		return nil
+	case "move":
+		err = moveWave(waveSet, args)
+	case "add":
+		err = addWave(waveSet, args)
+	case "remove":
+		err = removeWave(waveSet, args)
+	case "rename":
+		err = renameWave(waveSet, args)
+	case "set":
+		err = setGroup(waveSet, args)
+	case "pacing":
+		err = setPacing(waveSet, args)
+	case "growth":
+		err = setGrowth(waveSet, args)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := waveSet.Validate(); err != nil {
+		return err
+	}
+	if err := game.SaveWaveSet(file, waveSet); err != nil {
+		return err
+	}
+	printWaveSet(waveSet, preview)
+	return nil
+}
+
+// printWaveSet prints each wave's composition and expected difficulty
+func printWaveSet(waveSet *game.WaveSet, preview int) {
+	fmt.Printf("%3s  %-20s  %-40s  %7s  %8s  %7s  %6s\n", "#", "Name", "Composition", "Enemies", "HP", "DPS", "Time")
+	for n := 1; n <= len(waveSet.Waves)+preview; n++ {
+		wave := waveSet.Wave(n)
+		name := wave.Name
+		if n > len(waveSet.Waves) {
+			name += " (endless)"
+		}
+		stats := wave.Stats()
+		_, cooldown := wave.Pacing()
+		fmt.Printf("%3d  %-20s  %-40s  %7d  %8.0f  %7.1f  %5.1fs\n",
+			n, name, composition(wave), stats.Enemies, stats.TotalHP, stats.DPS, stats.Duration+cooldown)
+	}
+	fmt.Printf("Endless growth: +%d enemies per group per wave\n", waveSet.EndlessGrowth)
+}
+
+// composition formats a wave's groups as "10 Rocket, 4 Shooter"
+func composition(wave game.WaveDefinition) string {
+	parts := make([]string, 0, len(wave.Groups))
+	for _, group := range wave.Groups {
+		parts = append(parts, fmt.Sprintf("%d %s", group.Count, group.Enemy))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// moveWave moves wave FROM to position TO
+func moveWave(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: move FROM TO")
+	}
+	from, err := waveIndex(waveSet, args[0])
+	if err != nil {
+		return err
+	}
+	to, err := waveIndex(waveSet, args[1])
+	if err != nil {
+		return err
+	}
+
+	wave := waveSet.Waves[from]
+	waves := append(waveSet.Waves[:from:from], waveSet.Waves[from+1:]...)
+	waves = append(waves[:to], append([]game.WaveDefinition{wave}, waves[to:]...)...)
+	waveSet.Waves = waves
+	return nil
+}
+
+// addWave appends a new wave copying the last wave's composition so it's valid straight away
+func addWave(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: add NAME")
+	}
+	wave := waveSet.Waves[len(waveSet.Waves)-1]
+	wave.Name = args[0]
+	wave.Groups = append([]game.WaveGroup(nil), wave.Groups...)
+	waveSet.Waves = append(waveSet.Waves, wave)
+	return nil
+}
+
+// removeWave deletes a wave
+func removeWave(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: remove WAVE")
+	}
+	index, err := waveIndex(waveSet, args[0])
+	if err != nil {
+		return err
+	}
+	waveSet.Waves = append(waveSet.Waves[:index], waveSet.Waves[index+1:]...)
+	return nil
+}
+
+// renameWave changes a wave's name
+func renameWave(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: rename WAVE NAME")
+	}
+	index, err := waveIndex(waveSet, args[0])
+	if err != nil {
+		return err
+	}
+	waveSet.Waves[index].Name = args[1]
+	return nil
+}
+
+// setGroup sets the count of an enemy type in a wave, adding or removing the group as needed
+func setGroup(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: set WAVE ENEMY COUNT")
+	}
+	index, err := waveIndex(waveSet, args[0])
+	if err != nil {
+		return err
+	}
+	enemy := args[1]
+	if _, ok := game.GetEnemyTypeByName(enemy); !ok {
+		return fmt.Errorf("unknown enemy %q (known: %s)", enemy, strings.Join(enemyNames(), ", "))
+	}
+	count, err := strconv.Atoi(args[2])
+	if err != nil || count < 0 {
+		return fmt.Errorf("invalid count %q", args[2])
+	}
+
+	wave := &waveSet.Waves[index]
+	for i, group := range wave.Groups {
+		if group.Enemy != enemy {
+			continue
+		}
+		if count == 0 {
+			wave.Groups = append(wave.Groups[:i], wave.Groups[i+1:]...)
+		} else {
+			wave.Groups[i].Count = count
+		}
+		return nil
+	}
+	if count > 0 {
+		wave.Groups = append(wave.Groups, game.WaveGroup{Enemy: enemy, Count: count})
+	}
+	return nil
+}
+
+// setPacing sets a wave's spawn interval and cooldown (0 = default)
+func setPacing(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: pacing WAVE INTERVAL COOLDOWN")
+	}
+	index, err := waveIndex(waveSet, args[0])
+	if err != nil {
+		return err
+	}
+	interval, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || interval < 0 {
+		return fmt.Errorf("invalid spawn interval %q", args[1])
+	}
+	cooldown, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || cooldown < 0 {
+		return fmt.Errorf("invalid cooldown %q", args[2])
+	}
+	waveSet.Waves[index].SpawnInterval = interval
+	waveSet.Waves[index].Cooldown = cooldown
+	return nil
+}
+
+// setGrowth sets how many enemies each group gains per wave past the last designed wave
+func setGrowth(waveSet *game.WaveSet, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: growth COUNT")
+	}
+	growth, err := strconv.Atoi(args[0])
+	if err != nil || growth < 0 {
+		return fmt.Errorf("invalid growth %q", args[0])
+	}
+	waveSet.EndlessGrowth = growth
+	return nil
+}
+
+// waveIndex parses a 1-based wave number into a slice index
+func waveIndex(waveSet *game.WaveSet, arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 || n > len(waveSet.Waves) {
+		return 0, fmt.Errorf("invalid wave %q (1-%d)", arg, len(waveSet.Waves))
+	}
+	return n - 1, nil
+}
+
+// enemyNames lists the enemy type names usable in wave groups
+func enemyNames() []string {
+	names := make([]string, 0, len(game.EnemyTypes))
+	for _, enemyType := range game.EnemyTypes {
+		names = append(names, game.GetEnemyTypeConfig(enemyType).Name)
+	}
+	return names
+}
@@ -66,7 +66,9 @@ func canShipTargetEntity(shipType ShipType, target *Entity) bool {
 }
 
 // UpdateAI updates AI input providers with behavior patterns
-func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, deltaTime float64) {
+// think runs the full re-evaluation (target search); otherwise the previous target is kept
+// and only the cheap steering toward it runs (see AIScheduler)
+func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, deltaTime float64, think bool) {
 	if aiInput == nil {
 		return
 	}
@@ -74,56 +76,41 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 	// Update AI input state
 	aiInput.Update(deltaTime)
 
-	// Get entity faction to determine target
+	// Get entity faction to determine target (independents only fight when the player's reputation makes them)
 	entityFaction := GetEntityFaction(entity)
-	targetFaction := GetOppositeFaction(entityFaction)
-
-	// Find nearest target of opposite faction using spatial partitioning
-	var targetEntity *Entity
-	nearestDistanceSq := math.MaxFloat64
-
-	// Use spatial query to find nearby entities instead of iterating all entities
-	searchRadius := 1000.0 // Reasonable search radius
-	candidates := world.GetEntitiesInRadius(entity.X, entity.Y, searchRadius)
-
-	for _, candidate := range candidates {
-		if !candidate.Active || candidate == entity || candidate.Health <= 0 {
-			continue
+	targetFaction, fights := world.Reputation.TargetFaction(entityFaction)
+
+	// Re-acquire a target when scheduled; in between, keep chasing the last one while it's alive
+	// Group members use their group's target instead of searching themselves
+	targetEntity := aiInput.TargetEntity
+	// Wingmen answer their team's pings before picking their own fights
+	if aiInput.Group != nil {
+		targetEntity = aiInput.Group.Target
+	} else if think {
+		targetEntity = nil
+		if world.Config.PingWingmen && entityFaction == FactionPlayer {
+			targetEntity = answerPing(entity, world, targetFaction)
 		}
-
-		// Skip untargetable entities (XP, destroyed indicators, homing rockets, etc.)
-		if candidate.Type == EntityTypeXP || candidate.Type == EntityTypeDestroyedIndicator || candidate.Type == EntityTypeHomingRocket {
-			continue
+		aiInput.answeringPing = targetEntity != nil
+		if targetEntity == nil && fights {
+			targetEntity = findAITarget(entity, player, world, targetFaction)
 		}
+	} else if targetEntity != nil && (!targetEntity.Active || targetEntity.Health <= 0) {
+		targetEntity = nil // Target died, wait for the next think to find another
+	}
 
-		candidateFaction := GetEntityFaction(candidate)
-		if candidateFaction == targetFaction {
-			// Check if this ship can target this entity based on ship config
-			if !canShipTargetEntity(entity.ShipType, candidate) {
-				continue
-			}
-			dx := candidate.X - entity.X
-			dy := candidate.Y - entity.Y
-			distanceSq := dx*dx + dy*dy // Use squared distance to avoid sqrt
-
-			if distanceSq < nearestDistanceSq {
-				nearestDistanceSq = distanceSq
-				targetEntity = candidate
-			}
+	// Patrols only engage targets near their post, and break off when the target leaves (unless pinged away)
+	if aiInput.patrol && !aiInput.answeringPing && targetEntity != nil {
+		dx := targetEntity.X - aiInput.patrolX
+		dy := targetEntity.Y - aiInput.patrolY
+		if dx*dx+dy*dy > PatrolAggroRadius*PatrolAggroRadius {
+			targetEntity = nil
 		}
 	}
 
-	// If no target found in search radius, check player specifically (might be outside radius)
-	if targetEntity == nil && player != nil && player.Active {
-		playerFaction := GetEntityFaction(player)
-		if playerFaction == targetFaction {
-			dx := player.X - entity.X
-			dy := player.Y - entity.Y
-			distanceSq := dx*dx + dy*dy
-			if distanceSq < nearestDistanceSq {
-				targetEntity = player
-			}
-		}
+	// Cloaked ships decloak to attack a target in range
+	if entity.Cloak.Capable {
+		updateCloakAI(entity, targetEntity)
 	}
 
 	// Update hasTarget flag
@@ -138,6 +125,22 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 
 	// Behavior depends on enemy type
 	switch aiInput.EnemyType {
+	case EnemyTypeSwarm:
+		// Fly to the slot the group controller assigned, easing off on arrival
+		if aiInput.Group != nil {
+			targetX, targetY = aiInput.Group.SlotPosition(aiInput.groupSlot)
+			dx := targetX - entity.X
+			dy := targetY - entity.Y
+			aiInput.groupThrust = 1.0
+			if dx*dx+dy*dy < GroupSlotArrivalDistance*GroupSlotArrivalDistance {
+				aiInput.groupThrust = 0.3
+			}
+		} else if targetEntity != nil && targetEntity.Active {
+			// A stray member without a group simply rams its target
+			targetX = targetEntity.X
+			targetY = targetEntity.Y
+		}
+
 	case EnemyTypeRocket:
 		// Direct homing: chase target of opposite faction
 		if targetEntity != nil && targetEntity.Active {
@@ -145,14 +148,17 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 			targetY = targetEntity.Y
 		} else {
 			// No target, wander
-			aiInput.PatternTime += deltaTime
-			targetX = entity.X + math.Cos(aiInput.PatternTime)*50
-			targetY = entity.Y + math.Sin(aiInput.PatternTime)*50
+			targetX, targetY = aiInput.wander(entity, deltaTime)
 		}
 
 	case EnemyTypeShooter:
 		// Shooter: chase but keep some distance, shoot
-		if targetEntity != nil && targetEntity.Active {
+		if aiInput.fleeing && targetEntity != nil && targetEntity.Active {
+			// Fleeing mini-boss: burn straight away from the pursuer (turrets still fire back)
+			targetX, targetY = fleePoint(entity, targetEntity)
+			aiInput.TargetX = targetX
+			aiInput.TargetY = targetY
+		} else if targetEntity != nil && targetEntity.Active {
 			dx := targetEntity.X - entity.X
 			dy := targetEntity.Y - entity.Y
 			distanceSq := dx*dx + dy*dy
@@ -184,12 +190,16 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 			}
 		} else {
 			// No target, wander
-			aiInput.PatternTime += deltaTime
-			targetX = entity.X + math.Cos(aiInput.PatternTime)*50
-			targetY = entity.Y + math.Sin(aiInput.PatternTime)*50
+			targetX, targetY = aiInput.wander(entity, deltaTime)
 			aiInput.TargetX = targetX
 			aiInput.TargetY = targetY
 		}
+
+		// Turrets track the target independently of the hull (with a turn-rate limit)
+		updateAITurrets(entity, targetEntity, world, deltaTime)
+
+		// Sidestep incoming fire
+		updateDodge(aiInput, entity, world, deltaTime, think)
 	}
 
 	// Update target position (for movement, not shooting)
@@ -213,6 +223,12 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 		rotationTargetY = targetY
 	}
 
+	// Ships head back toward the safe zone center when outside it or close to its edge (turrets keep firing)
+	if zone := world.SafeZone; zone != nil && entity.Type == EntityTypeEnemy && zone.ShouldRetreat(entity.X, entity.Y, SafeZoneAIMargin) {
+		rotationTargetX = zone.X
+		rotationTargetY = zone.Y
+	}
+
 	dx := rotationTargetX - entity.X
 	dy := rotationTargetY - entity.Y
 	distance := math.Sqrt(dx*dx + dy*dy)
@@ -223,6 +239,13 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 		// Atan2(dy, dx) gives angle from positive x-axis
 		targetAngle := math.Atan2(dy, dx)
 
+		// Shooters turn so their first surviving turret bears on the target
+		if aiInput.EnemyType == EnemyTypeShooter {
+			if turret := entity.FirstOperationalTurret(); turret != nil {
+				targetAngle -= turret.Mount.Angle
+			}
+		}
+
 		// Calculate angle difference (normalize to -PI to PI)
 		angleDiff := targetAngle - entity.Rotation
 		// Normalize angle difference to [-PI, PI]
@@ -256,6 +279,80 @@ func UpdateAI(aiInput *AIInput, entity *Entity, player *Entity, world *World, de
 	}
 }
 
+// wander returns the movement target of a ship without a target:
+// a small loop around itself, or a wide orbit of its post for patrols
+func (a *AIInput) wander(entity *Entity, deltaTime float64) (float64, float64) {
+	a.PatternTime += deltaTime
+	if a.patrol {
+		angle := a.PatternTime * PatrolOrbitSpeed
+		return a.patrolX + math.Cos(angle)*PatrolOrbitRadius, a.patrolY + math.Sin(angle)*PatrolOrbitRadius
+	}
+	return entity.X + math.Cos(a.PatternTime)*50, entity.Y + math.Sin(a.PatternTime)*50
+}
+
+// findAITarget finds the nearest entity of the target faction the ship can target
+// Falls back to the player when nothing is in search range
+func findAITarget(entity *Entity, player *Entity, world *World, targetFaction Faction) *Entity {
+	searchRadius := 1000.0 // Reasonable search radius
+	targetEntity := findAITargetNear(entity, entity.X, entity.Y, searchRadius, world, targetFaction)
+
+	// If no target found in search radius, check player specifically (might be outside radius)
+	if targetEntity == nil && player != nil && player.Active {
+		playerFaction := GetEntityFaction(player)
+		if playerFaction == targetFaction {
+			targetEntity = player
+		}
+	}
+
+	return targetEntity
+}
+
+// findAITargetNear finds the entity of the target faction nearest to (x, y) within searchRadius that the ship can target
+func findAITargetNear(entity *Entity, x, y, searchRadius float64, world *World, targetFaction Faction) *Entity {
+	// Find nearest target of opposite faction using spatial partitioning
+	var targetEntity *Entity
+	var nearestDistanceSq float64
+
+	// Use spatial query to find nearby entities instead of iterating all entities
+	// The scan is shared with other AIs, rockets and the player's turrets searching the same cells
+	nearestDistanceSq = searchRadius * searchRadius
+	candidates := world.QueryTargetCandidates(x, y, searchRadius)
+
+	for _, candidate := range candidates {
+		if !candidate.Active || candidate == entity || candidate.Health <= 0 {
+			continue
+		}
+
+		// Skip untargetable entities (XP, destroyed indicators, homing rockets, etc.)
+		if candidate.Type == EntityTypeXP || candidate.Type == EntityTypeDestroyedIndicator || candidate.Type == EntityTypeHomingRocket {
+			continue
+		}
+
+		// Cloaked ships can't be targeted until the player's side detects them
+		if candidate.Cloak.Hidden() {
+			continue
+		}
+
+		candidateFaction := GetEntityFaction(candidate)
+		if candidateFaction == targetFaction {
+			// Check if this ship can target this entity based on ship config
+			if !canShipTargetEntity(entity.ShipType, candidate) {
+				continue
+			}
+			dx := candidate.X - x
+			dy := candidate.Y - y
+			distanceSq := dx*dx + dy*dy // Use squared distance to avoid sqrt
+
+			if distanceSq <= nearestDistanceSq {
+				nearestDistanceSq = distanceSq
+				targetEntity = candidate
+			}
+		}
+	}
+
+	return targetEntity
+}
+
 // CreateEnemyAI creates an AI input with a random enemy type
 func CreateEnemyAI() *AIInput {
 	enemyType := GetRandomEnemyType()
@@ -0,0 +1,53 @@
+package game
+
+import "math"
+
+// AIScheduler spreads full AI re-evaluations (target searches) across ticks
+// Each AI thinks every AIThinkInterval to AIThinkInterval+AIThinkSpread ticks (staggered by entity ID),
+// at most AIThinkBudget AIs think per tick, and steering toward the current target runs every tick
+type AIScheduler struct {
+	interval int
+	spread   int
+	budget   int
+
+	// Thinks granted so far this tick
+	thinks int
+}
+
+// NewAIScheduler creates a scheduler using the AI settings from config
+// Intervals are given in 60 TPS ticks and scaled so AIs react at the same speed at any tick rate
+func NewAIScheduler(config Config) *AIScheduler {
+	tickScale := float64(config.TickRate()) / DefaultSimulationTPS
+	return &AIScheduler{
+		interval: max(int(math.Round(float64(config.AIThinkInterval)*tickScale)), 1),
+		spread:   max(int(math.Round(float64(config.AIThinkSpread)*tickScale)), 0),
+		budget:   config.AIThinkBudget,
+	}
+}
+
+// BeginTick resets the per-tick think budget
+func (s *AIScheduler) BeginTick() {
+	s.thinks = 0
+}
+
+// ShouldThink returns true if the entity should run a full AI re-evaluation this tick
+// AIs that are due but over budget stay due and get their turn on a later tick
+func (s *AIScheduler) ShouldThink(aiInput *AIInput, entity *Entity) bool {
+	aiInput.thinkCountdown--
+
+	// A dead target makes the AI due right away
+	if target := aiInput.TargetEntity; target != nil && (!target.Active || target.Health <= 0) {
+		aiInput.thinkCountdown = 0
+	}
+
+	if aiInput.thinkCountdown > 0 {
+		return false
+	}
+	if s.budget > 0 && s.thinks >= s.budget {
+		return false
+	}
+
+	s.thinks++
+	aiInput.thinkCountdown = s.interval + int(entity.ID%uint64(s.spread+1))
+	return true
+}
@@ -0,0 +1,72 @@
+package game
+
+import "math"
+
+const (
+	// AnimationFrameTime is how long each sprite sheet frame is shown (seconds)
+	AnimationFrameTime = 0.08
+
+	// DamagedHealthFraction is the health fraction below which ships play their damaged animation
+	DamagedHealthFraction = 0.35
+)
+
+// AnimationState selects which sprite sheet a ship plays
+type AnimationState int
+
+const (
+	AnimationIdle AnimationState = iota
+	AnimationThrusting
+	AnimationDamaged
+)
+
+// animationSheetSuffix is appended to a ship's Sprite name to find each state's sheet
+// (e.g. "sprites/player_thrust"); idle uses the Sprite itself
+var animationSheetSuffix = [...]string{
+	AnimationIdle:      "",
+	AnimationThrusting: "_thrust",
+	AnimationDamaged:   "_damaged",
+}
+
+// AnimationController tracks the current animation state and time of a single entity
+type AnimationController struct {
+	State AnimationState
+	Time  float64 // Seconds spent in the current state
+}
+
+// Update picks the animation state from the entity's condition and advances time
+// Damaged wins over thrusting so a crippled ship always reads as crippled
+func (a *AnimationController) Update(entity *Entity, deltaTime float64) {
+	state := AnimationIdle
+	if entity.MaxHealth > 0 && entity.Health < entity.MaxHealth*DamagedHealthFraction {
+		state = AnimationDamaged
+	} else if entity.Input != nil && math.Abs(entity.Input.GetThrust()) > 0.01 {
+		state = AnimationThrusting
+	}
+
+	if state != a.State {
+		a.State = state
+		a.Time = 0
+		return
+	}
+	a.Time += deltaTime
+}
+
+// FrameIndex returns the looping frame index for a sheet with frameCount frames
+func (a *AnimationController) FrameIndex(frameCount int) int {
+	if frameCount <= 1 {
+		return 0
+	}
+	return int(a.Time/AnimationFrameTime) % frameCount
+}
+
+// animationSheetNames holds every state's sheet name for one sprite
+type animationSheetNames [len(animationSheetSuffix)]string
+
+// newAnimationSheetNames builds the sheet names for a ship sprite
+func newAnimationSheetNames(sprite string) animationSheetNames {
+	var names animationSheetNames
+	for state, suffix := range animationSheetSuffix {
+		names[state] = sprite + suffix
+	}
+	return names
+}
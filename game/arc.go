@@ -0,0 +1,146 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// ArcMaxJumps is the number of extra targets an arc hit chains to
+	ArcMaxJumps = 3
+
+	// ArcJumpRadius is how far the lightning can jump from one target to the next (pixels)
+	ArcJumpRadius = 150.0
+
+	// ArcJumpShare is the share of the weapon's damage dealt by the first jump
+	ArcJumpShare = 0.8
+
+	// ArcDamageFalloff is the share of the damage kept by each further jump
+	ArcDamageFalloff = 0.6
+
+	// ArcLifetime is how long the lightning stays on screen (seconds)
+	ArcLifetime = 0.2
+
+	// arcSegmentLength is the length of one zigzag of a drawn lightning bolt (pixels)
+	arcSegmentLength = 12.0
+
+	// arcJitter is how far the zigzags stray from the straight line (pixels)
+	arcJitter = 6.0
+)
+
+// arcColor is the color of the lightning
+var arcColor = color.RGBA{150, 200, 255, 255}
+
+// chainArc jumps from the hit target to up to ArcMaxJumps nearby hostile ships, each the nearest one not hit yet,
+// damaging each for less than the last, and leaves the lightning bolt as an FX entity
+func (g *Game) chainArc(projectile, target *Entity) {
+	damage := GetWeaponConfig(projectile.Weapon).Damage * ArcJumpShare
+	path := []float64{target.X, target.Y}
+	hit := []*Entity{target}
+	current := target
+	for jump := 0; jump < ArcMaxJumps; jump++ {
+		next := g.nearestArcTarget(projectile, current, hit)
+		if next == nil {
+			break
+		}
+		g.collisionSystem.applyWeaponDamage(projectile, next, damage, current.X, current.Y)
+		damage *= ArcDamageFalloff
+		path = append(path, next.X, next.Y)
+		hit = append(hit, next)
+		current = next
+	}
+	if len(hit) < 2 {
+		return
+	}
+
+	bolt := NewEntity(target.X, target.Y, 0, EntityTypeDestroyedIndicator, nil)
+	bolt.Indicator.Kind = IndicatorKindArc
+	bolt.Indicator.Path = path
+	bolt.Faction = projectile.OwnerFaction
+	bolt.Active = true
+	bolt.Health = 1.0 // Small health value so it renders
+	bolt.MaxHealth = 1.0
+	bolt.Lifetime = ArcLifetime
+	bolt.NoCollision = true
+	g.world.RegisterEntity(bolt)
+}
+
+// nearestArcTarget returns the closest hostile ship within ArcJumpRadius of from that the arc hasn't hit yet
+// It reuses the shared target scan, so chains in the same area cost one grid query
+func (g *Game) nearestArcTarget(projectile, from *Entity, hit []*Entity) *Entity {
+	var nearest *Entity
+	nearestDistanceSq := ArcJumpRadius * ArcJumpRadius
+	for _, candidate := range g.world.QueryTargetCandidates(from.X, from.Y, ArcJumpRadius) {
+		if !candidate.Active || candidate.Health <= 0 || candidate.Cloak.Hidden() {
+			continue
+		}
+		if candidate.Type != EntityTypePlayer && candidate.Type != EntityTypeEnemy {
+			continue
+		}
+		if GetEntityFaction(candidate) == projectile.OwnerFaction || GetEntityFaction(candidate) == FactionNeutral {
+			continue
+		}
+		if projectile.OwnerFaction == FactionPlayer && g.world.Reputation.Peaceful(GetEntityFaction(candidate)) {
+			continue
+		}
+		if arcAlreadyHit(hit, candidate) {
+			continue
+		}
+		dx, dy := candidate.X-from.X, candidate.Y-from.Y
+		if distanceSq := dx*dx + dy*dy; distanceSq < nearestDistanceSq {
+			nearestDistanceSq = distanceSq
+			nearest = candidate
+		}
+	}
+	return nearest
+}
+
+// arcAlreadyHit returns true if the chain already went through entity (chains are short, a scan is enough)
+func arcAlreadyHit(hit []*Entity, entity *Entity) bool {
+	for _, e := range hit {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// drawArc draws chain lightning as jagged polylines between the targets it jumped through
+// The zigzags are re-rolled a few times over the bolt's short life so it flickers
+func (r *Renderer) drawArc(screen *ebiten.Image, entity *Entity) {
+	path := entity.Indicator.Path
+	progress := math.Min(entity.Age/entity.Lifetime, 1)
+	clr := arcColor
+	clr.A = uint8(255 * (1 - progress))
+	frame := uint64(entity.Age * 30)
+	for i := 0; i+3 < len(path); i += 2 {
+		startX, startY := r.camera.WorldToScreen(path[i], path[i+1])
+		endX, endY := r.camera.WorldToScreen(path[i+2], path[i+3])
+		length := math.Hypot(endX-startX, endY-startY)
+		if length == 0 {
+			continue
+		}
+		normalX, normalY := -(endY-startY)/length, (endX-startX)/length
+
+		segments := max(int(length/(arcSegmentLength*r.camera.Zoom)), 1)
+		prevX, prevY := startX, startY
+		for s := 1; s <= segments; s++ {
+			t := float64(s) / float64(segments)
+			x, y := startX+(endX-startX)*t, startY+(endY-startY)*t
+			if s < segments {
+				// Cheap hash of the bolt, flicker frame and zigzag picks the offset
+				hash := (entity.ID*0x9E3779B1 + frame*0x85EBCA6B + uint64(i*64+s)*0xC2B2AE35) % 1000
+				offset := (float64(hash)/500 - 1) * arcJitter * r.camera.Zoom
+				x += normalX * offset
+				y += normalY * offset
+			}
+			r.lineCount++
+			r.drawCallCount++
+			vector.StrokeLine(screen, float32(prevX), float32(prevY), float32(x), float32(y), 2, clr, true)
+			prevX, prevY = x, y
+		}
+	}
+}
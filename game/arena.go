@@ -0,0 +1,63 @@
+package game
+
+import "math"
+
+const (
+	// ArenaBotCount is the number of player-faction bots kept alive in arena mode
+	ArenaBotCount = 6
+
+	// ArenaRadius is the radius around the world center where bots and enemies spawn
+	ArenaRadius = 600.0
+
+	// ArenaPublishRate is the number of spectator snapshots per second
+	ArenaPublishRate = 10
+)
+
+// arenaCenter returns the center of the arena (the world center)
+func (g *Game) arenaCenter() (float64, float64) {
+	return g.config.WorldMinX + g.config.WorldWidth/2, g.config.WorldMinY + g.config.WorldHeight/2
+}
+
+// spawnArenaBot spawns an AI-controlled player-faction ship near the arena center
+func (g *Game) spawnArenaBot() {
+	centerX, centerY := g.arenaCenter()
+	angle := runRNG.Float64() * 2 * math.Pi
+	distance := runRNG.Float64() * ArenaRadius * 0.5
+
+	// Bots fly the player ship with shooter behavior, targeting the enemy faction
+	aiInput := NewAIInputWithType(EnemyTypeShooter)
+	bot := NewEntityWithShipType(
+		centerX+math.Cos(angle)*distance,
+		centerY+math.Sin(angle)*distance,
+		EntityTypeEnemy,
+		ShipTypePlayer,
+		aiInput,
+	)
+	bot.Faction = FactionPlayer
+	g.world.RegisterEntity(bot)
+}
+
+// updateArena keeps the bot roster full and publishes snapshots to spectators
+func (g *Game) updateArena() {
+	// Count living bots and top the roster back up
+	botCount := 0
+	for _, entity := range g.world.AllEntities {
+		if entity.Active && entity.Health > 0 && entity.Type == EntityTypeEnemy && entity.Faction == FactionPlayer {
+			botCount++
+		}
+	}
+	for i := botCount; i < ArenaBotCount; i++ {
+		g.spawnArenaBot()
+	}
+
+	// Stream world state to connected spectators
+	publishInterval := uint64(max(g.config.TickRate()/ArenaPublishRate, 1))
+	if g.spectator != nil && g.tickCount%publishInterval == 0 {
+		g.spectator.Publish(g.world)
+	}
+}
+
+// SetSpectator attaches a spectator server that receives world snapshots in arena mode
+func (g *Game) SetSpectator(server *SpectatorServer) {
+	g.spectator = server
+}
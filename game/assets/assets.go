@@ -0,0 +1,203 @@
+// Package assets provides the game's embedded images and optional hot reloading for development
+package assets
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"image"
+	"image/png"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Embedded asset files: ship sprites (white, tinted by faction at draw time) and UI icons
+//
+//go:embed sprites/*.png icons/*.png
+var embedded embed.FS
+
+// DefaultReloadInterval is how often the watched assets directory is polled for changes
+const DefaultReloadInterval = 500 * time.Millisecond
+
+// Atlas holds every decoded image keyed by its path without extension (e.g. "sprites/player")
+// Image and Update must be called from the game goroutine; the watcher only decodes
+type Atlas struct {
+	images map[string]*ebiten.Image
+
+	// Frames of sprite sheets, split on first use
+	frames map[string][]*ebiten.Image
+
+	// Images decoded by the watcher, uploaded on the next Update
+	mu      sync.Mutex
+	pending map[string]image.Image
+	stop    chan struct{}
+}
+
+// Load decodes all embedded images into a new atlas
+func Load() (*Atlas, error) {
+	atlas := &Atlas{
+		images:  make(map[string]*ebiten.Image),
+		frames:  make(map[string][]*ebiten.Image),
+		pending: make(map[string]image.Image),
+	}
+
+	err := fs.WalkDir(embedded, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(filePath) != ".png" {
+			return err
+		}
+		data, err := embedded.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", filePath, err)
+		}
+		atlas.images[assetName(filePath)] = ebiten.NewImageFromImage(img)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded assets: %w", err)
+	}
+	return atlas, nil
+}
+
+// Image returns the image with the given name, or nil if it doesn't exist
+func (a *Atlas) Image(name string) *ebiten.Image {
+	if a == nil {
+		return nil
+	}
+	return a.images[name]
+}
+
+// Frames returns the frames of a sprite sheet: a horizontal strip of square frames
+// A single square image is a one-frame sheet; returns nil if the image doesn't exist
+func (a *Atlas) Frames(name string) []*ebiten.Image {
+	if a == nil {
+		return nil
+	}
+	if frames, ok := a.frames[name]; ok {
+		return frames
+	}
+
+	sheet := a.images[name]
+	if sheet == nil {
+		return nil
+	}
+	bounds := sheet.Bounds()
+	size := bounds.Dy()
+	count := max(bounds.Dx()/size, 1)
+	frames := make([]*ebiten.Image, count)
+	for i := range frames {
+		rect := image.Rect(bounds.Min.X+i*size, bounds.Min.Y, bounds.Min.X+(i+1)*size, bounds.Max.Y)
+		frames[i] = sheet.SubImage(rect).(*ebiten.Image)
+	}
+	a.frames[name] = frames
+	return frames
+}
+
+// Update uploads images changed on disk since the last call (no-op unless watching)
+func (a *Atlas) Update() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for name, img := range a.pending {
+		if old := a.images[name]; old != nil {
+			old.Deallocate()
+		}
+		a.images[name] = ebiten.NewImageFromImage(img)
+		delete(a.frames, name) // Re-split on next use (the frame count may have changed)
+		delete(a.pending, name)
+		log.Printf("assets: reloaded %s\n", name)
+	}
+}
+
+// Watch polls dir (laid out like the embedded assets, e.g. dir/sprites/player.png)
+// and hot-reloads images whose modification time changes. Intended for development
+func (a *Atlas) Watch(dir string, interval time.Duration) {
+	a.StopWatching()
+	a.stop = make(chan struct{})
+	go a.watchLoop(dir, interval, a.stop)
+}
+
+// StopWatching stops a watcher started by Watch
+func (a *Atlas) StopWatching() {
+	if a.stop != nil {
+		close(a.stop)
+		a.stop = nil
+	}
+}
+
+// watchLoop polls the assets directory until stopped
+func (a *Atlas) watchLoop(dir string, interval time.Duration, stop chan struct{}) {
+	modTimes := make(map[string]time.Time)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || filepath.Ext(filePath) != ".png" {
+				return nil // Missing or unreadable files are retried next poll
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil
+			}
+			last, seen := modTimes[filePath]
+			modTimes[filePath] = info.ModTime()
+			if !seen || !info.ModTime().After(last) {
+				return nil // First poll records the baseline; only later edits reload
+			}
+
+			rel, err := filepath.Rel(dir, filePath)
+			if err != nil {
+				return nil
+			}
+			img, err := decodePNGFile(filePath)
+			if err != nil {
+				log.Printf("assets: %v\n", err)
+				return nil
+			}
+			a.mu.Lock()
+			a.pending[assetName(filepath.ToSlash(rel))] = img
+			a.mu.Unlock()
+			return nil
+		})
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// decodePNGFile reads and decodes a PNG from disk
+func decodePNGFile(filePath string) (image.Image, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filePath, err)
+	}
+	return img, nil
+}
+
+// assetName converts a slash-separated asset path to its atlas name
+func assetName(filePath string) string {
+	return strings.TrimSuffix(filePath, path.Ext(filePath))
+}
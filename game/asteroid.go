@@ -0,0 +1,144 @@
+package game
+
+import "math"
+
+const (
+	// AsteroidFieldCount is the number of large asteroids scattered around the world center at the start of a run
+	AsteroidFieldCount = 15
+
+	// AsteroidFieldMinDistance and AsteroidFieldMaxDistance bound how far from the center the field is scattered
+	AsteroidFieldMinDistance = 600.0
+	AsteroidFieldMaxDistance = 3000.0
+
+	// AsteroidDriftSpeed is the maximum initial drift speed of field asteroids (pixels per second)
+	AsteroidDriftSpeed = 30.0
+
+	// AsteroidSpinSpeed is the maximum tumble speed of an asteroid (radians per second)
+	AsteroidSpinSpeed = 0.5
+)
+
+// AsteroidSize identifies an asteroid size class
+type AsteroidSize int
+
+const (
+	AsteroidSizeLarge AsteroidSize = iota
+	AsteroidSizeMedium
+	AsteroidSizeSmall
+)
+
+// AsteroidData holds the state of an asteroid
+type AsteroidData struct {
+	Size AsteroidSize
+}
+
+// AsteroidConfig holds configuration for each asteroid size
+// Sizes chain through SplitInto: large asteroids break into medium ones, medium into small
+type AsteroidConfig struct {
+	Radius        float64
+	Health        float64
+	FragmentsMin  int          // Fragments spawned on destruction (0 = none)
+	FragmentsMax  int          // Inclusive
+	SplitInto     AsteroidSize // Size of the fragments
+	FragmentSpeed float64      // Random spread speed added to the inherited momentum (pixels per second)
+	PickupChance  float64      // Chance of releasing a resource pickup when destroyed
+	PickupValue   int          // Score value of the resource pickup
+}
+
+// GetAsteroidConfig returns the configuration for an asteroid size
+func GetAsteroidConfig(size AsteroidSize) AsteroidConfig {
+	switch size {
+	case AsteroidSizeLarge:
+		return AsteroidConfig{
+			Radius:        40.0,
+			Health:        120.0,
+			FragmentsMin:  2,
+			FragmentsMax:  3,
+			SplitInto:     AsteroidSizeMedium,
+			FragmentSpeed: 60.0,
+			PickupChance:  0.2,
+			PickupValue:   20,
+		}
+	case AsteroidSizeMedium:
+		return AsteroidConfig{
+			Radius:        22.0,
+			Health:        50.0,
+			FragmentsMin:  2,
+			FragmentsMax:  3,
+			SplitInto:     AsteroidSizeSmall,
+			FragmentSpeed: 80.0,
+			PickupChance:  0.15,
+			PickupValue:   10,
+		}
+	default: // AsteroidSizeSmall
+		return AsteroidConfig{
+			Radius:       10.0,
+			Health:       15.0,
+			PickupChance: 0.1,
+			PickupValue:  5,
+		}
+	}
+}
+
+// NewAsteroid creates a neutral asteroid of the given size, tumbling at a random angle and speed
+func NewAsteroid(x, y float64, size AsteroidSize) *Entity {
+	asteroid := newAsteroid(x, y, size)
+	asteroid.Rotation = runRNG.Float64() * 2 * math.Pi
+	asteroid.AngularVelocity = (runRNG.Float64()*2 - 1) * AsteroidSpinSpeed
+	return asteroid
+}
+
+// newAsteroid creates a neutral asteroid of the given size without rolling its tumble
+func newAsteroid(x, y float64, size AsteroidSize) *Entity {
+	asteroidConfig := GetAsteroidConfig(size)
+	asteroid := NewEntity(x, y, asteroidConfig.Radius, EntityTypeAsteroid, nil)
+	asteroid.MaxHealth = asteroidConfig.Health
+	asteroid.Health = asteroidConfig.Health
+	asteroid.Faction = FactionNeutral
+	asteroid.Asteroid.Size = size
+	return asteroid
+}
+
+// spawnAsteroidField scatters large asteroids around the world center
+func (g *Game) spawnAsteroidField() {
+	centerX, centerY := g.arenaCenter()
+	for i := 0; i < AsteroidFieldCount; i++ {
+		angle := runRNG.Float64() * 2 * math.Pi
+		distance := AsteroidFieldMinDistance + runRNG.Float64()*(AsteroidFieldMaxDistance-AsteroidFieldMinDistance)
+		asteroid := NewAsteroid(centerX+math.Cos(angle)*distance, centerY+math.Sin(angle)*distance, AsteroidSizeLarge)
+
+		driftAngle := runRNG.Float64() * 2 * math.Pi
+		driftSpeed := runRNG.Float64() * AsteroidDriftSpeed
+		asteroid.VX = math.Cos(driftAngle) * driftSpeed
+		asteroid.VY = math.Sin(driftAngle) * driftSpeed
+		g.world.RegisterEntity(asteroid)
+	}
+}
+
+// breakAsteroid spawns the fragments and resource pickup of a destroyed asteroid
+func (g *Game) breakAsteroid(asteroid *Entity) {
+	asteroidConfig := GetAsteroidConfig(asteroid.Asteroid.Size)
+
+	// Fragments inherit the parent's momentum plus an outward spread
+	if asteroidConfig.FragmentsMax > 0 {
+		fragmentCount := asteroidConfig.FragmentsMin + runRNG.Intn(asteroidConfig.FragmentsMax-asteroidConfig.FragmentsMin+1)
+		fragmentRadius := GetAsteroidConfig(asteroidConfig.SplitInto).Radius
+		startAngle := runRNG.Float64() * 2 * math.Pi
+		for i := 0; i < fragmentCount; i++ {
+			angle := startAngle + float64(i)/float64(fragmentCount)*2*math.Pi + (runRNG.Float64()-0.5)*0.5
+			fragment := NewAsteroid(
+				asteroid.X+math.Cos(angle)*fragmentRadius,
+				asteroid.Y+math.Sin(angle)*fragmentRadius,
+				asteroidConfig.SplitInto,
+			)
+			speed := asteroidConfig.FragmentSpeed * (0.5 + runRNG.Float64()*0.5)
+			fragment.VX = asteroid.VX + math.Cos(angle)*speed
+			fragment.VY = asteroid.VY + math.Sin(angle)*speed
+			g.world.RegisterEntity(fragment)
+		}
+	}
+
+	// Occasionally release a resource pickup for the player
+	if g.player != nil && g.player.Active && runRNG.Float64() < asteroidConfig.PickupChance {
+		g.spawnPickup(asteroid.X, asteroid.Y, asteroidConfig.PickupValue, g.player, ScoreAsteroid)
+	}
+}
@@ -0,0 +1,55 @@
+package game
+
+import "math"
+
+// AttachTo attaches e to parent at (offsetX, offsetY) in the parent's frame, facing rotation relative to it
+// From then on its position and rotation follow the parent's every tick (see World.UpdateAttachments)
+func (e *Entity) AttachTo(parent *Entity, offsetX, offsetY, rotation float64) {
+	e.Parent = parent
+	e.AttachX, e.AttachY = offsetX, offsetY
+	e.AttachRotation = rotation
+	e.followParent()
+}
+
+// Detach frees e from its parent; it keeps the velocity it had as an attached part and drifts on its own
+func (e *Entity) Detach() {
+	e.Parent = nil
+}
+
+// followParent moves an attached entity to its place on its parent, turning and moving with it
+func (e *Entity) followParent() {
+	parent := e.Parent
+	cos, sin := math.Cos(parent.Rotation), math.Sin(parent.Rotation)
+	e.X = parent.X + e.AttachX*cos - e.AttachY*sin
+	e.Y = parent.Y + e.AttachX*sin + e.AttachY*cos
+	e.Rotation = parent.Rotation + e.AttachRotation
+	e.AngularVelocity = parent.AngularVelocity
+
+	// The part's velocity includes the spin of the parent around its center, so lead, impacts and
+	// the drift after detaching stay right
+	e.VX = parent.VX - parent.AngularVelocity*(e.Y-parent.Y)
+	e.VY = parent.VY + parent.AngularVelocity*(e.X-parent.X)
+}
+
+// UpdateAttachments moves every attached entity back onto its parent after physics moved them apart
+// Children of a destroyed or removed parent are detached and fly off with the velocity they had
+// Runs before entities are moved between cells and collisions are checked, so attached parts move as one piece
+func (w *World) UpdateAttachments() {
+	for _, entity := range w.AllEntities {
+		w.updateAttachment(entity)
+	}
+}
+
+// updateAttachment places one attached entity, placing its parent first when the parent is attached too
+func (w *World) updateAttachment(entity *Entity) {
+	parent := entity.Parent
+	if parent == nil {
+		return
+	}
+	if !parent.Active || parent.Health <= 0 {
+		entity.Detach()
+		return
+	}
+	w.updateAttachment(parent)
+	entity.followParent()
+}
@@ -0,0 +1,138 @@
+package game
+
+import "math"
+
+const (
+	// AutopilotCruiseSpeed is the speed the autopilot flies at (pixels per second)
+	AutopilotCruiseSpeed = MaxEntitySpeed * 0.9
+
+	// AutopilotLookahead is how far ahead the autopilot checks for obstacles, in seconds of travel at cruise speed
+	AutopilotLookahead = 1.5
+
+	// AutopilotClearance is the extra space kept between the ship and an obstacle it steers around (pixels)
+	AutopilotClearance = 60.0
+
+	// AutopilotFacingTolerance is how far off the wanted heading the autopilot still thrusts (radians)
+	AutopilotFacingTolerance = math.Pi / 6
+)
+
+// Autopilot flies the player toward the waypoint while engaged
+// It feeds the player's controls like a scripted frame, so the ship flies under normal physics
+type Autopilot struct {
+	Engaged bool
+
+	// Controls for the current tick (-1 to 1, like the keys)
+	Thrust, Rotation float64
+
+	// Hull when last checked; taking damage disengages
+	health float64
+}
+
+// toggleAutopilot engages the autopilot toward the waypoint, or disengages it
+func (g *Game) toggleAutopilot() {
+	if g.player == nil || !g.player.Active {
+		return
+	}
+	input, ok := g.player.Input.(*PlayerInput)
+	if !ok {
+		return
+	}
+	autopilot := &input.autopilot
+	if autopilot.Engaged || g.waypoint == nil {
+		*autopilot = Autopilot{}
+		return
+	}
+	*autopilot = Autopilot{Engaged: true, health: g.player.Health}
+}
+
+// autopilotEngaged returns true while the autopilot is flying the player
+func (g *Game) autopilotEngaged() bool {
+	if g.player == nil {
+		return false
+	}
+	input, ok := g.player.Input.(*PlayerInput)
+	return ok && input.autopilot.Engaged
+}
+
+// updateAutopilot steers the player toward the waypoint around obstacles
+// It disengages when the waypoint is reached or cleared, on damage, and on any manual steering
+func (g *Game) updateAutopilot() {
+	if g.player == nil {
+		return
+	}
+	input, ok := g.player.Input.(*PlayerInput)
+	if !ok || !input.autopilot.Engaged {
+		return
+	}
+	autopilot := &input.autopilot
+	thrust, rotation := input.manualControls()
+	if g.waypoint == nil || !g.player.Active || g.player.Health < autopilot.health || thrust != 0 || rotation != 0 {
+		*autopilot = Autopilot{}
+		return
+	}
+	autopilot.health = g.player.Health
+
+	player := g.player
+	dx := g.waypoint.X - player.X
+	dy := g.waypoint.Y - player.Y
+	distance := math.Hypot(dx, dy)
+	dirX, dirY := dx/distance, dy/distance
+	dirX, dirY = g.avoidObstacles(player, dirX, dirY, math.Min(distance, AutopilotCruiseSpeed*AutopilotLookahead))
+
+	// Ease off when close so friction brings the ship to a stop at the waypoint
+	speed := math.Min(AutopilotCruiseSpeed, distance)
+
+	// Thrust along the difference between the wanted and current velocity, so drift gets cancelled
+	correctionX := dirX*speed - player.VX
+	correctionY := dirY*speed - player.VY
+	if math.Hypot(correctionX, correctionY) < 10 {
+		autopilot.Thrust = 0
+		autopilot.Rotation = math.Max(-1, math.Min(1, -player.AngularVelocity))
+		return
+	}
+	angleDiff := math.Remainder(math.Atan2(correctionY, correctionX)-player.Rotation, 2*math.Pi)
+
+	// Turn toward the heading, damped by the current spin so the ship doesn't overshoot
+	autopilot.Rotation = math.Max(-1, math.Min(1, angleDiff*2-player.AngularVelocity*0.5))
+	autopilot.Thrust = 0
+	if math.Abs(angleDiff) < AutopilotFacingTolerance {
+		autopilot.Thrust = 1
+	}
+}
+
+// avoidObstacles returns the direction to fly instead of (dirX, dirY) when something blocks the next lookahead pixels
+// The ship aims past the blocking obstacle on the side it's already on
+func (g *Game) avoidObstacles(ship *Entity, dirX, dirY, lookahead float64) (float64, float64) {
+	hit, blocked := g.world.Raycast(ship.X, ship.Y, ship.X+dirX*lookahead, ship.Y+dirY*lookahead, func(entity *Entity) bool {
+		return entity != ship && isAutopilotObstacle(entity)
+	})
+	if !blocked {
+		return dirX, dirY
+	}
+
+	obstacle := hit.Entity
+	toObstacleX := obstacle.X - ship.X
+	toObstacleY := obstacle.Y - ship.Y
+	side := 1.0
+	if dirX*toObstacleY-dirY*toObstacleX > 0 {
+		side = -1.0 // Obstacle is to the right of the path, pass it on the left
+	}
+	passDistance := obstacle.Radius + ship.Radius + AutopilotClearance
+	aimX := obstacle.X - dirY*side*passDistance - ship.X
+	aimY := obstacle.Y + dirX*side*passDistance - ship.Y
+	length := math.Hypot(aimX, aimY)
+	if length == 0 {
+		return dirX, dirY
+	}
+	return aimX / length, aimY / length
+}
+
+// isAutopilotObstacle returns true for entities the autopilot steers around (solid bodies, not shots or pickups)
+func isAutopilotObstacle(entity *Entity) bool {
+	switch entity.Type {
+	case EntityTypeAsteroid, EntityTypeStation, EntityTypeEnemy, EntityTypePlayer:
+		return !entity.NoCollision
+	default:
+		return false
+	}
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
+)
+
+const (
+	// BloomThreshold is the brightness (0-1) above which parts of the world start to glow
+	BloomThreshold = 0.6
+
+	// BloomIntensity is how strongly the blurred glow is added on top of the world
+	BloomIntensity = 0.8
+)
+
+// bloomBlurWeights are the taps of the separable blur, centered on the pixel (they sum to 1)
+var bloomBlurWeights = [...]float32{1.0 / 16, 4.0 / 16, 6.0 / 16, 4.0 / 16, 1.0 / 16}
+
+// BloomQuality selects how the bloom pass is done, trading looks for fill rate
+type BloomQuality int
+
+const (
+	BloomOff  BloomQuality = iota // No post-processing, the world is drawn straight to the screen
+	BloomLow                      // Quarter resolution glow, one blur pass
+	BloomHigh                     // Half resolution glow, two blur passes
+	bloomQualityCount
+)
+
+// bloomQualityNames are the names used by the -bloom flag
+var bloomQualityNames = [bloomQualityCount]string{"off", "low", "high"}
+
+// String returns the quality's flag name
+func (q BloomQuality) String() string {
+	if q < 0 || q >= bloomQualityCount {
+		return bloomQualityNames[BloomOff]
+	}
+	return bloomQualityNames[q]
+}
+
+// ParseBloomQuality parses a bloom quality name ("off", "low" or "high")
+func ParseBloomQuality(name string) (BloomQuality, error) {
+	for quality, qualityName := range bloomQualityNames {
+		if name == qualityName {
+			return BloomQuality(quality), nil
+		}
+	}
+	return BloomOff, fmt.Errorf("unknown bloom quality %q", name)
+}
+
+// downsample returns the factor the glow buffers are shrunk by
+func (q BloomQuality) downsample() int {
+	if q == BloomHigh {
+		return 2
+	}
+	return 4
+}
+
+// blurPasses returns how many times the glow is blurred
+func (q BloomQuality) blurPasses() int {
+	if q == BloomHigh {
+		return 2
+	}
+	return 1
+}
+
+// bloomEnabled returns true if the world should be drawn through the bloom pass
+// Low-end mode bypasses it whatever the quality setting
+func bloomEnabled() bool {
+	settings := GetSettings()
+	return !settings.LowEnd && settings.Bloom != BloomOff
+}
+
+// Bloom makes bright parts of the world (engine trails, lasers, explosions) glow
+// The world is drawn to an offscreen scene, its bright areas extracted at low resolution and blurred,
+// and the result added back on top with BlendLighter
+type Bloom struct {
+	scene   *ebiten.Image // The world as drawn this frame
+	glow    *ebiten.Image // Bright pass, and the blur's vertical pass output
+	scratch *ebiten.Image // The blur's horizontal pass output
+
+	// Quality the glow buffers were made for
+	quality BloomQuality
+}
+
+// Begin returns the offscreen image the world should be drawn to this frame, starting from what's on screen
+func (b *Bloom) Begin(screen *ebiten.Image) *ebiten.Image {
+	quality := GetSettings().Bloom
+	bounds := screen.Bounds()
+	if b.scene == nil || b.scene.Bounds() != bounds || b.quality != quality {
+		b.deallocate()
+		scale := quality.downsample()
+		width := max(bounds.Dx()/scale, 1)
+		height := max(bounds.Dy()/scale, 1)
+		b.scene = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+		b.glow = ebiten.NewImage(width, height)
+		b.scratch = ebiten.NewImage(width, height)
+		b.quality = quality
+	}
+	b.scene.DrawImage(screen, &ebiten.DrawImageOptions{Blend: ebiten.BlendCopy})
+	return b.scene
+}
+
+// Apply draws the scene back onto screen with its glow on top
+// It returns the number of draw calls made
+func (b *Bloom) Apply(screen *ebiten.Image) int {
+	screen.DrawImage(b.scene, nil)
+	drawCalls := 1
+
+	// Bright pass: remap brightness so only what's above the threshold is left, downsampling on the way
+	var brightness colorm.ColorM
+	gain := 1 / (1 - BloomThreshold)
+	brightness.Scale(gain, gain, gain, 1)
+	brightness.Translate(-BloomThreshold*gain, -BloomThreshold*gain, -BloomThreshold*gain, 0)
+	scale := float64(b.quality.downsample())
+	brightOp := &colorm.DrawImageOptions{}
+	brightOp.GeoM.Scale(1/scale, 1/scale)
+	brightOp.Filter = ebiten.FilterLinear
+	b.glow.Clear()
+	colorm.DrawImage(b.glow, b.scene, brightness, brightOp)
+	drawCalls++
+
+	for i := 0; i < b.quality.blurPasses(); i++ {
+		drawCalls += blurInto(b.scratch, b.glow, 1, 0)
+		drawCalls += blurInto(b.glow, b.scratch, 0, 1)
+	}
+
+	// Scale the glow back up over the scene
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.Filter = ebiten.FilterLinear
+	op.ColorScale.Scale(BloomIntensity, BloomIntensity, BloomIntensity, BloomIntensity)
+	op.Blend = ebiten.BlendLighter
+	screen.DrawImage(b.glow, op)
+	return drawCalls + 1
+}
+
+// blurInto blurs src along the (dx, dy) direction into dst, by adding weighted, shifted copies of it
+// It returns the number of draw calls made
+func blurInto(dst, src *ebiten.Image, dx, dy float64) int {
+	dst.Clear()
+	center := len(bloomBlurWeights) / 2
+	for i, weight := range bloomBlurWeights {
+		offset := float64(i - center)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(offset*dx, offset*dy)
+		op.ColorScale.Scale(weight, weight, weight, weight)
+		op.Blend = ebiten.BlendLighter
+		dst.DrawImage(src, op)
+	}
+	return len(bloomBlurWeights)
+}
+
+// deallocate frees the offscreen buffers
+func (b *Bloom) deallocate() {
+	for _, image := range []*ebiten.Image{b.scene, b.glow, b.scratch} {
+		if image != nil {
+			image.Deallocate()
+		}
+	}
+	b.scene, b.glow, b.scratch = nil, nil, nil
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// CameraMinStiffness and CameraMaxStiffness bound how quickly the camera catches up (per second)
+	CameraMinStiffness = 1.0
+	CameraMaxStiffness = 30.0
+
+	// CameraMaxLeadTime bounds how far ahead of the player's motion the lead camera looks (seconds)
+	CameraMaxLeadTime = 2.0
+)
+
+// CameraMode selects how the camera follows the player
+type CameraMode int
+
+const (
+	CameraTight CameraMode = iota // Follows the player itself
+	CameraLead                    // Looks ahead of the player's movement
+	CameraMouse                   // Shifts toward the cursor
+	cameraModeCount
+)
+
+// cameraModeNames are the names shown when cycling the camera mode
+var cameraModeNames = [cameraModeCount]string{"Tight", "Lead", "Mouse"}
+
+// String returns the mode's display name
+func (m CameraMode) String() string {
+	if m < 0 || m >= cameraModeCount {
+		return cameraModeNames[CameraTight]
+	}
+	return cameraModeNames[m]
+}
+
+// Next returns the following mode, wrapping around (used to cycle it with F9)
+func (m CameraMode) Next() CameraMode {
+	return (m + 1) % cameraModeCount
+}
+
+// CameraSettings holds the camera follow mode and the tuning of each mode
+// Stiffness is the rate the camera closes the distance to its target (per second): higher is snappier
+type CameraSettings struct {
+	Mode CameraMode // F9 cycles
+
+	TightStiffness float64
+
+	LeadTime      float64 // The lead camera aims where the player will be in this many seconds
+	LeadStiffness float64
+
+	MouseBias      float64 // Fraction of the cursor's offset from the screen center the camera shifts by (0-1)
+	MouseStiffness float64
+}
+
+// DefaultCameraSettings returns the default camera settings (a tight follow with the original feel)
+func DefaultCameraSettings() CameraSettings {
+	return CameraSettings{
+		Mode:           CameraTight,
+		TightStiffness: 6.3, // Closes 10% of the distance per tick at 60 TPS
+		LeadTime:       0.5,
+		LeadStiffness:  3.0,
+		MouseBias:      0.3,
+		MouseStiffness: 5.0,
+	}
+}
+
+// Sanitize clamps the camera settings to valid ranges
+func (c *CameraSettings) Sanitize() {
+	if c.Mode < 0 || c.Mode >= cameraModeCount {
+		c.Mode = CameraTight
+	}
+	c.TightStiffness = math.Max(CameraMinStiffness, math.Min(c.TightStiffness, CameraMaxStiffness))
+	c.LeadStiffness = math.Max(CameraMinStiffness, math.Min(c.LeadStiffness, CameraMaxStiffness))
+	c.MouseStiffness = math.Max(CameraMinStiffness, math.Min(c.MouseStiffness, CameraMaxStiffness))
+	c.LeadTime = math.Max(0, math.Min(c.LeadTime, CameraMaxLeadTime))
+	c.MouseBias = math.Max(0, math.Min(c.MouseBias, 1))
+}
+
+// followTarget returns where the camera should center and how stiffly it moves there
+// Mouse bias only applies to the player with the mouse; others get a tight follow
+func (c *CameraSettings) followTarget(player *Entity, camera *Camera, mouse bool) (float64, float64, float64) {
+	switch {
+	case c.Mode == CameraLead:
+		return player.X + player.VX*c.LeadTime, player.Y + player.VY*c.LeadTime, c.LeadStiffness
+	case c.Mode == CameraMouse && mouse:
+		cursorX, cursorY := ebiten.CursorPosition()
+		offsetX := (float64(cursorX) - camera.Width/2) / camera.Zoom
+		offsetY := (float64(cursorY) - camera.Height/2) / camera.Zoom
+		return player.X + offsetX*c.MouseBias, player.Y + offsetY*c.MouseBias, c.MouseStiffness
+	default:
+		return player.X, player.Y, c.TightStiffness
+	}
+}
+
+// updateCamera moves the player's camera (and the co-op player's) toward its follow target
+func (g *Game) updateCamera(deltaTime float64) {
+	if g.player != nil && g.player.Active {
+		followCamera(g.camera, g.player, true, deltaTime)
+	}
+	if g.splitScreen == nil {
+		return
+	}
+
+	// A dead co-op player watches their partner
+	if coop := g.splitScreen.Player; coop != nil && coop.Active {
+		followCamera(g.splitScreen.camera, coop, false, deltaTime)
+	} else if g.player != nil && g.player.Active {
+		followCamera(g.splitScreen.camera, g.player, false, deltaTime)
+	}
+}
+
+// followCamera moves a camera toward its follow target around the ship
+// The catch-up is exponential in time, so the camera feels the same at any tick rate and game speed
+func followCamera(camera *Camera, ship *Entity, mouse bool, deltaTime float64) {
+	targetX, targetY, stiffness := GetSettings().Camera.followTarget(ship, camera, mouse)
+	blend := 1 - math.Exp(-stiffness*deltaTime)
+	camera.X += (targetX - camera.X) * blend
+	camera.Y += (targetY - camera.Y) * blend
+}
@@ -0,0 +1,117 @@
+package game
+
+import "math"
+
+// CapitalSectionInput is the input of a capital ship hull section: it never steers (it's welded to the core)
+// and fires when one of its turrets has a clear shot
+type CapitalSectionInput struct {
+	shoot bool
+}
+
+// GetThrust returns 0: sections are carried by the core
+func (c *CapitalSectionInput) GetThrust() float64 {
+	return 0
+}
+
+// GetRotation returns 0: sections turn with the core
+func (c *CapitalSectionInput) GetRotation() float64 {
+	return 0
+}
+
+// ShouldShoot returns true when a turret of the section has a clear shot
+func (c *CapitalSectionInput) ShouldShoot() bool {
+	return c.shoot
+}
+
+// HasTarget returns true when a turret of the section has a target
+func (c *CapitalSectionInput) HasTarget() bool {
+	return c.shoot
+}
+
+// Update does nothing: updateCapitalShips aims the section's turrets
+func (c *CapitalSectionInput) Update(deltaTime float64) {
+}
+
+// capitalSection describes one hull section of a capital ship: where it's welded on the core and its turret
+type capitalSection struct {
+	OffsetX, OffsetY float64
+	Turret           TurretMountPoint
+}
+
+// capitalSections is the layout of a capital ship: a bow launcher, broadside batteries and a rear gun
+// Turret arcs are relative to the section, which faces the same way as the core
+var capitalSections = []capitalSection{
+	{OffsetX: 44, OffsetY: 0, Turret: TurretMountPoint{OffsetX: 8, Active: true, BarrelLength: 12, WeaponType: WeaponTypeHomingMissile, ArcMin: -math.Pi / 3, ArcMax: math.Pi / 3}},
+	{OffsetX: 0, OffsetY: -40, Turret: TurretMountPoint{OffsetY: -6, Angle: -math.Pi / 2, Active: true, BarrelLength: 14, WeaponType: WeaponTypeBullet, ArcMin: -math.Pi * 11 / 12, ArcMax: -math.Pi / 12}},
+	{OffsetX: 0, OffsetY: 40, Turret: TurretMountPoint{OffsetY: 6, Angle: math.Pi / 2, Active: true, BarrelLength: 14, WeaponType: WeaponTypeBullet, ArcMin: math.Pi / 12, ArcMax: math.Pi * 11 / 12}},
+	{OffsetX: -44, OffsetY: 0, Turret: TurretMountPoint{OffsetX: -8, Angle: math.Pi, Active: true, BarrelLength: 12, WeaponType: WeaponTypeBullet, ArcMin: math.Pi * 2 / 3, ArcMax: math.Pi * 4 / 3}},
+}
+
+// CapitalShip is a core ship with hull sections welded to it
+// Each section is its own entity with its own health, collision and turret; destroying a section takes
+// its turret with it, destroying the core destroys the whole ship
+type CapitalShip struct {
+	Core     *Entity
+	Sections []*Entity
+}
+
+// spawnCapitalShip spawns a capital ship core at (x, y) with every section of capitalSections welded on
+func (g *Game) spawnCapitalShip(x, y float64) {
+	core := NewEntityWithShipType(x, y, EntityTypeEnemy, ShipTypeCapitalCore, CreateEnemyAIWithType(EnemyTypeCapital))
+	core.Faction = FactionEnemy
+	g.world.RegisterEntity(core)
+
+	capital := &CapitalShip{Core: core}
+	for _, layout := range capitalSections {
+		section := NewEntityWithShipType(x, y, EntityTypeEnemy, ShipTypeCapitalSection, &CapitalSectionInput{})
+		section.Faction = core.Faction
+		section.Turrets[0].Mount = layout.Turret
+		section.AttachTo(core, layout.OffsetX, layout.OffsetY, 0)
+		g.world.RegisterEntity(section)
+		capital.Sections = append(capital.Sections, section)
+	}
+	g.capitals = append(g.capitals, capital)
+}
+
+// updateCapitalShips aims the sections' turrets at the core's target
+// A destroyed core takes its remaining sections down with it
+func (g *Game) updateCapitalShips(deltaTime float64) {
+	liveShips := g.capitals[:0]
+	for _, capital := range g.capitals {
+		core := capital.Core
+		coreAlive := core.Active && core.Health > 0
+
+		live := capital.Sections[:0]
+		for _, section := range capital.Sections {
+			if !section.Active || section.Health <= 0 {
+				continue // Shot off: removed by the update loop
+			}
+			if !coreAlive {
+				section.Health = 0
+				continue
+			}
+			live = append(live, section)
+
+			var target *Entity
+			if aiInput, ok := core.Input.(*AIInput); ok {
+				target = aiInput.TargetEntity
+			}
+			updateAITurrets(section, target, g.world, deltaTime)
+			input := section.Input.(*CapitalSectionInput)
+			input.shoot = false
+			for i := range section.Turrets {
+				if section.Turrets[i].Target.HasTarget {
+					input.shoot = true
+				}
+			}
+		}
+		clear(capital.Sections[len(live):])
+		capital.Sections = live
+
+		if coreAlive {
+			liveShips = append(liveShips, capital)
+		}
+	}
+	clear(g.capitals[len(liveShips):])
+	g.capitals = liveShips
+}
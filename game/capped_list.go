@@ -0,0 +1,65 @@
+package game
+
+// CappedList holds entities that live outside the spatial grid (FX, debris), oldest first
+// Adding to a full list evicts the oldest entry, so a busy fight can't grow it without bound
+type CappedList []*Entity
+
+// pinned returns true for entries eviction skips, which only expire with their lifetime
+// Salvage is worth credits and team pings carry orders, so a burst of sparks or debris mustn't push them out
+func pinned(entity *Entity) bool {
+	return isSalvage(entity) || (entity.Type == EntityTypeDestroyedIndicator && isPingKind(entity.Indicator.Kind))
+}
+
+// add appends an entity, evicting the oldest entry that isn't pinned first if the list already holds limit entries
+// Pinned entries can take the list past its limit; they're few and short-lived
+func (l *CappedList) add(w *World, entity *Entity, limit int) {
+	if len(*l) >= limit {
+		for _, oldest := range *l {
+			if !pinned(oldest) {
+				oldest.Active = false
+				w.UnregisterEntity(oldest)
+				break
+			}
+		}
+	}
+	*l = append(*l, entity)
+}
+
+// remove drops an entity, keeping the rest in spawn order
+func (l *CappedList) remove(entity *Entity) {
+	list := *l
+	for i, e := range list {
+		if e == entity {
+			copy(list[i:], list[i+1:])
+			list[len(list)-1] = nil
+			*l = list[:len(list)-1]
+			return
+		}
+	}
+}
+
+// update ages the entries and removes the ones whose lifetime has expired
+// step (if not nil) then runs on each entry still alive
+func (l *CappedList) update(w *World, deltaTime float64, step func(*Entity)) {
+	list := *l
+	live := list[:0]
+	for _, entity := range list {
+		entity.Age += deltaTime
+		if !entity.Active || (entity.Lifetime > 0 && entity.Age >= entity.Lifetime) {
+			entity.Active = false
+			if w.entitiesByID[entity.ID] == entity {
+				delete(w.entitiesByID, entity.ID)
+			}
+			continue
+		}
+		if step != nil {
+			step(entity)
+		}
+		live = append(live, entity)
+	}
+	// Clear the tail so expired entities can be garbage collected
+	for i := len(live); i < len(list); i++ {
+		list[i] = nil
+	}
+	*l = live
+}
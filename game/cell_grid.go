@@ -0,0 +1,130 @@
+package game
+
+const (
+	// cellChunkShift sets the chunk size: chunks are 1<<cellChunkShift cells on a side
+	cellChunkShift = 3
+	cellChunkSize  = 1 << cellChunkShift
+	cellChunkMask  = cellChunkSize - 1
+
+	// cellInitialCapacity is the entity capacity of a newly allocated cell (cells grow as needed)
+	cellInitialCapacity = 16
+
+	// maxFreeCellChunks is the number of emptied chunks kept for reuse
+	maxFreeCellChunks = 64
+)
+
+// cellChunkKey identifies a chunk by its position in chunks (cell coordinates >> cellChunkShift)
+type cellChunkKey struct {
+	x, y int
+}
+
+// cellChunk is a square block of cells, allocated when the first entity enters it
+type cellChunk struct {
+	cells [cellChunkSize * cellChunkSize]*Cell // Each cell is allocated on first use
+	count int                                  // Entities in the chunk's cells
+}
+
+// cellGrid is the world's sparse spatial grid
+// Only chunks that contain entities exist, so memory follows the entities instead of the world size
+// and a huge world costs no more than a small one with the same population
+type cellGrid struct {
+	chunks map[cellChunkKey]*cellChunk
+
+	// Emptied chunks (cells and their capacity kept), so ships crossing a chunk border don't churn allocations
+	free []*cellChunk
+
+	// Last chunk looked up: queries walk neighbouring cells, which mostly share a chunk
+	lastKey   cellChunkKey
+	lastChunk *cellChunk
+}
+
+// newCellGrid creates an empty grid
+func newCellGrid() cellGrid {
+	return cellGrid{chunks: make(map[cellChunkKey]*cellChunk, 64)}
+}
+
+// chunk returns the chunk holding a cell, or nil if it isn't allocated
+func (g *cellGrid) chunk(key cellChunkKey) *cellChunk {
+	if g.lastChunk != nil && g.lastKey == key {
+		return g.lastChunk
+	}
+	chunk := g.chunks[key]
+	if chunk != nil {
+		g.lastKey = key
+		g.lastChunk = chunk
+	}
+	return chunk
+}
+
+// cell returns the cell at the given cell coordinates, or nil if it holds no entities and never has
+func (g *cellGrid) cell(cellX, cellY int) *Cell {
+	chunk := g.chunk(cellChunkKey{cellX >> cellChunkShift, cellY >> cellChunkShift})
+	if chunk == nil {
+		return nil
+	}
+	return chunk.cells[(cellY&cellChunkMask)<<cellChunkShift|cellX&cellChunkMask]
+}
+
+// add puts an entity in a cell, allocating the chunk and cell if needed
+func (g *cellGrid) add(cellX, cellY int, entity *Entity) {
+	key := cellChunkKey{cellX >> cellChunkShift, cellY >> cellChunkShift}
+	chunk := g.chunk(key)
+	if chunk == nil {
+		if n := len(g.free); n > 0 {
+			chunk = g.free[n-1]
+			g.free[n-1] = nil
+			g.free = g.free[:n-1]
+		} else {
+			chunk = &cellChunk{}
+		}
+		g.chunks[key] = chunk
+	}
+
+	index := (cellY&cellChunkMask)<<cellChunkShift | cellX&cellChunkMask
+	cell := chunk.cells[index]
+	if cell == nil {
+		cell = NewCell(cellInitialCapacity)
+		chunk.cells[index] = cell
+	}
+	count := cell.Count
+	cell.AddEntity(entity)
+	chunk.count += cell.Count - count // AddEntity ignores duplicates
+}
+
+// eachOccupiedCell calls fn for every cell holding at least one entity
+func (g *cellGrid) eachOccupiedCell(fn func(cell *Cell)) {
+	for _, chunk := range g.chunks {
+		for _, cell := range chunk.cells {
+			if cell != nil && cell.Count > 0 {
+				fn(cell)
+			}
+		}
+	}
+}
+
+// remove takes an entity out of a cell, releasing the chunk once it is empty
+func (g *cellGrid) remove(cellX, cellY int, entity *Entity) {
+	key := cellChunkKey{cellX >> cellChunkShift, cellY >> cellChunkShift}
+	chunk := g.chunk(key)
+	if chunk == nil {
+		return
+	}
+	cell := chunk.cells[(cellY&cellChunkMask)<<cellChunkShift|cellX&cellChunkMask]
+	if cell == nil {
+		return
+	}
+	count := cell.Count
+	cell.RemoveEntity(entity)
+	chunk.count -= count - cell.Count
+	if chunk.count > 0 {
+		return
+	}
+
+	delete(g.chunks, key)
+	if g.lastChunk == chunk {
+		g.lastChunk = nil
+	}
+	if len(g.free) < maxFreeCellChunks {
+		g.free = append(g.free, chunk)
+	}
+}
@@ -0,0 +1,185 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// CheckpointInterval is the number of waves between two checkpoints (one is saved as waves 6, 11, 16... start)
+	CheckpointInterval = 5
+
+	// checkpointFileName is the name of the checkpoint file, next to the settings file
+	checkpointFileName = "checkpoint.json"
+)
+
+// Checkpoint is what the run had at the start of a checkpoint wave: a dead player can restart from here
+// instead of wave 1. The world itself isn't saved; the checkpoint wave starts in a fresh one
+type Checkpoint struct {
+	Difficulty Difficulty // A saved checkpoint is only offered to runs on the same difficulty
+	Wave       int
+	Score      int
+	Credits    int
+
+	// Score breakdown and purchases, so the death recap carries on from the checkpoint
+	ScoreSources [scoreSourceCount]int
+	Upgrades     []RecapUpgrade
+
+	// The player's ship upgrades: weapon per turret, sensor and salvage beam level, drones and sentry charges
+	Weapons     []WeaponType
+	Sensors     int
+	SalvageBeam int
+	Drones      int
+	Sentries    int
+
+	// The player's standing with the factions that take sides, saved with the rest of the checkpoint
+	Reputation Reputation
+}
+
+// CheckpointsAllowed returns true if the difficulty offers checkpoints (hard runs always start over from wave 1)
+func CheckpointsAllowed(difficulty Difficulty) bool {
+	return difficulty != DifficultyHard
+}
+
+// saveCheckpoint records a checkpoint when a checkpoint wave starts
+func (g *Game) saveCheckpoint() {
+	if g.waveNumber <= 1 || (g.waveNumber-1)%CheckpointInterval != 0 {
+		return
+	}
+	if !CheckpointsAllowed(g.config.Difficulty) || g.player == nil || !g.player.Active {
+		return
+	}
+	checkpoint := &Checkpoint{
+		Difficulty:   g.config.Difficulty,
+		Wave:         g.waveNumber,
+		Score:        g.score,
+		Credits:      g.credits,
+		ScoreSources: g.recap.Score,
+		Upgrades:     append([]RecapUpgrade(nil), g.recap.Upgrades...),
+		Weapons:      shipWeapons(g.player),
+		Sensors:      g.player.Sensors,
+		SalvageBeam:  g.player.SalvageBeam,
+		Drones:       g.player.Drones,
+		Sentries:     g.player.Sentries,
+		Reputation:   g.world.Reputation,
+	}
+	g.setCheckpoint(checkpoint)
+	fmt.Printf("Checkpoint reached at wave %d (score %d)\n", checkpoint.Wave, checkpoint.Score)
+}
+
+// loadCheckpoint restarts the run from the last checkpoint: a new world, the checkpoint's score, credits and
+// upgrades, and its wave. The checkpoint stays available for the next death
+func (g *Game) loadCheckpoint() {
+	checkpoint := g.checkpoint
+	if checkpoint == nil {
+		return
+	}
+	g.respawnPlayer(0)
+	g.setCheckpoint(checkpoint)
+
+	g.score = checkpoint.Score
+	g.credits = checkpoint.Credits
+	g.recap.Score = checkpoint.ScoreSources
+	g.recap.Upgrades = append(g.recap.Upgrades, checkpoint.Upgrades...)
+	g.world.Reputation = checkpoint.Reputation
+	if g.player != nil {
+		g.player.Sensors = checkpoint.Sensors
+		g.player.SalvageBeam = checkpoint.SalvageBeam
+		g.player.Drones = checkpoint.Drones
+		g.player.Sentries = checkpoint.Sentries
+		equipWeapons(g.player, checkpoint.Weapons)
+	}
+	g.startWave(checkpoint.Wave)
+	fmt.Printf("Restarted from the wave %d checkpoint\n", checkpoint.Wave)
+}
+
+// setCheckpoint replaces the run's checkpoint (nil drops it), keeping the checkpoint file in step if it's enabled
+func (g *Game) setCheckpoint(checkpoint *Checkpoint) {
+	g.checkpoint = checkpoint
+	if !g.checkpointFile {
+		return
+	}
+	if err := SaveCheckpointFile(checkpoint); err != nil {
+		fmt.Printf("Failed to save checkpoint: %v\n", err)
+	}
+}
+
+// EnableCheckpointFile keeps the run's checkpoint in the checkpoint file, so it survives quitting the game,
+// and offers the saved one (if it was reached on this difficulty) on the death screen
+func (g *Game) EnableCheckpointFile() {
+	g.checkpointFile = true
+	if !CheckpointsAllowed(g.config.Difficulty) {
+		return
+	}
+	checkpoint, err := LoadCheckpointFile()
+	if err != nil {
+		fmt.Printf("Ignoring saved checkpoint: %v\n", err)
+		return
+	}
+	if checkpoint != nil && checkpoint.Difficulty == g.config.Difficulty {
+		g.checkpoint = checkpoint
+	}
+}
+
+// checkpointPath returns the full path of the checkpoint file
+func checkpointPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(configDir, settingsDirName, checkpointFileName), nil
+}
+
+// LoadCheckpointFile reads the checkpoint file
+// A missing file is not an error; it returns nil
+func LoadCheckpointFile() (*Checkpoint, error) {
+	path, err := checkpointPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	checkpoint.Reputation.Sanitize()
+	return checkpoint, nil
+}
+
+// SaveCheckpointFile writes a checkpoint to the checkpoint file; nil removes the file
+func SaveCheckpointFile(checkpoint *Checkpoint) error {
+	path, err := checkpointPath()
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove checkpoint: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Checksum hashes the ID, type, position and health of every active entity, in ID order
+// Two worlds with the same checksum are (almost certainly) in the same state, which makes it cheap
+// to compare replays, retried seeds or peers tick by tick. FX entities are visual only and not included
+func (w *World) Checksum() uint64 {
+	hash := fnv.New64a()
+	buf := make([]byte, 0, 40)
+	for _, state := range w.checksumStates() {
+		buf = binary.LittleEndian.AppendUint64(buf[:0], state.ID)
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(state.Type))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(state.X))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(state.Y))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(state.Health))
+		hash.Write(buf)
+	}
+	return hash.Sum64()
+}
+
+// checksumStates returns the states of the active entities, ordered by ID
+func (w *World) checksumStates() []EntityState {
+	states := make([]EntityState, 0, len(w.AllEntities))
+	for _, entity := range w.AllEntities {
+		if entity.Active {
+			states = append(states, captureEntityState(entity))
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ID < states[j].ID
+	})
+	return states
+}
+
+// Divergence is the first entity that differs between two worlds (see DiffWorlds)
+type Divergence struct {
+	ID   uint64
+	A, B *EntityState // nil if the entity only exists in the other world
+}
+
+// String describes the difference
+func (d Divergence) String() string {
+	switch {
+	case d.A == nil:
+		return fmt.Sprintf("entity %d (type %v) only exists in the second world", d.ID, d.B.Type)
+	case d.B == nil:
+		return fmt.Sprintf("entity %d (type %v) only exists in the first world", d.ID, d.A.Type)
+	case d.A.Type != d.B.Type:
+		return fmt.Sprintf("entity %d is type %v in the first world and %v in the second", d.ID, d.A.Type, d.B.Type)
+	default:
+		return fmt.Sprintf("entity %d (type %v) at (%g, %g) health %g vs (%g, %g) health %g",
+			d.ID, d.A.Type, d.A.X, d.A.Y, d.A.Health, d.B.X, d.B.Y, d.B.Health)
+	}
+}
+
+// DiffWorlds returns the lowest-ID entity whose checksummed state differs between two worlds
+// (false if the worlds match, in which case their checksums are equal)
+func DiffWorlds(a, b *World) (Divergence, bool) {
+	statesA, statesB := a.checksumStates(), b.checksumStates()
+	i, j := 0, 0
+	for i < len(statesA) || j < len(statesB) {
+		switch {
+		case j >= len(statesB) || (i < len(statesA) && statesA[i].ID < statesB[j].ID):
+			return Divergence{ID: statesA[i].ID, A: &statesA[i]}, true
+		case i >= len(statesA) || statesB[j].ID < statesA[i].ID:
+			return Divergence{ID: statesB[j].ID, B: &statesB[j]}, true
+		}
+		stateA, stateB := &statesA[i], &statesB[j]
+		if stateA.Type != stateB.Type || stateA.X != stateB.X || stateA.Y != stateB.Y || stateA.Health != stateB.Health {
+			return Divergence{ID: stateA.ID, A: stateA, B: stateB}, true
+		}
+		i++
+		j++
+	}
+	return Divergence{}, false
+}
+
+// logChecksum prints the world checksum every Config.ChecksumInterval ticks (0 = off)
+func (g *Game) logChecksum() {
+	interval := uint64(g.config.ChecksumInterval)
+	if interval == 0 || g.tickCount%interval != 0 {
+		return
+	}
+	fmt.Printf("Tick %d checksum %016x\n", g.tickCount, g.world.Checksum())
+}
@@ -0,0 +1,96 @@
+package game
+
+import "math"
+
+const (
+	// SensorBaseRange is how close a cloaked ship must be for the player to detect it (pixels)
+	SensorBaseRange = 250.0
+
+	// SensorRangePerLevel is the detection range each sensor upgrade adds (pixels)
+	SensorRangePerLevel = 125.0
+
+	// SensorMaxLevel is the number of sensor upgrades the player can buy
+	SensorMaxLevel = 3
+
+	// SensorUpgradePrice is the price of one sensor upgrade at a station shop
+	SensorUpgradePrice = 150
+
+	// CloakAttackRange: a cloaked ship decloaks to attack when its target is this close (pixels)
+	CloakAttackRange = 450.0
+
+	// CloakRecloakRange: a decloaked ship cloaks again once its target is farther than this (pixels)
+	CloakRecloakRange = 700.0
+
+	// CloakRecloakDelay is how long after its last shot a ship must wait before cloaking again (seconds)
+	CloakRecloakDelay = 2.0
+
+	// CloakWarmupTime is how long weapons take to come online after decloaking (seconds)
+	CloakWarmupTime = 0.6
+
+	// CloakFadeSpeed is how fast a cloaked ship fades in or out of view (visibility per second)
+	CloakFadeSpeed = 2.5
+
+	// CloakTargetableVisibility is the visibility below which the player's side can't target a cloaked ship
+	CloakTargetableVisibility = 0.5
+)
+
+// CloakData holds the state of a ship's cloaking device
+type CloakData struct {
+	Capable   bool    // The ship carries a cloaking device
+	Engaged   bool    // The cloak is on (weapons are offline)
+	Warmup    float64 // Time left after decloaking before weapons are online (seconds)
+	SinceShot float64 // Time since the ship last fired (seconds)
+
+	// How visible the ship is to the player's side: 0 hidden, 1 fully visible
+	// Eases toward 1 while the ship is decloaked or within sensor range, toward 0 otherwise
+	Visibility float64
+}
+
+// Hidden returns true if the player's side can't see (or target) the ship
+func (c *CloakData) Hidden() bool {
+	return c.Capable && c.Visibility < CloakTargetableVisibility
+}
+
+// WeaponsOnline returns false while the cloak is engaged or the ship is still decloaking
+func (c *CloakData) WeaponsOnline() bool {
+	return !c.Capable || (!c.Engaged && c.Warmup <= 0)
+}
+
+// SensorRange returns the player's cloak detection range for a sensor upgrade level
+func SensorRange(level int) float64 {
+	return SensorBaseRange + float64(level)*SensorRangePerLevel
+}
+
+// updateCloakAI decloaks a cloaked ship to attack a target in range, and cloaks it again
+// once the target is gone or out of range and it has stopped firing
+func updateCloakAI(entity *Entity, target *Entity) {
+	cloak := &entity.Cloak
+	hasTarget := target != nil && target.Active
+	if cloak.Engaged {
+		if hasTarget && entity.DistanceTo(target) <= CloakAttackRange {
+			cloak.Engaged = false
+			cloak.Warmup = CloakWarmupTime
+		}
+		return
+	}
+	if (!hasTarget || entity.DistanceTo(target) > CloakRecloakRange) && cloak.SinceShot >= CloakRecloakDelay {
+		cloak.Engaged = true
+	}
+}
+
+// updateCloak advances a cloaking ship's timers and fades it in or out of the player's view
+func (g *Game) updateCloak(entity *Entity, deltaTime float64) {
+	cloak := &entity.Cloak
+	cloak.Warmup = math.Max(cloak.Warmup-deltaTime, 0)
+	cloak.SinceShot += deltaTime
+
+	detected := !cloak.Engaged
+	if g.player != nil && g.player.Active && entity.DistanceTo(g.player) <= SensorRange(g.player.Sensors)+entity.Radius {
+		detected = true
+	}
+	if detected {
+		cloak.Visibility = math.Min(cloak.Visibility+CloakFadeSpeed*deltaTime, 1)
+	} else {
+		cloak.Visibility = math.Max(cloak.Visibility-CloakFadeSpeed*deltaTime, 0)
+	}
+}
@@ -71,6 +71,11 @@ func (c *CollisionSystem) CheckCollisions() {
 					continue
 				}
 
+				// Parts welded into one ship don't push each other apart
+				if entity.Parent == other || other.Parent == entity || (entity.Parent != nil && entity.Parent == other.Parent) {
+					continue
+				}
+
 				// Skip collision if either entity is XP (XP has no collision)
 				if entity.Type == EntityTypeXP || other.Type == EntityTypeXP {
 					continue
@@ -121,8 +126,9 @@ func (c *CollisionSystem) HandleCollision(e1, e2 *Entity) {
 	if e1.Type == EntityTypeHomingRocket && e2.Type != EntityTypeHomingRocket {
 		if GetEntityFaction(e1) != GetEntityFaction(e2) {
 			// Different factions - homing rocket explodes
-			e2.Health -= 50.0 // Damage target
-			e1.Health = 0     // Destroy homing rocket (don't set Active=false, let update loop handle cleanup)
+			c.applyWeaponDamage(e1, e2, GetWeaponConfig(e1.Weapon).Damage, e1.X, e1.Y) // Damage target
+			e1.Health = 0                                                              // Destroy homing rocket (don't set Active=false, let update loop handle cleanup)
+			c.damageTurret(e2, e1.X, e1.Y)
 			return
 		}
 		// Same faction - skip collision if NoCollision is set
@@ -133,8 +139,9 @@ func (c *CollisionSystem) HandleCollision(e1, e2 *Entity) {
 	if e2.Type == EntityTypeHomingRocket && e1.Type != EntityTypeHomingRocket {
 		if GetEntityFaction(e1) != GetEntityFaction(e2) {
 			// Different factions - homing rocket explodes
-			e1.Health -= 50.0 // Damage target
-			e2.Health = 0     // Destroy homing rocket (don't set Active=false, let update loop handle cleanup)
+			c.applyWeaponDamage(e2, e1, GetWeaponConfig(e2.Weapon).Damage, e2.X, e2.Y) // Damage target
+			e2.Health = 0                                                              // Destroy homing rocket (don't set Active=false, let update loop handle cleanup)
+			c.damageTurret(e1, e2.X, e2.Y)
 			return
 		}
 		// Same faction - skip collision if NoCollision is set
@@ -164,8 +171,12 @@ func (c *CollisionSystem) HandleCollision(e1, e2 *Entity) {
 		}
 
 		if !isSuicide1 && !isSuicide2 {
-			e1.Health -= 10.0
-			e2.Health -= 10.0
+			if !e1.Status.IsInvulnerable() {
+				e1.Health -= 10.0
+			}
+			if !e2.Status.IsInvulnerable() {
+				e2.Health -= 10.0
+			}
 		}
 	}
 }
@@ -183,7 +194,7 @@ func (c *CollisionSystem) HandleProjectileCollision(projectile, target *Entity)
 	}
 
 	// Don't hit the owner of the projectile (player bullets can't hit player)
-	if projectile.Owner != nil && projectile.Owner == target {
+	if projectile.OwnerID != 0 && projectile.OwnerID == target.ID {
 		return
 	}
 
@@ -192,49 +203,117 @@ func (c *CollisionSystem) HandleProjectileCollision(projectile, target *Entity)
 		return
 	}
 
-	// Apply damage
-	damage := 25.0
-	oldHealth := target.Health
-	target.Health -= damage
-
-	// Check if enemy was destroyed by player projectile
-	if target.Type == EntityTypeEnemy && oldHealth > 0 && target.Health <= 0 {
-		// Enemy was just killed - check if projectile is from player faction
-		if projectile.Owner != nil && projectile.Owner.Faction == FactionPlayer {
-			// Create destroyed indicator in yellow (bullet color)
-			if c.game != nil {
-				c.game.createDestroyedIndicatorYellow(target.X, target.Y)
-				// Don't spawn XP from homing rockets
-				if target.Type != EntityTypeHomingRocket {
-					// Spawn XP entity at enemy position, attracted to the player
-					// Pass the enemy to get its score value
-					c.game.spawnXPFromEnemy(target, projectile.Owner)
-				}
-			}
+	// Apply damage (hits on allies follow the friendly-fire rules)
+	damage := GetWeaponConfig(projectile.Weapon).Damage
+	if GetEntityFaction(projectile) == GetEntityFaction(target) {
+		switch c.world.Config.FriendlyFire {
+		case FriendlyFireOff:
+			return // Pass through allies
+		case FriendlyFireReduced:
+			damage *= c.world.Config.FriendlyFireDamageScale
+		}
+	}
+	c.applyWeaponDamage(projectile, target, damage, projectile.X, projectile.Y)
+	if c.game != nil {
+		c.game.spawnImpactFX(projectile, target)
+		if hit := GetWeaponConfig(projectile.Weapon).Hit; hit != nil {
+			hit(c.game, projectile, target)
 		}
 	}
 
+	// Hits near a turret mount may knock out that turret
+	c.damageTurret(target, projectile.X, projectile.Y)
+
 	// Mark projectile for removal (don't set Active=false, let update loop handle cleanup)
 	projectile.Health = 0
 }
 
+// applyWeaponDamage damages target with a projectile or rocket hitting at (hitX, hitY), records the hit in the
+// target's ledger and publishes it (and the kill, with assists) to the event bus
+func (c *CollisionSystem) applyWeaponDamage(projectile, target *Entity, damage, hitX, hitY float64) {
+	if target.Status.IsInvulnerable() {
+		return
+	}
+	record := DamageRecord{
+		SourceID:      projectile.OwnerID,
+		SourceFaction: projectile.OwnerFaction,
+		Weapon:        projectile.Weapon,
+		Amount:        damage,
+	}
+
+	// Incendiary and sapping weapons leave the target burning or slowed
+	weaponConfig := GetWeaponConfig(projectile.Weapon)
+	target.Status.Ignite(weaponConfig.BurnDuration, weaponConfig.BurnDPS, record)
+	target.Status.Slow(weaponConfig.SlowDuration)
+
+	c.applyDamage(target, record, false, hitX, hitY)
+}
+
+// applyDamage deals the record's damage to target through its shield, notes it in the ledger,
+// publishes it and credits the kill if it was the killing blow
+// Burn ticks (burn true) come through here as well, so they count like any other damage
+func (c *CollisionSystem) applyDamage(target *Entity, record DamageRecord, burn bool, hitX, hitY float64) {
+	if target.Status.IsInvulnerable() {
+		return
+	}
+	oldHealth := target.Health
+	target.Health -= absorbShieldDamage(target, record.Amount)
+
+	record.Time = target.Age
+	if record.SourceID != 0 {
+		target.Ledger.Record(record)
+	}
+	if c.game == nil {
+		return
+	}
+
+	c.game.events.Publish(Event{
+		Type:          EventDamage,
+		Source:        c.world.GetEntityByID(record.SourceID),
+		SourceID:      record.SourceID,
+		SourceFaction: record.SourceFaction,
+		Target:        target,
+		Weapon:        record.Weapon,
+		Amount:        record.Amount,
+		Burn:          burn,
+		HitX:          hitX,
+		HitY:          hitY,
+	})
+	isShip := target.Type == EntityTypePlayer || target.Type == EntityTypeEnemy
+	if isShip && oldHealth > 0 && target.Health <= 0 && !target.Ledger.credited {
+		c.game.creditKill(target, record)
+	}
+}
+
+// damageTurret applies subsystem damage from a hit and marks destroyed turrets with an indicator
+func (c *CollisionSystem) damageTurret(target *Entity, hitX, hitY float64) {
+	if target.Health <= 0 {
+		return // Ship is already dead, no need to knock out turrets
+	}
+	turret := damageTurretNearHit(target, hitX, hitY)
+	if turret != nil && c.game != nil {
+		turretX, turretY := turret.WorldPosition(target)
+		c.game.createDestroyedIndicator(turretX, turretY, target.Faction)
+	}
+}
+
 // HandleXPCollision handles collision between XP and player
 func (c *CollisionSystem) HandleXPCollision(xp, player *Entity) {
 	// Only collect XP if it's targeting this player
-	if xp.Owner == player {
+	if xp.Pickup.Target == player {
 		// Use higher pickup range (check distance instead of collision)
 		pickupRange := 30.0 // Higher pickup range
 		distance := xp.DistanceTo(player)
 
 		if distance <= pickupRange {
-			// Award score (stored in XP's MaxHealth)
-			scoreValue := int(xp.MaxHealth)
+			// Award score
+			scoreValue := xp.Pickup.Value
 			if scoreValue == 0 {
 				scoreValue = 10 // Default score if not set
 			}
 
 			if c.game != nil {
-				c.game.score += scoreValue
+				c.game.addScore(xp.Pickup.Source, scoreValue)
 			}
 
 			// Mark XP for removal (don't set Active=false, let update loop handle cleanup)
@@ -0,0 +1,83 @@
+package game
+
+const (
+	// CollisionWarningTime is how far ahead (in seconds) collision courses are checked
+	CollisionWarningTime = 3.0
+
+	// CollisionWarningSamples is the number of predicted player positions checked
+	CollisionWarningSamples = 30
+
+	// CollisionWarningMargin is extra distance added to the combined radii when checking for a hit
+	CollisionWarningMargin = 10.0
+
+	// MaxCollisionWarnings caps how many threats are flagged per frame
+	MaxCollisionWarnings = 16
+)
+
+// IsOnCollisionCourse checks whether a threat moving in a straight line will come within
+// hitRadius of the predicted path. path[i] is the predicted position at time (i+1)*stepTime
+// Returns whether a collision is predicted and the time until it happens
+func IsOnCollisionCourse(path [][2]float64, stepTime, hitRadius float64, threat *Entity) (bool, float64) {
+	hitRadiusSq := hitRadius * hitRadius
+	for i, point := range path {
+		t := float64(i+1) * stepTime
+		threatX := threat.X + threat.VX*t
+		threatY := threat.Y + threat.VY*t
+		dx := threatX - point[0]
+		dy := threatY - point[1]
+		if dx*dx+dy*dy <= hitRadiusSq {
+			return true, t
+		}
+	}
+	return false, 0
+}
+
+// isCollisionThreat returns true if an entity can hurt the player on impact
+func isCollisionThreat(entity *Entity, player *Entity) bool {
+	if !entity.Active || entity.Health <= 0 || entity == player {
+		return false
+	}
+	if GetEntityFaction(entity) == GetEntityFaction(player) {
+		return false
+	}
+	switch entity.Type {
+	case EntityTypeHomingRocket, EntityTypeProjectile, EntityTypeEnemy, EntityTypeAsteroid, EntityTypeStation:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateCollisionWarnings finds hostile entities on a collision course with the player
+// The player's path is predicted from the currently held input, threats are extrapolated linearly
+func (g *Game) updateCollisionWarnings() {
+	g.collisionThreats = g.collisionThreats[:0]
+	if g.player == nil || !g.player.Active || g.player.Input == nil {
+		return
+	}
+
+	thrust := g.player.Input.GetThrust()
+	rotation := g.player.Input.GetRotation()
+	g.collisionPathBuffer = PredictShipPath(g.player, thrust, rotation, CollisionWarningTime, CollisionWarningSamples, g.collisionPathBuffer)
+	if len(g.collisionPathBuffer) == 0 {
+		return
+	}
+	stepTime := CollisionWarningTime / float64(len(g.collisionPathBuffer))
+
+	// Anything that could reach the player within the warning window (both moving at max speed)
+	searchRadius := CollisionWarningTime * MaxEntitySpeed * 2
+	candidates := g.world.GetEntitiesInRadius(g.player.X, g.player.Y, searchRadius)
+
+	for _, candidate := range candidates {
+		if !isCollisionThreat(candidate, g.player) {
+			continue
+		}
+		hitRadius := g.player.Radius + candidate.Radius + CollisionWarningMargin
+		if onCourse, _ := IsOnCollisionCourse(g.collisionPathBuffer, stepTime, hitRadius, candidate); onCourse {
+			g.collisionThreats = append(g.collisionThreats, candidate)
+			if len(g.collisionThreats) >= MaxCollisionWarnings {
+				return
+			}
+		}
+	}
+}
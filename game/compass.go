@@ -0,0 +1,60 @@
+package game
+
+const (
+	// CompassThreatRange is how far away the compass still points at the nearest threat (pixels)
+	CompassThreatRange = 3000.0
+
+	// CompassRingRadius is the radius of the compass ring around the player (screen pixels)
+	CompassRingRadius = 70.0
+
+	// CompassTapeSpan is the arc of headings the compass tape shows across its width (degrees)
+	CompassTapeSpan = 180.0
+)
+
+// CompassStyle selects the heading compass drawn on the HUD
+type CompassStyle int
+
+const (
+	CompassRing CompassStyle = iota // Ring around the player ship
+	CompassTape                     // Heading tape along the top of the screen
+	CompassOff
+	compassStyleCount
+)
+
+// compassStyleNames are the names shown when cycling the compass
+var compassStyleNames = [compassStyleCount]string{"Ring", "Tape", "Off"}
+
+// String returns the style's display name
+func (s CompassStyle) String() string {
+	if s < 0 || s >= compassStyleCount {
+		return compassStyleNames[CompassRing]
+	}
+	return compassStyleNames[s]
+}
+
+// Next returns the following style, wrapping around (used to cycle it with F8)
+func (s CompassStyle) Next() CompassStyle {
+	return (s + 1) % compassStyleCount
+}
+
+// nearestCompassThreat returns the closest visible hostile ship or missile within CompassThreatRange (nil if none)
+func nearestCompassThreat(world *World, player *Entity) *Entity {
+	var nearest *Entity
+	nearestDistanceSq := CompassThreatRange * CompassThreatRange
+	hostile := GetOppositeFaction(GetEntityFaction(player))
+	for _, entity := range world.QueryTargetCandidates(player.X, player.Y, CompassThreatRange) {
+		if !entity.Active || entity.Health <= 0 || entity.Cloak.Hidden() || GetEntityFaction(entity) != hostile {
+			continue
+		}
+		if entity.Type != EntityTypeEnemy && entity.Type != EntityTypeHomingRocket {
+			continue
+		}
+		dx := entity.X - player.X
+		dy := entity.Y - player.Y
+		if distanceSq := dx*dx + dy*dy; distanceSq < nearestDistanceSq {
+			nearest = entity
+			nearestDistanceSq = distanceSq
+		}
+	}
+	return nearest
+}
@@ -1,5 +1,7 @@
 package game
 
+import "fmt"
+
 // Config holds game configuration constants
 type Config struct {
 	// CellSize is the size of each spatial partition cell in pixels
@@ -22,6 +24,147 @@ type Config struct {
 
 	// ScreenHeight is the window height in pixels
 	ScreenHeight int
+
+	// Mode selects the game mode (standard, arena, ...)
+	Mode GameMode
+
+	// FriendlyFire controls whether projectiles damage ships of their own faction
+	FriendlyFire FriendlyFireMode
+
+	// FriendlyFireDamageScale scales damage to allies when FriendlyFire is FriendlyFireReduced
+	FriendlyFireDamageScale float64
+
+	// Difficulty tunes enemy AI skill (currently how fast shooters react to incoming fire, see dodge.go)
+	Difficulty Difficulty
+
+	// SafeZone enables the shrinking safe zone: ships outside it take damage over time (see safe_zone.go)
+	SafeZone bool
+
+	// WaveBudget controls how random endless waves grow (see spawn_budget.go)
+	WaveBudget WaveBudgetConfig
+
+	// Sectors controls procedural generation of the regions the player explores (see sectors.go)
+	Sectors SectorConfig
+
+	// RunSeed seeds the simulation's random numbers (0 = pick a random seed when the game starts, see rng.go)
+	RunSeed int64
+
+	// Mission adds an objective to escort or defend (see mission.go)
+	Mission MissionType
+
+	// Lives is the number of ships the player gets before the continue screen (0 = off, dying ends the run, see lives.go)
+	Lives int
+
+	// Respawn selects whether dying restarts the run or respawns the player in it, and the penalty (see respawn.go)
+	Respawn RespawnConfig
+
+	// HitStop configures the brief slow-motion pause on big events (see hitstop.go)
+	HitStop HitStopConfig
+
+	// SplitScreen adds a second local player on a gamepad, each with their own half of the window (see coop.go)
+	SplitScreen bool
+
+	// PingWingmen makes friendly AI ships fight near their team's attack and help pings (see ping.go)
+	PingWingmen bool
+
+	// SimulationTPS is the number of simulation ticks per second (e.g. 30 on weak hardware, 120 for smoother motion)
+	SimulationTPS int
+
+	// AIThinkInterval is the minimum number of ticks between an AI's full re-evaluations (target search)
+	// Counted in 60 TPS ticks and scaled to SimulationTPS; steering still runs every tick
+	AIThinkInterval int
+
+	// AIThinkSpread adds 0 to AIThinkSpread extra ticks per AI (by entity ID) so AIs don't think in lockstep
+	// Counted in 60 TPS ticks like AIThinkInterval
+	AIThinkSpread int
+
+	// AIThinkBudget caps full AI re-evaluations per tick (0 = unlimited)
+	AIThinkBudget int
+
+	// ChecksumInterval logs the world checksum every this many ticks, to compare runs for determinism (0 = off, see checksum.go)
+	ChecksumInterval int
+
+	// Pprof starts the profiling server (net/http/pprof) at launch; F11 toggles it in game (see pprof_server.go)
+	Pprof bool
+
+	// PprofAddr is the address the profiling server listens on (empty = DefaultPprofAddr)
+	PprofAddr string
+
+	// GC fixes GOGC or the soft memory limit; whatever is left at 0 is tuned while the game runs (see gc_governor.go)
+	GC GCConfig
+}
+
+// GameMode selects how a game is set up and which systems run
+type GameMode int
+
+const (
+	// GameModeStandard is the normal single-player wave survival game
+	GameModeStandard GameMode = iota
+
+	// GameModeArena has no human player: player-faction bots fight enemy waves
+	GameModeArena
+)
+
+// DefaultSimulationTPS is the default tick rate (Ebiten's default)
+const DefaultSimulationTPS = 60
+
+// TickRate returns the simulation tick rate, falling back to the default for unset values
+func (c Config) TickRate() int {
+	if c.SimulationTPS <= 0 {
+		return DefaultSimulationTPS
+	}
+	return c.SimulationTPS
+}
+
+// FriendlyFireMode selects how projectiles treat ships of the shooter's faction
+type FriendlyFireMode int
+
+const (
+	// FriendlyFireOff lets projectiles pass through allies
+	FriendlyFireOff FriendlyFireMode = iota
+
+	// FriendlyFireReduced damages allies scaled by FriendlyFireDamageScale
+	FriendlyFireReduced
+
+	// FriendlyFireFull damages allies like enemies
+	FriendlyFireFull
+)
+
+// ParseFriendlyFireMode parses a friendly-fire mode name ("off", "reduced" or "full")
+func ParseFriendlyFireMode(name string) (FriendlyFireMode, error) {
+	switch name {
+	case "off":
+		return FriendlyFireOff, nil
+	case "reduced":
+		return FriendlyFireReduced, nil
+	case "full":
+		return FriendlyFireFull, nil
+	default:
+		return FriendlyFireOff, fmt.Errorf("unknown friendly fire mode %q", name)
+	}
+}
+
+// Difficulty selects how skilled enemy AI is
+type Difficulty int
+
+const (
+	DifficultyNormal Difficulty = iota
+	DifficultyEasy
+	DifficultyHard
+)
+
+// ParseDifficulty parses a difficulty name ("easy", "normal" or "hard")
+func ParseDifficulty(name string) (Difficulty, error) {
+	switch name {
+	case "easy":
+		return DifficultyEasy, nil
+	case "normal":
+		return DifficultyNormal, nil
+	case "hard":
+		return DifficultyHard, nil
+	default:
+		return DifficultyNormal, fmt.Errorf("unknown difficulty %q", name)
+	}
 }
 
 // DefaultConfig returns a default configuration
@@ -34,6 +177,26 @@ func DefaultConfig() Config {
 		WorldHeight:  200000.0, // From -100000 to 100000
 		ScreenWidth:  1024,
 		ScreenHeight: 768,
+		Mode:         GameModeStandard,
+
+		FriendlyFire:            FriendlyFireOff,
+		FriendlyFireDamageScale: 0.25,
+
+		Difficulty: DifficultyNormal,
+
+		WaveBudget: DefaultWaveBudgetConfig(),
+		Sectors:    DefaultSectorConfig(),
+		Mission:    MissionNone,
+		HitStop:    DefaultHitStopConfig(),
+		Respawn:    DefaultRespawnConfig(),
+
+		PingWingmen: true,
+
+		SimulationTPS: DefaultSimulationTPS,
+
+		AIThinkInterval: 3, // Each AI thinks every 3-5 ticks
+		AIThinkSpread:   2,
+		AIThinkBudget:   200,
 	}
 }
 
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"image/color"
+
+	"billionslike3/game/assets"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// CoopSpawnOffset is how far to the right of the keyboard player the co-op player starts (pixels)
+const CoopSpawnOffset = 150.0
+
+// SplitScreen is local co-op: a second player on a gamepad, with the window split into two views side by side
+// The keyboard player keeps the game's main camera and renderer (sized to the left view); the co-op player
+// gets their own camera, renderer and HUD in the right view. Screens and menus are drawn across the window
+type SplitScreen struct {
+	Player *Entity // The co-op player (flies on the gamepad)
+
+	camera   *Camera
+	renderer *Renderer
+
+	// Renders the death screen and menus over the whole window
+	overlay *Renderer
+
+	// Offscreen images the two views are drawn into before they are placed side by side
+	views [2]*ebiten.Image
+}
+
+// NewSplitScreen creates the co-op player's view and the window overlay
+func NewSplitScreen(config Config, sprites *assets.Atlas, shaders *ShaderManager) *SplitScreen {
+	viewWidth, viewHeight := viewportSize(config)
+	camera := NewCamera(viewWidth, viewHeight)
+	s := &SplitScreen{
+		camera:   camera,
+		renderer: NewRenderer(camera, sprites, shaders),
+		overlay:  NewRenderer(NewCamera(float64(config.ScreenWidth), float64(config.ScreenHeight)), sprites, shaders),
+	}
+	for i := range s.views {
+		s.views[i] = ebiten.NewImage(int(viewWidth), int(viewHeight))
+	}
+	return s
+}
+
+// viewportSize returns the size of the keyboard player's view: the window, or its left half in split screen
+func viewportSize(config Config) (float64, float64) {
+	if config.SplitScreen {
+		return float64(config.ScreenWidth / 2), float64(config.ScreenHeight)
+	}
+	return float64(config.ScreenWidth), float64(config.ScreenHeight)
+}
+
+// createCoopPlayer creates the gamepad player next to the keyboard player
+func (g *Game) createCoopPlayer() {
+	coop := NewEntityWithShipType(
+		g.player.X+CoopSpawnOffset,
+		g.player.Y,
+		EntityTypePlayer,
+		ShipTypePlayer,
+		NewGamepadPlayerInput(),
+	)
+	coop.Faction = FactionPlayer
+	g.world.RegisterEntity(coop)
+	g.splitScreen.Player = coop
+
+	// Center its camera on it
+	g.splitScreen.camera.X = coop.X
+	g.splitScreen.camera.Y = coop.Y
+}
+
+// updateCoopPlayer reads the co-op player's gamepad and aims their turrets
+func (g *Game) updateCoopPlayer(deltaTime float64) {
+	coop := g.splitScreen.Player
+	if coop == nil || coop.Input == nil {
+		return
+	}
+	coop.Input.Update(deltaTime)
+	if coopInput, ok := coop.Input.(*PlayerInput); ok {
+		g.updatePlayerTargeting(coop, coopInput, deltaTime)
+	}
+}
+
+// hudRenderer returns the renderer for screens and menus drawn across the whole window
+func (g *Game) hudRenderer() *Renderer {
+	if g.splitScreen != nil {
+		return g.splitScreen.overlay
+	}
+	return g.renderer
+}
+
+// drawSplitScreen draws the keyboard player's view on the left and the co-op player's on the right
+func (g *Game) drawSplitScreen(screen *ebiten.Image, hideUI bool) {
+	left, right := g.splitScreen.views[0], g.splitScreen.views[1]
+	left.Fill(color.RGBA{20, 20, 40, 255})
+	g.drawView(left, hideUI)
+
+	// The co-op player's camera keeps showing the fight after they die
+	right.Fill(color.RGBA{20, 20, 40, 255})
+	coop := g.splitScreen.Player
+	renderer := g.splitScreen.renderer
+	renderer.HideUI = hideUI
+	renderer.Heatmap = g.heatmap
+	renderer.Checkpoint = g.checkpoint
+	renderer.Lives = g.lives
+	renderer.Reputation = &g.world.Reputation
+	renderer.Render(right, g.world, coop, g.score, g.fps)
+	if style := GetSettings().Compass; !hideUI && style != CompassOff && coop != nil && coop.Active {
+		renderer.RenderCompass(right, style, coop, g.waypoint, nearestCompassThreat(g.world, coop))
+	}
+
+	screen.DrawImage(left, nil)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(left.Bounds().Dx()), 0)
+	screen.DrawImage(right, op)
+	dividerX := float32(left.Bounds().Dx())
+	vector.StrokeLine(screen, dividerX, 0, dividerX, float32(g.config.ScreenHeight), 2, color.RGBA{120, 120, 160, 255}, false)
+}
@@ -0,0 +1,159 @@
+package game
+
+const (
+	// DamageLedgerWindow is how long a hit counts toward kill credit and assists (seconds)
+	DamageLedgerWindow = 5.0
+
+	// AssistScoreFraction is the share of an enemy's XP the player earns for an assist
+	AssistScoreFraction = 0.5
+
+	// damageLedgerSize is the number of recent hits remembered per entity
+	damageLedgerSize = 8
+)
+
+// DamageRecord is one weapon hit remembered by the victim
+type DamageRecord struct {
+	SourceID      uint64 // Ship that fired (may be dead by now)
+	SourceFaction Faction
+	Weapon        WeaponType
+	Amount        float64
+	Time          float64 // Victim's Age when hit
+}
+
+// DamageLedger remembers the most recent weapon hits an entity took
+// Kills go to the last hit within DamageLedgerWindow (also for deaths by ramming or the zone),
+// and every other ally of the killer with a hit in the window earns an assist
+type DamageLedger struct {
+	records [damageLedgerSize]DamageRecord // Ring buffer, oldest overwritten first
+	count   int
+	next    int
+
+	// Whether the kill has already been credited (a death is only credited once)
+	credited bool
+}
+
+// Record adds a hit, overwriting the oldest once full
+func (l *DamageLedger) Record(record DamageRecord) {
+	l.records[l.next] = record
+	l.next = (l.next + 1) % damageLedgerSize
+	if l.count < damageLedgerSize {
+		l.count++
+	}
+}
+
+// Reset forgets all hits (for pooled entities)
+func (l *DamageLedger) Reset() {
+	*l = DamageLedger{}
+}
+
+// LastHit returns the most recent hit within the window before now (false if there is none)
+func (l *DamageLedger) LastHit(now float64) (DamageRecord, bool) {
+	if l.count == 0 {
+		return DamageRecord{}, false
+	}
+	last := l.records[(l.next-1+damageLedgerSize)%damageLedgerSize]
+	if now-last.Time > DamageLedgerWindow {
+		return DamageRecord{}, false
+	}
+	return last, true
+}
+
+// AppendAssists appends one record per ally of the killer that hit within the window before now,
+// with Amount summed over its hits and Weapon set to its latest one
+func (l *DamageLedger) AppendAssists(assists []DamageRecord, killer DamageRecord, now float64) []DamageRecord {
+	start := len(assists)
+	for i := 0; i < l.count; i++ {
+		// Oldest to newest, so later hits overwrite the weapon
+		record := l.records[(l.next-l.count+i+damageLedgerSize)%damageLedgerSize]
+		if record.SourceID == killer.SourceID || record.SourceFaction != killer.SourceFaction || now-record.Time > DamageLedgerWindow {
+			continue
+		}
+
+		merged := false
+		for j := start; j < len(assists); j++ {
+			if assists[j].SourceID == record.SourceID {
+				assists[j].Amount += record.Amount
+				assists[j].Weapon = record.Weapon
+				assists[j].Time = record.Time
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			assists = append(assists, record)
+		}
+	}
+	return assists
+}
+
+// creditKill publishes the kill of target to the killer's record and assists to its allies
+func (g *Game) creditKill(target *Entity, killer DamageRecord) {
+	target.Ledger.credited = true
+	g.events.Publish(Event{
+		Type:          EventKill,
+		Source:        g.world.GetEntityByID(killer.SourceID),
+		SourceID:      killer.SourceID,
+		SourceFaction: killer.SourceFaction,
+		Target:        target,
+		Weapon:        killer.Weapon,
+		Amount:        killer.Amount,
+	})
+
+	g.assistBuffer = target.Ledger.AppendAssists(g.assistBuffer[:0], killer, target.Age)
+	for _, assist := range g.assistBuffer {
+		g.events.Publish(Event{
+			Type:          EventAssist,
+			Source:        g.world.GetEntityByID(assist.SourceID),
+			SourceID:      assist.SourceID,
+			SourceFaction: assist.SourceFaction,
+			Target:        target,
+			Weapon:        assist.Weapon,
+			Amount:        assist.Amount,
+		})
+	}
+}
+
+// creditUnattributedDeath credits a ship that died without a killing weapon hit (ramming, the zone)
+// to whoever hit it last within the window
+func (g *Game) creditUnattributedDeath(entity *Entity) {
+	if entity.Ledger.credited || (entity.Type != EntityTypePlayer && entity.Type != EntityTypeEnemy) {
+		return
+	}
+	if killer, ok := entity.Ledger.LastHit(entity.Age); ok {
+		g.creditKill(entity, killer)
+	}
+}
+
+// onKillReward marks enemies killed by the player's side and drops their XP for the player
+func (g *Game) onKillReward(event Event) {
+	if event.SourceFaction != FactionPlayer || event.Target.Type != EntityTypeEnemy || event.Target.Faction == FactionPlayer {
+		return // Not ours, or friendly fire
+	}
+	g.createDestroyedIndicatorYellow(event.Target.X, event.Target.Y)
+	if event.IsFrom(g.player) || (g.player != nil && g.isPlayerEscort(event.SourceID)) {
+		g.spawnXPFromEnemy(event.Target, g.player)
+	}
+}
+
+// onAssistReward drops a share of the enemy's XP when the player assists an ally's kill
+func (g *Game) onAssistReward(event Event) {
+	if !event.IsFrom(g.player) || !g.player.Active || event.Target.Type != EntityTypeEnemy || event.Target.Faction == FactionPlayer {
+		return
+	}
+	if scoreValue := int(float64(enemyXPValue(event.Target)) * AssistScoreFraction); scoreValue > 0 {
+		g.spawnPickup(event.Target.X, event.Target.Y, scoreValue, g.player, ScoreAssist)
+	}
+}
+
+// isPlayerEscort returns true if id is one of the player's drones or deployed sentries, whose kills count as the player's
+func (g *Game) isPlayerEscort(id uint64) bool {
+	if g.drones.Launched(id) {
+		return true
+	}
+	for _, sentry := range g.sentries {
+		if sentry.ID == id {
+			return true
+		}
+	}
+	return false
+}
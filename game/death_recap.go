@@ -0,0 +1,175 @@
+package game
+
+const (
+	// RecapWindow is how far back the death recap's damage timeline reaches (seconds)
+	RecapWindow = 10.0
+
+	// RecapSampleInterval is the time between two hull samples on the timeline (seconds)
+	RecapSampleInterval = 0.25
+
+	// recapSamples is the number of hull samples covering the window
+	recapSamples = int(RecapWindow / RecapSampleInterval)
+)
+
+// ScoreSource identifies what a score pickup was earned for
+type ScoreSource int
+
+const (
+	ScoreKill ScoreSource = iota
+	ScoreAssist
+	ScoreAsteroid
+	ScoreLoot // Broken-open stations
+	ScoreMission
+	ScoreWaveClear // Wave-clear bonuses (see wave_clear.go)
+	scoreSourceCount
+)
+
+// scoreSourceNames are the names shown in the death recap's score breakdown
+var scoreSourceNames = [scoreSourceCount]string{"Kills", "Assists", "Asteroids", "Loot", "Missions", "Wave clears"}
+
+// String returns the source's display name
+func (s ScoreSource) String() string {
+	if s < 0 || s >= scoreSourceCount {
+		return scoreSourceNames[ScoreKill]
+	}
+	return scoreSourceNames[s]
+}
+
+// RecapHit is one weapon hit the player took
+type RecapHit struct {
+	Time     float64 // Run time of the hit
+	Amount   float64
+	Weapon   WeaponType
+	Attacker string
+}
+
+// RecapUpgrade is one purchase made at a station
+type RecapUpgrade struct {
+	Wave  int
+	Name  string
+	Price int
+}
+
+// DeathRecap collects what the death screen shows about a run: the hull and hits over the last
+// RecapWindow seconds, what landed the killing blow, where the score came from and what was bought
+type DeathRecap struct {
+	// Run time in seconds (stops when the player dies)
+	Time float64
+	dead bool
+
+	// Hull fraction samples, a ring buffer holding the newest recapSamples
+	health      [recapSamples]float64
+	healthCount int
+	healthNext  int
+	sampleTimer float64
+
+	// Weapon hits within the window before Time, oldest first
+	Hits []RecapHit
+
+	// Score earned per source
+	Score [scoreSourceCount]int
+
+	// Station purchases, in order
+	Upgrades []RecapUpgrade
+
+	// The killing hit (Killer is empty if the player died to a collision or the zone)
+	Killer       string
+	KillerWeapon WeaponType
+}
+
+// NewDeathRecap creates an empty recap
+func NewDeathRecap() *DeathRecap {
+	return &DeathRecap{
+		Hits:     make([]RecapHit, 0, 32),
+		Upgrades: make([]RecapUpgrade, 0, 8),
+	}
+}
+
+// Reset clears the recap (a new run)
+func (r *DeathRecap) Reset() {
+	*r = DeathRecap{Hits: r.Hits[:0], Upgrades: r.Upgrades[:0]}
+}
+
+// Revive resumes the timeline after the player respawned in the same run
+func (r *DeathRecap) Revive() {
+	r.dead = false
+}
+
+// Update advances the run time and samples the player's hull, until the player dies
+func (r *DeathRecap) Update(deltaTime float64, player *Entity) {
+	if r.dead || player == nil {
+		return
+	}
+	if !player.Active || player.Health <= 0 {
+		// End the timeline on the killing blow
+		r.dead = true
+		r.addSample(0)
+		return
+	}
+
+	r.Time += deltaTime
+	r.sampleTimer -= deltaTime
+	if r.sampleTimer <= 0 {
+		r.sampleTimer = RecapSampleInterval
+		r.addSample(player.Health / player.MaxHealth)
+	}
+}
+
+// addSample appends a hull sample, overwriting the oldest once full
+func (r *DeathRecap) addSample(health float64) {
+	r.health[r.healthNext] = health
+	r.healthNext = (r.healthNext + 1) % recapSamples
+	if r.healthCount < recapSamples {
+		r.healthCount++
+	}
+}
+
+// HealthTimeline returns the hull fraction samples, oldest first
+func (r *DeathRecap) HealthTimeline() []float64 {
+	samples := make([]float64, r.healthCount)
+	for i := range samples {
+		samples[i] = r.health[(r.healthNext-r.healthCount+i+recapSamples)%recapSamples]
+	}
+	return samples
+}
+
+// AddHit records a hit, dropping the ones that fell out of the window
+func (r *DeathRecap) AddHit(hit RecapHit) {
+	kept := r.Hits[:0]
+	for _, old := range r.Hits {
+		if hit.Time-old.Time <= RecapWindow {
+			kept = append(kept, old)
+		}
+	}
+	r.Hits = append(kept, hit)
+}
+
+// onRecapDamage records weapon hits on the player for the timeline
+func (g *Game) onRecapDamage(event Event) {
+	if event.Target != g.player {
+		return
+	}
+	g.recap.AddHit(RecapHit{
+		Time:     g.recap.Time,
+		Amount:   event.Amount,
+		Weapon:   event.Weapon,
+		Attacker: g.killFeedName(event.Source, event.SourceID),
+	})
+}
+
+// onRecapKill remembers what killed the player
+func (g *Game) onRecapKill(event Event) {
+	if event.Target != g.player {
+		return
+	}
+	g.recap.Killer = g.killFeedName(event.Source, event.SourceID)
+	g.recap.KillerWeapon = event.Weapon
+}
+
+// addScore awards score from a collected pickup and counts it toward its source
+func (g *Game) addScore(source ScoreSource, value int) {
+	g.score += value
+	if source >= 0 && source < scoreSourceCount {
+		g.recap.Score[source] += value
+	}
+}
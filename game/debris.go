@@ -0,0 +1,129 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// MaxDebris is the global cap on debris pieces; the oldest plain pieces are evicted first when it's reached (salvage never is)
+	MaxDebris = 300
+
+	// DebrisLifetime is how long a piece drifts before it's gone (seconds)
+	DebrisLifetime = 20.0
+
+	// DebrisFadeTime is the final part of a piece's lifetime spent fading out (seconds)
+	DebrisFadeTime = 5.0
+
+	// DebrisPerRadius is the number of pieces a destroyed ship leaves per pixel of its radius,
+	// between DebrisMinPieces and DebrisMaxPieces at full particle density
+	DebrisPerRadius = 0.3
+	DebrisMinPieces = 3
+	DebrisMaxPieces = 12
+
+	// DebrisSpeed is the fastest a piece is flung away from the wreck (pixels per second)
+	DebrisSpeed = 60.0
+
+	// DebrisFriction is the per-tick velocity kept by drifting pieces (see StepFriction)
+	DebrisFriction = 0.995
+
+	// DebrisMaxSpin is the fastest a piece tumbles (radians per second)
+	DebrisMaxSpin = 3.0
+)
+
+// debrisRandom returns a number in [0, 1) for a piece of a ship's debris
+// It's derived from the ship ID instead of drawing from runRNG, so the run (and its salvage)
+// replays the same whatever the particle density
+func debrisRandom(shipID uint64, piece, salt int) float64 {
+	// SplitMix64 finalizer
+	x := shipID*0x9E3779B97F4A7C15 + uint64(piece)*0xBF58476D1CE4E5B9 + uint64(salt)*0x94D049BB133111EB
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+	return float64(x>>11) / (1 << 53)
+}
+
+// spawnDebris scatters drifting pieces of a destroyed ship around its wreck, some of them salvage (see salvage.go)
+func (g *Game) spawnDebris(ship *Entity) {
+	pieces := int(math.Round(math.Max(DebrisMinPieces, math.Min(ship.Radius*DebrisPerRadius, DebrisMaxPieces)) * GetSettings().ParticleDensity))
+	for i := 0; i < pieces; i++ {
+		g.world.RegisterEntity(newDebrisPiece(ship, i, 0))
+	}
+	for i := 0; i < salvagePieces(ship); i++ {
+		piece := newDebrisPiece(ship, i, 1)
+		piece.Radius = 3.0
+		piece.Pickup.Value = SalvageValue
+		piece.Lifetime = SalvageLifetime
+		g.world.RegisterEntity(piece)
+	}
+}
+
+// newDebrisPiece creates the i-th piece of a ship's debris, flung out of the wreck
+// Plain debris and salvage use different streams so the particle density doesn't move the salvage
+func newDebrisPiece(ship *Entity, i, stream int) *Entity {
+	salt := stream * 8
+	angle := debrisRandom(ship.ID, i, salt) * 2 * math.Pi
+	speed := DebrisSpeed * (0.2 + 0.8*debrisRandom(ship.ID, i, salt+1))
+	offset := ship.Radius * 0.5 * debrisRandom(ship.ID, i, salt+2)
+
+	piece := NewEntity(ship.X+math.Cos(angle)*offset, ship.Y+math.Sin(angle)*offset, 1.5+2.5*debrisRandom(ship.ID, i, salt+3), EntityTypeDebris, nil)
+	piece.Faction = ship.Faction
+	piece.VX = ship.VX*0.5 + math.Cos(angle)*speed
+	piece.VY = ship.VY*0.5 + math.Sin(angle)*speed
+	piece.Rotation = debrisRandom(ship.ID, i, salt+4) * 2 * math.Pi
+	piece.AngularVelocity = (debrisRandom(ship.ID, i, salt+5)*2 - 1) * DebrisMaxSpin
+	piece.Health = 1.0 // Small health value so it renders
+	piece.MaxHealth = 1.0
+	piece.Lifetime = DebrisLifetime
+	piece.NoCollision = true
+	return piece
+}
+
+// UpdateDebris drifts and spins debris pieces, and removes the ones whose lifetime has expired
+func (w *World) UpdateDebris(deltaTime float64) {
+	friction := StepFriction(DebrisFriction, deltaTime)
+	w.Debris.update(w, deltaTime, func(entity *Entity) {
+		entity.VX *= friction
+		entity.VY *= friction
+		entity.X += entity.VX * deltaTime
+		entity.Y += entity.VY * deltaTime
+		entity.Rotation += entity.AngularVelocity * deltaTime
+	})
+}
+
+// renderDebris draws debris pieces as tumbling shards in a darkened faction color, and salvage with a glint
+func (r *Renderer) renderDebris(screen *ebiten.Image, world *World) {
+	for _, entity := range world.Debris {
+		if !entity.Active {
+			continue
+		}
+		sx, sy := r.camera.WorldToScreen(entity.X, entity.Y)
+		if sx < -20 || sx > r.camera.Width+20 || sy < -20 || sy > r.camera.Height+20 {
+			continue
+		}
+
+		alpha := 200.0
+		if remaining := entity.Lifetime - entity.Age; remaining < DebrisFadeTime {
+			alpha *= math.Max(remaining/DebrisFadeTime, 0)
+		}
+		base := GetFactionConfig(entity.Faction).Color
+		clr := color.RGBA{base.R / 2, base.G / 2, base.B / 2, uint8(alpha)}
+		width := float32(1.5)
+		if isSalvage(entity) {
+			clr = salvageColor
+			clr.A = uint8(alpha)
+			width = 2.5
+		}
+
+		length := math.Max(entity.Radius*r.camera.Zoom, 1)
+		dx, dy := math.Cos(entity.Rotation)*length, math.Sin(entity.Rotation)*length
+		r.lineCount++
+		r.drawCallCount++
+		vector.StrokeLine(screen, float32(sx-dx), float32(sy-dy), float32(sx+dx), float32(sy+dy), width, clr, true)
+	}
+}
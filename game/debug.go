@@ -2,19 +2,28 @@ package game
 
 // DebugState holds global debug flags that persist across game resets
 type DebugState struct {
-	ShowGrid bool // Show cell grid lines and cell coordinates
+	ShowGrid   bool // Show cell grid lines and cell coordinates
+	ShowTrails bool // Show breadcrumb and predicted trails for enemies and rockets
+
+	ShowPlayerPath bool // Show the player's predicted trajectory
+
+	ShowStats bool // Show the entity statistics panel
+
+	Heatmap HeatmapMode // World heatmap overlay of entity density or recent damage
 }
 
 // Global debug state instance (persists across game resets)
 var globalDebugState = &DebugState{
-	ShowGrid: false, // Default to off
+	ShowGrid:   false, // Default to off
+	ShowTrails: false, // Default to off
+
+	ShowPlayerPath: false, // Default to off
+
+	ShowStats: false, // Default to off
+	Heatmap:   HeatmapOff,
 }
 
 // GetDebugState returns the global debug state
 func GetDebugState() *DebugState {
 	return globalDebugState
 }
-
-
-
-
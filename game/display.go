@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DisplayMode selects how the game's window is shown
+type DisplayMode int
+
+const (
+	DisplayWindowed   DisplayMode = iota
+	DisplayBorderless             // A window without decorations covering the whole monitor
+	DisplayFullscreen             // Ebiten's fullscreen mode
+	displayModeCount
+)
+
+// displayModeNames are the names used by the -display flag
+var displayModeNames = [displayModeCount]string{"windowed", "borderless", "fullscreen"}
+
+// String returns the mode's flag name
+func (m DisplayMode) String() string {
+	if m < 0 || m >= displayModeCount {
+		return displayModeNames[DisplayWindowed]
+	}
+	return displayModeNames[m]
+}
+
+// ParseDisplayMode parses a display mode name ("windowed", "borderless" or "fullscreen")
+func ParseDisplayMode(name string) (DisplayMode, error) {
+	for mode, modeName := range displayModeNames {
+		if name == modeName {
+			return DisplayMode(mode), nil
+		}
+	}
+	return DisplayWindowed, fmt.Errorf("unknown display mode %q", name)
+}
+
+// WindowGeometry is the size and position of the window in windowed mode
+// The position is relative to the monitor's upper-left corner, in device-independent pixels
+type WindowGeometry struct {
+	X, Y          int
+	Width, Height int // 0 = not remembered yet
+}
+
+// DisplaySettings are the display options remembered across sessions
+type DisplaySettings struct {
+	Mode    DisplayMode
+	Monitor int // Index of the monitor to open on (0 = primary)
+
+	// The window as it was when the game was last closed in windowed mode
+	Window WindowGeometry
+
+	// Frame rate cap, and whether to throttle to BackgroundTPS while unfocused (see frame_limiter.go)
+	FrameRateCap FrameRateCap
+	BatterySaver bool
+}
+
+// Sanitize clamps the display settings to valid values
+func (s *DisplaySettings) Sanitize() {
+	if s.Mode < 0 || s.Mode >= displayModeCount {
+		s.Mode = DisplayWindowed
+	}
+	if s.Monitor < 0 {
+		s.Monitor = 0
+	}
+	if s.Window.Width <= 0 || s.Window.Height <= 0 {
+		s.Window = WindowGeometry{}
+	}
+	if s.FrameRateCap < 0 || s.FrameRateCap >= frameRateCapCount {
+		s.FrameRateCap = FrameRateVsync
+	}
+}
+
+// ApplyDisplaySettings sets up the window from the settings (call before ebiten.RunGame)
+// The window defaults to width x height; a monitor that is no longer connected falls back to the primary one
+func ApplyDisplaySettings(settings DisplaySettings, width, height int) {
+	monitors := ebiten.AppendMonitors(nil)
+	monitor := ebiten.Monitor()
+	if settings.Monitor < len(monitors) {
+		monitor = monitors[settings.Monitor]
+		ebiten.SetMonitor(monitor)
+	}
+
+	// The window size also applies when leaving fullscreen
+	if settings.Window.Width > 0 {
+		width, height = settings.Window.Width, settings.Window.Height
+	}
+	ebiten.SetWindowSize(width, height)
+
+	switch settings.Mode {
+	case DisplayWindowed:
+		if settings.Window.Width > 0 {
+			ebiten.SetWindowPosition(settings.Window.X, settings.Window.Y)
+		}
+	case DisplayBorderless:
+		ebiten.SetWindowDecorated(false)
+		if monitor != nil {
+			ebiten.SetWindowSize(monitor.Size())
+		}
+		ebiten.SetWindowPosition(0, 0)
+	case DisplayFullscreen:
+		ebiten.SetFullscreen(true)
+	}
+}
+
+// rememberWindow stores the window's monitor, and its size and position in windowed mode, in the settings
+func rememberWindow(settings *DisplaySettings) {
+	current := ebiten.Monitor()
+	for i, monitor := range ebiten.AppendMonitors(nil) {
+		if monitor == current {
+			settings.Monitor = i
+		}
+	}
+	if settings.Mode != DisplayWindowed || ebiten.IsFullscreen() {
+		return
+	}
+	settings.Window.X, settings.Window.Y = ebiten.WindowPosition()
+	settings.Window.Width, settings.Window.Height = ebiten.WindowSize()
+}
+
+// closeWindow remembers the window in the settings file before the game exits
+func closeWindow() error {
+	rememberWindow(&GetSettings().Display)
+	if err := SaveSettings(); err != nil {
+		fmt.Printf("Failed to save settings: %v\n", err)
+	}
+	return ebiten.Termination
+}
@@ -0,0 +1,234 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	// DockRange is how far from a station's hull the player can dock (pixels)
+	DockRange = 150.0
+
+	// DockMaxSpeed is the fastest the player may be moving to dock (pixels per second)
+	DockMaxSpeed = 150.0
+
+	// RepairCostPerHealth is the price of restoring one point of hull
+	RepairCostPerHealth = 0.5
+
+	// RearmCostPerTurret is the price of replacing one destroyed turret
+	RearmCostPerTurret = 40
+)
+
+// ShopItemKind identifies what a station sells
+type ShopItemKind int
+
+const (
+	ShopItemRepair       ShopItemKind = iota // Restore the hull to full health
+	ShopItemRearm                            // Replace destroyed turrets and refill tractor energy
+	ShopItemWeaponModule                     // Swap a turret's weapon for Weapon
+	ShopItemSensor                           // Widen the range cloaked ships are detected at (see cloak.go)
+	ShopItemSalvageBeam                      // Gather salvage in a wider radius (see salvage.go)
+	ShopItemDrone                            // Add a drone to the ship's escort (see drone.go)
+	ShopItemSentry                           // One more sentry turret to deploy (see sentry.go)
+)
+
+// ShopItem is one entry of a station's inventory
+type ShopItem struct {
+	Kind   ShopItemKind
+	Weapon WeaponType // ShopItemWeaponModule
+	Price  int        // ShopItemWeaponModule (repairs are priced by the damage)
+}
+
+// stationDrop is one entry of the station inventory drop table
+type stationDrop struct {
+	Weapon WeaponType
+	Weight float64
+	Price  int
+}
+
+// stationDropTable lists the weapon modules stations may stock, with their relative odds and prices
+var stationDropTable = []stationDrop{
+	{Weapon: WeaponTypeBullet, Weight: 0.45, Price: 60},
+	{Weapon: WeaponTypeHomingMissile, Weight: 0.35, Price: 180},
+	{Weapon: WeaponTypeTractor, Weight: 0.20, Price: 120},
+	{Weapon: WeaponTypeEMP, Weight: 0.15, Price: 220},
+	{Weapon: WeaponTypeArc, Weight: 0.15, Price: 200},
+}
+
+// rollStationInventory builds a station's stock: repairs, plus one or two weapon modules from the drop table
+// It only draws from rng, so stations generated from a seed always stock the same items
+func rollStationInventory(rng *rand.Rand) []ShopItem {
+	inventory := []ShopItem{{Kind: ShopItemRepair}, {Kind: ShopItemRearm}, {Kind: ShopItemSensor}, {Kind: ShopItemSalvageBeam}, {Kind: ShopItemDrone}, {Kind: ShopItemSentry}}
+
+	totalWeight := 0.0
+	for _, drop := range stationDropTable {
+		totalWeight += drop.Weight
+	}
+	for i := 1 + rng.Intn(2); i > 0; i-- {
+		roll := rng.Float64() * totalWeight
+		for _, drop := range stationDropTable {
+			roll -= drop.Weight
+			if roll < 0 {
+				inventory = append(inventory, ShopItem{Kind: ShopItemWeaponModule, Weapon: drop.Weapon, Price: drop.Price})
+				break
+			}
+		}
+	}
+	return inventory
+}
+
+// ShopItemName returns the label of a shop item
+func ShopItemName(item ShopItem) string {
+	switch item.Kind {
+	case ShopItemRepair:
+		return "Repair hull"
+	case ShopItemRearm:
+		return "Rearm turrets"
+	case ShopItemSensor:
+		return "Sensor upgrade"
+	case ShopItemSalvageBeam:
+		return "Salvage beam upgrade"
+	case ShopItemDrone:
+		return "Drone"
+	case ShopItemSentry:
+		return "Sentry turret"
+	default:
+		return GetWeaponConfig(item.Weapon).Name + " module"
+	}
+}
+
+// ShopItemPrice returns what an item costs the player right now (0 with ok=false if there's nothing to buy)
+func ShopItemPrice(item ShopItem, player *Entity) (price int, ok bool) {
+	switch item.Kind {
+	case ShopItemRepair:
+		missing := player.MaxHealth - player.Health
+		return int(math.Ceil(missing * RepairCostPerHealth)), missing > 0
+	case ShopItemRearm:
+		destroyed := 0
+		drained := false
+		for i := range player.Turrets {
+			turret := &player.Turrets[i]
+			if turret.Destroyed {
+				destroyed++
+			}
+			if tractor := GetWeaponConfig(turret.Mount.WeaponType).Tractor; tractor != nil && turret.Energy < tractor.Energy {
+				drained = true
+			}
+		}
+		return destroyed * RearmCostPerTurret, destroyed > 0 || drained
+	case ShopItemSensor:
+		return SensorUpgradePrice, player.Sensors < SensorMaxLevel
+	case ShopItemSalvageBeam:
+		return SalvageBeamUpgradePrice, player.SalvageBeam < SalvageBeamMaxLevel
+	case ShopItemDrone:
+		return DronePrice, player.Drones < MaxDrones
+	case ShopItemSentry:
+		return SentryPrice, player.Sentries < MaxSentryCharges
+	default:
+		return item.Price, moduleTurret(player, item.Weapon) >= 0
+	}
+}
+
+// moduleTurret returns the turret a weapon module would be fitted to: the first working turret
+// carrying a different weapon (-1 if every turret already has it)
+func moduleTurret(player *Entity, weapon WeaponType) int {
+	for i := range player.Turrets {
+		turret := &player.Turrets[i]
+		if turret.Mount.Active && !turret.Destroyed && turret.Mount.WeaponType != weapon {
+			return i
+		}
+	}
+	return -1
+}
+
+// nearestDockableStation returns the closest shop station the player is in docking range of (nil if none)
+func (g *Game) nearestDockableStation() *Entity {
+	var nearest *Entity
+	nearestDistance := math.MaxFloat64
+	for _, entity := range g.world.QueryTargetCandidates(g.player.X, g.player.Y, StationRadius+DockRange) {
+		if entity.Type != EntityTypeStation || !entity.Active || entity.Health <= 0 || len(entity.Station.Inventory) == 0 {
+			continue
+		}
+		if distance := entity.DistanceTo(g.player) - entity.Radius; distance <= DockRange && distance < nearestDistance {
+			nearest = entity
+			nearestDistance = distance
+		}
+	}
+	return nearest
+}
+
+// toggleDock docks at the nearest station in range, or undocks
+func (g *Game) toggleDock() {
+	if g.dockedStation != nil {
+		g.dockedStation = nil
+		return
+	}
+	if g.player == nil || !g.player.Active || g.player.Health <= 0 {
+		return
+	}
+	if math.Hypot(g.player.VX, g.player.VY) > DockMaxSpeed {
+		return // Too fast to dock
+	}
+	if station := g.nearestDockableStation(); station != nil {
+		g.dockedStation = station
+		g.modMenuOpen = false
+		g.loadoutMenuOpen = false
+		g.radio.Say(RadioCueVendorGreeting, "Vendor", "", GetFactionConfig(station.Faction).Color)
+	}
+}
+
+// updateDocking undocks when the station is destroyed or the player dies or drifts out of range
+func (g *Game) updateDocking() {
+	station := g.dockedStation
+	if station == nil {
+		return
+	}
+	if !station.Active || station.Health <= 0 || g.player == nil || !g.player.Active || g.player.Health <= 0 ||
+		station.DistanceTo(g.player)-station.Radius > DockRange {
+		g.dockedStation = nil
+	}
+}
+
+// buyShopItem buys the docked station's item at index for the player, if it's available and affordable
+func (g *Game) buyShopItem(index int) {
+	station := g.dockedStation
+	if station == nil || index >= len(station.Station.Inventory) {
+		return
+	}
+	item := station.Station.Inventory[index]
+	price, ok := ShopItemPrice(item, g.player)
+	if !ok || price > g.credits {
+		return
+	}
+	g.credits -= price
+	g.recap.Upgrades = append(g.recap.Upgrades, RecapUpgrade{Wave: g.waveNumber, Name: ShopItemName(item), Price: price})
+
+	switch item.Kind {
+	case ShopItemRepair:
+		g.player.Health = g.player.MaxHealth
+	case ShopItemRearm:
+		for i := range g.player.Turrets {
+			turret := &g.player.Turrets[i]
+			turret.Destroyed = false
+			if tractor := GetWeaponConfig(turret.Mount.WeaponType).Tractor; tractor != nil {
+				turret.Energy = tractor.Energy
+			}
+		}
+	case ShopItemSensor:
+		g.player.Sensors++
+	case ShopItemSalvageBeam:
+		g.player.SalvageBeam++
+	case ShopItemDrone:
+		g.player.Drones++
+	case ShopItemSentry:
+		g.player.Sentries++
+	case ShopItemWeaponModule:
+		turret := &g.player.Turrets[moduleTurret(g.player, item.Weapon)]
+		turret.Mount.WeaponType = item.Weapon
+		turret.HasFired = false
+		turret.Energy = 0
+		if tractor := GetWeaponConfig(item.Weapon).Tractor; tractor != nil {
+			turret.Energy = tractor.Energy
+		}
+	}
+}
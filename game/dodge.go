@@ -0,0 +1,115 @@
+package game
+
+import "math"
+
+// DodgeConfig tunes how shooter AI evades incoming projectiles
+type DodgeConfig struct {
+	ReactionTime float64 // Delay between spotting a threatening projectile and starting to dodge (seconds)
+	SampleRadius float64 // Projectiles farther away are ignored (pixels)
+	Lookahead    float64 // Only projectiles reaching their closest approach within this time count (seconds)
+	Margin       float64 // Dodge when the closest approach is within both radii plus this margin (pixels)
+	Impulse      float64 // Lateral acceleration while dodging (pixels per second squared)
+}
+
+// GetDodgeConfig returns the evasion tuning for a difficulty
+func GetDodgeConfig(difficulty Difficulty) DodgeConfig {
+	switch difficulty {
+	case DifficultyEasy:
+		return DodgeConfig{
+			ReactionTime: 0.5,
+			SampleRadius: 400.0,
+			Lookahead:    1.0,
+			Margin:       4.0,
+			Impulse:      250.0,
+		}
+	case DifficultyHard:
+		return DodgeConfig{
+			ReactionTime: 0.08,
+			SampleRadius: 500.0,
+			Lookahead:    1.0,
+			Margin:       10.0,
+			Impulse:      600.0,
+		}
+	default: // DifficultyNormal
+		return DodgeConfig{
+			ReactionTime: 0.25,
+			SampleRadius: 450.0,
+			Lookahead:    1.0,
+			Margin:       6.0,
+			Impulse:      400.0,
+		}
+	}
+}
+
+// updateDodge looks for incoming hostile projectiles on think ticks and, after the reaction time,
+// pushes the ship sideways out of the projectile's path until it has passed
+func updateDodge(aiInput *AIInput, entity *Entity, world *World, deltaTime float64, think bool) {
+	dodge := GetDodgeConfig(world.Config.Difficulty)
+
+	if think && aiInput.dodgeTime <= 0 {
+		if timeToPass, dirX, dirY, ok := findIncomingProjectile(entity, world, dodge); ok {
+			aiInput.dodgeReaction = dodge.ReactionTime
+			aiInput.dodgeTime = timeToPass
+			aiInput.dodgeX = dirX
+			aiInput.dodgeY = dirY
+		}
+	}
+	if aiInput.dodgeTime <= 0 {
+		return
+	}
+
+	// The projectile keeps flying while the pilot reacts: a slow reaction can mean no dodge at all
+	aiInput.dodgeTime -= deltaTime
+	if aiInput.dodgeReaction > 0 {
+		aiInput.dodgeReaction -= deltaTime
+		return
+	}
+	entity.VX += aiInput.dodgeX * dodge.Impulse * deltaTime
+	entity.VY += aiInput.dodgeY * dodge.Impulse * deltaTime
+}
+
+// findIncomingProjectile returns the hostile projectile that will pass closest soonest within the dodge margin:
+// the time until it passes and the unit direction that moves the ship away from its path
+func findIncomingProjectile(entity *Entity, world *World, dodge DodgeConfig) (timeToPass, dirX, dirY float64, ok bool) {
+	entityFaction := GetEntityFaction(entity)
+	timeToPass = math.MaxFloat64
+
+	// Projectiles live in the grid like ships, so the shared target query sees them too
+	for _, projectile := range world.QueryTargetCandidates(entity.X, entity.Y, dodge.SampleRadius) {
+		if projectile.Type != EntityTypeProjectile || !projectile.Active || projectile.Health <= 0 ||
+			GetEntityFaction(projectile) == entityFaction {
+			continue
+		}
+
+		// Closest approach of the projectile relative to the ship
+		relX := projectile.X - entity.X
+		relY := projectile.Y - entity.Y
+		relVX := projectile.VX - entity.VX
+		relVY := projectile.VY - entity.VY
+		speedSq := relVX*relVX + relVY*relVY
+		if speedSq == 0 {
+			continue
+		}
+		t := -(relX*relVX + relY*relVY) / speedSq
+		if t < 0 || t > dodge.Lookahead || t >= timeToPass {
+			continue // Moving away, too far off, or not the most urgent
+		}
+		missX := relX + relVX*t
+		missY := relY + relVY*t
+		missDistance := math.Sqrt(missX*missX + missY*missY)
+		if missDistance > entity.Radius+projectile.Radius+dodge.Margin {
+			continue
+		}
+
+		// Move away from the point where the projectile passes; dead-on shots go to its left
+		speed := math.Sqrt(speedSq)
+		if missDistance > 0.001 {
+			dirX, dirY = -missX/missDistance, -missY/missDistance
+		} else {
+			dirX, dirY = relVY/speed, -relVX/speed
+		}
+		timeToPass = t
+		ok = true
+	}
+	return timeToPass, dirX, dirY, ok
+}
@@ -0,0 +1,231 @@
+package game
+
+import "math"
+
+const (
+	// MaxDrones is the number of drones the player can buy
+	MaxDrones = 3
+
+	// DronePrice is the price of one drone at a station shop
+	DronePrice = 200
+
+	// DroneOrbitRadius is how far from the owner's center the drones circle (pixels)
+	DroneOrbitRadius = 50.0
+
+	// DroneOrbitSpeed is how fast the drones go around the owner (radians per second)
+	DroneOrbitSpeed = 1.5
+
+	// DroneTargetRange is how far from the owner a drone picks targets (pixels)
+	DroneTargetRange = 400.0
+
+	// DroneLeashDistance is how far a drone may fall behind before it stops fighting and flies straight back (pixels)
+	DroneLeashDistance = 250.0
+
+	// DroneLaunchInterval is the time between two drones leaving the ship (seconds)
+	DroneLaunchInterval = 1.0
+
+	// DroneRebuildTime is how long the ship takes to replace a destroyed drone (seconds)
+	DroneRebuildTime = 20.0
+
+	// droneFacingTolerance is how far a drone may face away from where it wants to go and still thrust (radians)
+	droneFacingTolerance = math.Pi / 3
+)
+
+// DroneRole selects what a drone's turret shoots at
+type DroneRole int
+
+const (
+	DroneGunner       DroneRole = iota // Hostile ships
+	DronePointDefense                  // Suicide rockets and homing missiles
+)
+
+// DroneInput steers a drone; the drone bay sets its controls every tick
+type DroneInput struct {
+	Owner *Entity
+	Role  DroneRole
+
+	Thrust   float64
+	Rotation float64
+	shoot    bool
+}
+
+// GetThrust returns the drone's thrust
+func (d *DroneInput) GetThrust() float64 {
+	return d.Thrust
+}
+
+// GetRotation returns the drone's rotation input
+func (d *DroneInput) GetRotation() float64 {
+	return d.Rotation
+}
+
+// ShouldShoot returns true when the drone's turret has a clear shot at a target
+func (d *DroneInput) ShouldShoot() bool {
+	return d.shoot
+}
+
+// HasTarget returns true when the drone's turret has a target
+func (d *DroneInput) HasTarget() bool {
+	return d.shoot
+}
+
+// Update does nothing: the drone bay steers the drones
+func (d *DroneInput) Update(deltaTime float64) {
+}
+
+// DroneBay launches, steers and recalls the drones escorting the player
+// Drones are player-faction ships that orbit their owner, each with a small turret, and die with it
+type DroneBay struct {
+	// Drones in flight, in orbit slot order
+	drones []*Entity
+
+	// Time until the next drone can launch (seconds)
+	launchTimer float64
+
+	// Current angle of the first orbit slot (radians)
+	orbit float64
+}
+
+// Launched returns true if id is one of the drones in flight
+func (b *DroneBay) Launched(id uint64) bool {
+	for _, drone := range b.drones {
+		if drone.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// updateDrones recalls the drones of a dead or replaced owner, launches the player's missing drones
+// and steers every drone around its orbit slot, shooting what comes near the owner
+func (g *Game) updateDrones(deltaTime float64) {
+	bay := &g.drones
+	owner := g.player
+	ownerAlive := owner != nil && owner.Active && owner.Health > 0
+
+	live := bay.drones[:0]
+	for _, drone := range bay.drones {
+		switch {
+		case !drone.Active || drone.Health <= 0:
+			// Shot down: the ship builds a replacement
+			bay.launchTimer = DroneRebuildTime
+		case !ownerAlive || drone.Input.(*DroneInput).Owner != owner:
+			// The owner is gone: its drones go down with it
+			g.createDestroyedIndicator(drone.X, drone.Y, drone.Faction)
+			drone.Active = false
+			g.world.UnregisterEntity(drone)
+		default:
+			live = append(live, drone)
+		}
+	}
+	clear(bay.drones[len(live):])
+	bay.drones = live
+	if !ownerAlive {
+		return
+	}
+
+	bay.launchTimer -= deltaTime
+	if len(bay.drones) < owner.Drones && bay.launchTimer <= 0 {
+		g.launchDrone(owner)
+		bay.launchTimer = DroneLaunchInterval
+	}
+
+	bay.orbit = math.Remainder(bay.orbit+DroneOrbitSpeed*deltaTime, 2*math.Pi)
+	for i, drone := range bay.drones {
+		angle := bay.orbit + 2*math.Pi*float64(i)/float64(len(bay.drones))
+		g.steerDrone(drone, owner.X+math.Cos(angle)*DroneOrbitRadius, owner.Y+math.Sin(angle)*DroneOrbitRadius, deltaTime)
+	}
+}
+
+// launchDrone builds a drone at the owner's hull, taking whichever role the escort has fewer of
+func (g *Game) launchDrone(owner *Entity) {
+	gunners := 0
+	for _, drone := range g.drones.drones {
+		if drone.Input.(*DroneInput).Role == DroneGunner {
+			gunners++
+		}
+	}
+	role := DroneGunner
+	if gunners > len(g.drones.drones)-gunners {
+		role = DronePointDefense
+	}
+
+	drone := NewEntityWithShipType(owner.X, owner.Y, EntityTypeEnemy, ShipTypeDrone, &DroneInput{Owner: owner, Role: role})
+	drone.Faction = FactionPlayer
+	drone.NoCollision = true // Passes through its owner and allies, still hit by enemies
+	drone.Rotation = owner.Rotation
+	drone.VX, drone.VY = owner.VX, owner.VY
+	g.world.RegisterEntity(drone)
+	g.drones.drones = append(g.drones.drones, drone)
+}
+
+// steerDrone flies a drone toward its orbit slot at (slotX, slotY) and aims its turret
+// A drone that fell past the leash distance ignores targets until it's back with its owner
+func (g *Game) steerDrone(drone *Entity, slotX, slotY, deltaTime float64) {
+	input := drone.Input.(*DroneInput)
+	owner := input.Owner
+	leashed := drone.DistanceTo(owner) > DroneLeashDistance
+
+	// Match the owner's velocity, plus a pull toward the slot
+	maxSpeed := GetShipTypeConfig(ShipTypeDrone).Speed
+	pullX, pullY := (slotX-drone.X)*3, (slotY-drone.Y)*3
+	if pull := math.Hypot(pullX, pullY); pull > maxSpeed {
+		pullX, pullY = pullX/pull*maxSpeed, pullY/pull*maxSpeed
+	}
+	correctionX := owner.VX + pullX - drone.VX
+	correctionY := owner.VY + pullY - drone.VY
+
+	input.Thrust = 0
+	if math.Hypot(correctionX, correctionY) < 10 {
+		input.Rotation = math.Max(-1, math.Min(1, -drone.AngularVelocity))
+	} else {
+		angleDiff := math.Remainder(math.Atan2(correctionY, correctionX)-drone.Rotation, 2*math.Pi)
+		input.Rotation = math.Max(-1, math.Min(1, angleDiff*2-drone.AngularVelocity*0.3))
+		if math.Abs(angleDiff) < droneFacingTolerance {
+			input.Thrust = 1
+		}
+	}
+
+	var target *Entity
+	if !leashed {
+		target = g.droneTarget(drone, input)
+	}
+	updateAITurrets(drone, target, g.world, deltaTime)
+	input.shoot = false
+	for i := range drone.Turrets {
+		if drone.Turrets[i].Target.HasTarget {
+			input.shoot = true
+		}
+	}
+}
+
+// droneTarget returns the nearest hostile within DroneTargetRange of the owner that the drone's role shoots at
+func (g *Game) droneTarget(drone *Entity, input *DroneInput) *Entity {
+	owner := input.Owner
+	var best *Entity
+	bestDistanceSq := DroneTargetRange * DroneTargetRange
+	for _, candidate := range g.world.QueryTargetCandidates(owner.X, owner.Y, DroneTargetRange) {
+		if !candidate.Active || candidate.Health <= 0 || candidate.Cloak.Hidden() {
+			continue
+		}
+		if GetEntityFaction(candidate) == drone.Faction {
+			continue
+		}
+		switch input.Role {
+		case DroneGunner:
+			if candidate.Type != EntityTypeEnemy {
+				continue
+			}
+		case DronePointDefense:
+			if !isMissileTarget(candidate) {
+				continue
+			}
+		}
+		dx, dy := candidate.X-owner.X, candidate.Y-owner.Y
+		if distanceSq := dx*dx + dy*dy; distanceSq < bestDistanceSq {
+			bestDistanceSq = distanceSq
+			best = candidate
+		}
+	}
+	return best
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"image/color"
+	"math"
+)
+
+const (
+	// EliteBaseChance is the chance a spawned enemy is elite in wave 1
+	EliteBaseChance = 0.02
+
+	// EliteChancePerWave is added to the elite chance every wave
+	EliteChancePerWave = 0.01
+
+	// EliteMaxChance caps the elite chance in late waves
+	EliteMaxChance = 0.25
+
+	// EliteSplitSpread is how far from the parent split-off enemies appear (pixels)
+	EliteSplitSpread = 20.0
+)
+
+// EliteModifier identifies an elite upgrade applied to an enemy when it spawns
+type EliteModifier int
+
+const (
+	EliteNone EliteModifier = iota
+	EliteShielded
+	EliteFast
+	EliteSplitting
+	EliteRegenerating
+)
+
+// EliteModifiers lists every modifier an enemy can roll
+var EliteModifiers = []EliteModifier{EliteShielded, EliteFast, EliteSplitting, EliteRegenerating}
+
+// EliteModifierConfig holds the stat changes and look of an elite modifier
+type EliteModifierConfig struct {
+	Name              string
+	AuraColor         color.RGBA
+	HealthScale       float64 // Multiplier for max health
+	Shield            float64 // Shield absorbing weapon damage, as a fraction of max health
+	AccelerationScale float64 // Multiplier for thrust and turn acceleration
+	RegenPerSecond    float64 // Health regenerated per second, as a fraction of max health
+	SplitCount        int     // Regular enemies spawned on death
+	SplitInto         EnemyType
+	XPMultiplier      int // Multiplier for the XP dropped on death
+}
+
+// GetEliteModifierConfig returns the configuration for an elite modifier
+func GetEliteModifierConfig(modifier EliteModifier) EliteModifierConfig {
+	switch modifier {
+	case EliteShielded:
+		return EliteModifierConfig{
+			Name:              "Shielded",
+			AuraColor:         color.RGBA{80, 160, 255, 200}, // Blue
+			HealthScale:       1.0,
+			Shield:            1.0, // Shield as strong as the hull
+			AccelerationScale: 1.0,
+			XPMultiplier:      3,
+		}
+	case EliteFast:
+		return EliteModifierConfig{
+			Name:              "Fast",
+			AuraColor:         color.RGBA{255, 230, 80, 200}, // Yellow
+			HealthScale:       1.0,
+			AccelerationScale: 1.8,
+			XPMultiplier:      3,
+		}
+	case EliteSplitting:
+		return EliteModifierConfig{
+			Name:              "Splitting",
+			AuraColor:         color.RGBA{80, 255, 120, 200}, // Green
+			HealthScale:       1.5,
+			AccelerationScale: 1.0,
+			SplitCount:        3,
+			SplitInto:         EnemyTypeRocket,
+			XPMultiplier:      3,
+		}
+	case EliteRegenerating:
+		return EliteModifierConfig{
+			Name:              "Regenerating",
+			AuraColor:         color.RGBA{255, 80, 200, 200}, // Pink
+			HealthScale:       1.5,
+			AccelerationScale: 1.0,
+			RegenPerSecond:    0.1, // Full heal in 10 seconds
+			XPMultiplier:      4,
+		}
+	default:
+		return EliteModifierConfig{
+			Name:              "",
+			HealthScale:       1.0,
+			AccelerationScale: 1.0,
+			XPMultiplier:      1,
+		}
+	}
+}
+
+// EliteChance returns the chance an enemy spawned in the given wave is elite
+func EliteChance(waveNumber int) float64 {
+	return math.Min(EliteBaseChance+EliteChancePerWave*float64(waveNumber-1), EliteMaxChance)
+}
+
+// RollEliteModifier returns a random modifier with the wave's elite chance (EliteNone otherwise)
+func RollEliteModifier(waveNumber int) EliteModifier {
+	if runRNG.Float64() >= EliteChance(waveNumber) {
+		return EliteNone
+	}
+	return EliteModifiers[runRNG.Intn(len(EliteModifiers))]
+}
+
+// ApplyEliteModifier upgrades a freshly spawned ship with an elite modifier
+func ApplyEliteModifier(entity *Entity, modifier EliteModifier) {
+	eliteConfig := GetEliteModifierConfig(modifier)
+	entity.Elite = modifier
+	entity.MaxHealth *= eliteConfig.HealthScale
+	entity.Health = entity.MaxHealth
+	entity.Shield = entity.MaxHealth * eliteConfig.Shield
+}
+
+// updateElite applies per-tick elite effects (regeneration)
+func updateElite(entity *Entity, deltaTime float64) {
+	regen := GetEliteModifierConfig(entity.Elite).RegenPerSecond
+	if regen > 0 && entity.Health > 0 {
+		entity.Health = math.Min(entity.Health+entity.MaxHealth*regen*deltaTime, entity.MaxHealth)
+	}
+}
+
+// absorbShieldDamage takes as much damage as the entity's shield can and returns the rest
+func absorbShieldDamage(entity *Entity, damage float64) float64 {
+	if entity.Shield <= 0 {
+		return damage
+	}
+	absorbed := math.Min(entity.Shield, damage)
+	entity.Shield -= absorbed
+	return damage - absorbed
+}
+
+// splitElite spawns the regular enemies a splitting elite breaks into on death
+func (g *Game) splitElite(entity *Entity) {
+	eliteConfig := GetEliteModifierConfig(entity.Elite)
+	for i := 0; i < eliteConfig.SplitCount; i++ {
+		angle := float64(i) / float64(eliteConfig.SplitCount) * 2 * math.Pi
+		x := entity.X + math.Cos(angle)*EliteSplitSpread
+		y := entity.Y + math.Sin(angle)*EliteSplitSpread
+
+		aiInput := CreateEnemyAIWithType(eliteConfig.SplitInto)
+		split := NewEntityWithShipType(x, y, EntityTypeEnemy, GetEnemyTypeConfig(eliteConfig.SplitInto).ShipType, aiInput)
+		split.Faction = entity.Faction
+
+		// Scatter outward, keeping the parent's momentum
+		split.VX = entity.VX + math.Cos(angle)*100
+		split.VY = entity.VY + math.Sin(angle)*100
+		g.world.RegisterEntity(split)
+	}
+}
+
+// onHitStopKill triggers a hit-stop when the player lands the killing blow on an elite
+func (g *Game) onHitStopKill(event Event) {
+	if event.IsFrom(g.player) && event.Target.Elite != EliteNone {
+		g.hitStop.Trigger(HitStopEliteKill)
+	}
+}
@@ -0,0 +1,36 @@
+package game
+
+const (
+	// EMPBlastRadius is the radius of an EMP missile's blast (pixels)
+	EMPBlastRadius = 160.0
+
+	// EMPDisableTime is how long the blast keeps ships disabled (seconds)
+	EMPDisableTime = 3.0
+
+	// EMPRingLifetime is how long the blast ring stays on screen (seconds)
+	EMPRingLifetime = 0.5
+)
+
+// detonateEMP disables every enemy ship caught in an EMP missile's blast
+// It runs however the missile goes off: on impact, on timeout or when shot down
+func (g *Game) detonateEMP(missile *Entity) {
+	for _, entity := range g.world.GetEntitiesInRadius(missile.X, missile.Y, EMPBlastRadius) {
+		if entity.Type != EntityTypePlayer && entity.Type != EntityTypeEnemy {
+			continue
+		}
+		if GetEntityFaction(entity) == missile.OwnerFaction {
+			continue
+		}
+		entity.Status.Disable(EMPDisableTime)
+	}
+
+	ring := NewEntity(missile.X, missile.Y, EMPBlastRadius, EntityTypeDestroyedIndicator, nil)
+	ring.Indicator.Kind = IndicatorKindEMP
+	ring.Faction = missile.OwnerFaction
+	ring.Active = true
+	ring.Health = 1.0 // Small health value so it renders
+	ring.MaxHealth = 1.0
+	ring.Lifetime = EMPRingLifetime
+	ring.NoCollision = true
+	g.world.RegisterEntity(ring)
+}
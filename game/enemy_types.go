@@ -9,17 +9,34 @@ const (
 	EnemyTypeRocket      EnemyType = iota // Chases player and explodes on contact
 	EnemyTypeShooter                      // Shoots rockets at player
 	EnemyTypeShooterTwin                  // Shoots rockets and bullets at player
-
+	EnemyTypeSwarm                        // Group of tiny ships steered by one GroupAI
+	EnemyTypeMiniBoss                     // Gunship that flees to heal when hurt and calls reinforcements
+	EnemyTypeStalker                      // Cloaked shooter that decloaks to attack
+	EnemyTypeCapital                      // Core with hull sections welded to it, each carrying turrets (see capital.go)
 )
 
 // EnemyTypeConfig holds configuration for each enemy type
 type EnemyTypeConfig struct {
 	Type          EnemyType
+	Name          string
 	ShipType      ShipType
 	Speed         float64
 	Health        float64
 	Radius        float64
-	ShootCooldown float64 // Only used for shooter type
+	ShootCooldown float64 // Only used for shooter type; the shortest a ship can roll (see RollShootCooldown)
+	CooldownRange float64 // How much longer than ShootCooldown a ship's own cooldown can be rolled
+	GroupSize     int     // Ships spawned together under one GroupAI (0 = a single ship with its own AI)
+	Cost          int     // Spawn budget points a random wave spends on one of these
+	SpawnWeight   float64 // Relative chance of being picked for random waves
+}
+
+// RollShootCooldown rolls a ship's own shoot cooldown at spawn, between ShootCooldown and ShootCooldown+CooldownRange
+// Types without a range don't draw from rng, and the configs themselves never do: they're looked up while drawing
+func (c EnemyTypeConfig) RollShootCooldown(rng *rand.Rand) float64 {
+	if c.CooldownRange <= 0 {
+		return c.ShootCooldown
+	}
+	return c.ShootCooldown + rng.Float64()*c.CooldownRange
 }
 
 // GetEnemyTypeConfig returns configuration for an enemy type
@@ -27,43 +44,129 @@ func GetEnemyTypeConfig(enemyType EnemyType) EnemyTypeConfig {
 	switch enemyType {
 	case EnemyTypeRocket:
 		return EnemyTypeConfig{
-			Type:     EnemyTypeRocket,
-			ShipType: ShipTypeHomingSuicide,
-			Speed:    200.0, // Faster than shooter
-			Health:   30.0,  // Less health
-			Radius:   10.0,
+			Type:        EnemyTypeRocket,
+			Name:        "Rocket",
+			ShipType:    ShipTypeHomingSuicide,
+			Speed:       200.0, // Faster than shooter
+			Health:      30.0,  // Less health
+			Radius:      10.0,
+			Cost:        1,
+			SpawnWeight: 0.45,
 		}
 	case EnemyTypeShooter:
 		return EnemyTypeConfig{
 			Type:          EnemyTypeShooter,
+			Name:          "Shooter",
 			ShipType:      ShipTypeShooter,
 			Speed:         120.0, // Slower
 			Health:        50.0,  // More health
 			Radius:        12.0,
-			ShootCooldown: 1.0 + rand.Float64()*1.5, // 1-2.5 seconds
+			ShootCooldown: 1.0, // 1-2.5 seconds, rolled per ship
+			CooldownRange: 1.5,
+			Cost:          3,
+			SpawnWeight:   0.30,
 		}
 	case EnemyTypeShooterTwin:
 		return EnemyTypeConfig{
 			Type:          EnemyTypeShooterTwin,
+			Name:          "ShooterTwin",
 			ShipType:      ShipTypePlayer,
 			Speed:         120.0, // Slower
 			Health:        50.0,  // More health
 			Radius:        12.0,
-			ShootCooldown: 1.0 + rand.Float64()*1.5, // 1-2.5 seconds
+			ShootCooldown: 1.0, // 1-2.5 seconds, rolled per ship
+			CooldownRange: 1.5,
+			Cost:          5,
+			SpawnWeight:   0.17,
+		}
+	case EnemyTypeSwarm:
+		return EnemyTypeConfig{
+			Type:        EnemyTypeSwarm,
+			Name:        "Swarm",
+			ShipType:    ShipTypeSwarmer,
+			Speed:       260.0,
+			Health:      6.0,
+			Radius:      5.0,
+			GroupSize:   12,
+			Cost:        6,
+			SpawnWeight: 0.08,
+		}
+	case EnemyTypeMiniBoss:
+		return EnemyTypeConfig{
+			Type:          EnemyTypeMiniBoss,
+			Name:          "MiniBoss",
+			ShipType:      ShipTypeGunship,
+			Speed:         150.0,
+			Health:        400.0,
+			Radius:        22.0,
+			ShootCooldown: 0.8,
+			Cost:          20, // Too expensive for the first few random waves
+			SpawnWeight:   0.04,
+		}
+	case EnemyTypeStalker:
+		return EnemyTypeConfig{
+			Type:          EnemyTypeStalker,
+			Name:          "Stalker",
+			ShipType:      ShipTypeStalker,
+			Speed:         160.0,
+			Health:        40.0,
+			Radius:        11.0,
+			ShootCooldown: 0.5,
+			Cost:          4,
+			SpawnWeight:   0.08,
+		}
+	case EnemyTypeCapital:
+		return EnemyTypeConfig{
+			Type:          EnemyTypeCapital,
+			Name:          "Capital",
+			ShipType:      ShipTypeCapitalCore,
+			Speed:         50.0,
+			Health:        1200.0,
+			Radius:        26.0,
+			ShootCooldown: 1.5,
+			Cost:          40, // Only late random waves can afford it
+			SpawnWeight:   0.02,
 		}
 	default:
 		return GetEnemyTypeConfig(EnemyTypeRocket)
 	}
 }
 
-// GetRandomEnemyType returns a random enemy type (weighted towards homing suicide)
+// EnemyTypes lists every enemy type
+var EnemyTypes = []EnemyType{EnemyTypeRocket, EnemyTypeShooter, EnemyTypeShooterTwin, EnemyTypeSwarm, EnemyTypeMiniBoss, EnemyTypeStalker, EnemyTypeCapital}
+
+// GetEnemyTypeByName returns the enemy type with the given config name
+func GetEnemyTypeByName(name string) (EnemyType, bool) {
+	for _, enemyType := range EnemyTypes {
+		if GetEnemyTypeConfig(enemyType).Name == name {
+			return enemyType, true
+		}
+	}
+	return 0, false
+}
+
+// GetRandomEnemyType returns a random enemy type by spawn weight (weighted towards homing suicide)
 func GetRandomEnemyType() EnemyType {
-	r := rand.Float64()
-	if r < 0.5 {
-		return EnemyTypeRocket
-	} else if r < 0.8 {
-		return EnemyTypeShooter
-	} else {
-		return EnemyTypeShooterTwin
+	enemyType, _ := pickWeightedEnemyType(EnemyTypes)
+	return enemyType
+}
+
+// pickWeightedEnemyType returns a random type from candidates by spawn weight (false if candidates is empty)
+func pickWeightedEnemyType(candidates []EnemyType) (EnemyType, bool) {
+	if len(candidates) == 0 {
+		return EnemyTypeRocket, false
+	}
+	totalWeight := 0.0
+	for _, enemyType := range candidates {
+		totalWeight += GetEnemyTypeConfig(enemyType).SpawnWeight
+	}
+
+	r := runRNG.Float64() * totalWeight
+	for _, enemyType := range candidates {
+		r -= GetEnemyTypeConfig(enemyType).SpawnWeight
+		if r < 0 {
+			return enemyType, true
+		}
 	}
+	return candidates[len(candidates)-1], true
 }
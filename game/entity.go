@@ -10,6 +10,9 @@ const MaxEntitySpeed = 500.0
 
 // Entity represents a game entity (player, enemy, or projectile)
 type Entity struct {
+	// Unique ID assigned by the world on registration (0 means unregistered)
+	ID uint64
+
 	// Position in world coordinates
 	X, Y float64
 
@@ -28,6 +31,12 @@ type Entity struct {
 	// Maximum health
 	MaxHealth float64
 
+	// Shield points absorbing weapon damage before health (shielded elites)
+	Shield float64
+
+	// Dents left in the shield bubble by recent hits (visual only, see shield_bubble.go)
+	ShieldBubble ShieldBubble
+
 	// Collision radius in pixels
 	Radius float64
 
@@ -43,6 +52,9 @@ type Entity struct {
 	// Faction (determined at spawn time)
 	Faction Faction
 
+	// Elite modifier applied at spawn (EliteNone for regular ships)
+	Elite EliteModifier
+
 	// Current cell coordinates (for fast lookup)
 	CellX, CellY int
 
@@ -52,15 +64,101 @@ type Entity struct {
 	// Time since creation (for projectiles to avoid immediate collision with shooter)
 	Age float64
 
-	// Owner entity (for projectiles, tracks who fired them)
-	Owner *Entity
+	// Shooter of a projectile or homing rocket: its ID and its faction when it fired
+	// Kept by value so attribution stays correct after the shooter dies (IDs are never reused)
+	OwnerID      uint64
+	OwnerFaction Faction
+
+	// Recent weapon hits taken, for kill credit and assists
+	Ledger DamageLedger
+
+	// Weapon that fired this entity (projectiles and homing rockets)
+	Weapon WeaponType
 
 	// NoCollision flag - if true, entity doesn't collide with other entities (except for special cases like explosions)
 	NoCollision bool
 
+	// Entity this one is attached to (nil if free): its position and rotation follow the parent's every tick,
+	// at an offset in the parent's frame (see attach.go)
+	Parent                           *Entity
+	AttachX, AttachY, AttachRotation float64
+
 	// Lifetime in seconds (0 means no lifetime limit)
 	// When Age >= Lifetime, entity will be destroyed
 	Lifetime float64
+
+	// Trail of recent positions (nil for entities that don't record breadcrumbs)
+	Trail *Trail
+
+	// Per-entity turret state (initialized from the ship type's mounts)
+	Turrets []TurretState
+
+	// Sprite animation state (ships with art only)
+	Animation AnimationController
+
+	// Cloaking device state (ships with Cloaking only, see cloak.go)
+	Cloak CloakData
+
+	// Sensor upgrade level, widening the range cloaked ships are detected at (player only)
+	Sensors int
+
+	// Salvage beam upgrade level, gathering salvage in a radius (player only, see salvage.go)
+	SalvageBeam int
+
+	// Number of drones orbiting the ship (player only, see drone.go)
+	Drones int
+
+	// Sentry turrets carried, ready to deploy (player only, see sentry.go)
+	Sentries int
+
+	// Timed conditions such as EMP disables (see status.go)
+	Status StatusEffects
+
+	// Targeting pass that last claimed this entity as a turret target (see Game.updatePlayerTargeting)
+	// A stamp instead of a per-frame set keeps targeting allocation-free
+	targetedStamp uint64
+
+	// Type-specific payloads (only meaningful for the matching EntityType)
+	Pickup    PickupData    // EntityTypeXP, and salvage debris (Value is its credits)
+	Indicator IndicatorData // EntityTypeDestroyedIndicator
+	Asteroid  AsteroidData  // EntityTypeAsteroid
+	Station   StationData   // EntityTypeStation
+}
+
+// PickupData holds the state of an XP pickup
+type PickupData struct {
+	Target *Entity     // Entity the pickup homes toward; only it can collect the pickup
+	Value  int         // Score awarded when collected
+	Source ScoreSource // What the score was earned for (see death_recap.go)
+	Orbs   int         // Orbs merged into this one (see xp_merge.go; 0 or 1 = a single orb)
+	Vacuum float64     // Seconds left to reach the target when pulled in by a wave clear (see wave_clear.go)
+}
+
+// IndicatorKind identifies why a destroyed indicator was spawned (selects its color)
+type IndicatorKind int
+
+const (
+	IndicatorKindTimeout      IndicatorKind = iota // Missile timed out or was destroyed (faction color)
+	IndicatorKindKill                              // Enemy shot down by the player (yellow)
+	IndicatorKindEMP                               // EMP blast (expanding ring, see emp.go)
+	IndicatorKindPingAttack                        // Team ping: attack here (see ping.go)
+	IndicatorKindPingHelp                          // Team ping: help needed here
+	IndicatorKindPingLoot                          // Team ping: loot here
+	IndicatorKindImpactSparks                      // Spark burst where a projectile hit (see impact_fx.go)
+	IndicatorKindScorch                            // Scorch decal on a big entity that was hit
+	IndicatorKindArc                               // Chain lightning between the targets of an arc hit (see arc.go)
+)
+
+// IndicatorData holds the state of a destroyed indicator
+type IndicatorData struct {
+	Kind IndicatorKind
+
+	// Scorch decals: the entity they're on and their offset in its frame (unrotated)
+	HostID           uint64
+	OffsetX, OffsetY float64
+
+	// Chain lightning: world positions of the targets the arc jumped through, as x, y pairs
+	Path []float64
 }
 
 // EntityType identifies the type of entity
@@ -73,6 +171,10 @@ const (
 	EntityTypeDestroyedIndicator
 	EntityTypeXP
 	EntityTypeHomingRocket
+	EntityTypeAsteroid
+	EntityTypeStation
+	EntityTypeDebris // Drifting wreckage, some of it salvage (see debris.go)
+	entityTypeCount
 )
 
 // HomingRocketConfig holds configuration for homing rockets
@@ -141,6 +243,8 @@ func NewEntityWithShipType(x, y float64, entityType EntityType, shipType ShipTyp
 		Active:    true,
 		Age:       0.0,
 		Faction:   FactionEnemy, // Default, should be set explicitly
+		Turrets:   NewTurretStates(shipType),
+		Cloak:     CloakData{Capable: shipConfig.Cloaking, Engaged: shipConfig.Cloaking},
 	}
 	return entity
 }
@@ -171,9 +275,34 @@ func (e *Entity) Update(deltaTime float64) {
 		return
 	}
 
+	friction := e.Steer(deltaTime)
+
+	// Apply friction to velocity
+	e.VX *= friction
+	e.VY *= friction
+
+	// Apply speed limit (after all velocity updates)
+	if e.IsSpeedLimited() {
+		e.clampSpeed()
+	}
+
+	// Apply velocity to position
+	e.X += e.VX * deltaTime
+	e.Y += e.VY * deltaTime
+}
+
+// Steer applies input and type-specific behavior to the entity's velocity and advances its timers
+// Returns the velocity friction factor for this tick; friction, speed limit and position
+// are left to Update
+func (e *Entity) Steer(deltaTime float64) float64 {
+	friction := 1.0
+
 	// Update age
 	e.Age += deltaTime
 
+	// Advance turret cooldowns
+	e.updateTurrets(deltaTime)
+
 	// Special handling for homing rockets: predictive intercept with acceleration
 	if e.Type == EntityTypeHomingRocket && e.Input != nil {
 		rocketConfig := GetHomingRocketConfig()
@@ -221,19 +350,33 @@ func (e *Entity) Update(deltaTime float64) {
 			}
 		}
 
-		// Apply friction to velocity
-		e.VX *= rocketConfig.Friction
-		e.VY *= rocketConfig.Friction
+		// Friction is applied during integration
+		friction = StepFriction(rocketConfig.Friction, deltaTime)
 	} else if e.Input != nil && e.Type != EntityTypeProjectile {
 		// Standard physics for other entities
 		// Get ship config for physics properties
 		shipConfig := GetShipTypeConfig(e.ShipType)
 
-		// Handle rotation (angular velocity)
+		// Fast elites accelerate and turn harder
+		accelerationScale := 1.0
+		if e.Elite != EliteNone {
+			accelerationScale = GetEliteModifierConfig(e.Elite).AccelerationScale
+		}
+		if e.Status.IsSlowed() {
+			accelerationScale *= SlowedAccelerationScale
+		}
+
+		// EMPs cut AI controls and scramble the player's
 		rotationInput := e.Input.GetRotation()
+		thrustInput := e.Input.GetThrust()
+		if e.Status.IsDisabled() {
+			rotationInput, thrustInput = e.disabledControls(rotationInput, thrustInput)
+		}
+
+		// Handle rotation (angular velocity)
 		if math.Abs(rotationInput) > 0.01 {
 			// Apply angular acceleration
-			e.AngularVelocity += rotationInput * shipConfig.AngularAcceleration * deltaTime
+			e.AngularVelocity += rotationInput * shipConfig.AngularAcceleration * accelerationScale * deltaTime
 
 			// Clamp to max angular speed
 			if e.AngularVelocity > shipConfig.MaxAngularSpeed {
@@ -243,14 +386,13 @@ func (e *Entity) Update(deltaTime float64) {
 			}
 		} else {
 			// Apply angular friction
-			e.AngularVelocity *= 0.9999
+			e.AngularVelocity *= StepFriction(0.9999, deltaTime)
 		}
 
 		// Update rotation
 		e.Rotation += e.AngularVelocity * deltaTime
 
 		// Handle thrust (forward/backward acceleration)
-		thrustInput := e.Input.GetThrust()
 		if math.Abs(thrustInput) > 0.01 {
 			// Calculate forward direction vector
 			// Rotation 0 points right (east), matching the rendering convention
@@ -258,23 +400,25 @@ func (e *Entity) Update(deltaTime float64) {
 			forwardY := math.Sin(e.Rotation)
 
 			// Apply acceleration in forward/backward direction
-			acceleration := thrustInput * shipConfig.Acceleration * deltaTime
+			acceleration := thrustInput * shipConfig.Acceleration * accelerationScale * deltaTime
 			e.VX += forwardX * acceleration
 			e.VY += forwardY * acceleration
 		}
 
-		// Apply friction to velocity
-		e.VX *= shipConfig.Friction
-		e.VY *= shipConfig.Friction
+		// Friction is applied during integration
+		friction = StepFriction(shipConfig.Friction, deltaTime)
 	} else if e.Type == EntityTypeProjectile {
 		// Projectiles maintain their velocity without physics
 		// (they're already set when created)
+	} else if e.Type == EntityTypeAsteroid || e.Type == EntityTypeStation {
+		// Asteroids and derelict stations drift and tumble
+		e.Rotation += e.AngularVelocity * deltaTime
 	} else if e.Type == EntityTypeXP {
-		// XP entities move toward their target (stored in Owner)
-		if e.Owner != nil && e.Owner.Active {
+		// XP entities move toward their pickup target
+		if target := e.Pickup.Target; target != nil && target.Active {
 			// Calculate direction to target
-			dx := e.Owner.X - e.X
-			dy := e.Owner.Y - e.Y
+			dx := target.X - e.X
+			dy := target.Y - e.Y
 			distance := math.Sqrt(dx*dx + dy*dy)
 
 			if distance > 0 {
@@ -289,6 +433,12 @@ func (e *Entity) Update(deltaTime float64) {
 					xpSpeed = MaxEntitySpeed * 2.0 // Speed up even more when close
 				}
 
+				// A wave-clear vacuum pulls the orb in fast enough to arrive when it ends
+				if e.Pickup.Vacuum > 0 {
+					xpSpeed = math.Max(xpSpeed, distance/math.Max(e.Pickup.Vacuum, deltaTime))
+					e.Pickup.Vacuum -= deltaTime
+				}
+
 				// Set velocity toward target
 				e.VX = dx * xpSpeed
 				e.VY = dy * xpSpeed
@@ -296,15 +446,13 @@ func (e *Entity) Update(deltaTime float64) {
 		}
 	}
 
-	// Apply speed limit to all entities (after all velocity updates)
-	// XP entities and projectiles are exempt (projectiles inherit ship velocity so need higher cap)
-	if e.Type != EntityTypeXP && e.Type != EntityTypeProjectile {
-		e.clampSpeed()
-	}
+	return friction
+}
 
-	// Apply velocity to position
-	e.X += e.VX * deltaTime
-	e.Y += e.VY * deltaTime
+// IsSpeedLimited returns true if the entity is held to MaxEntitySpeed
+// XP entities and projectiles are exempt (projectiles inherit ship velocity so need higher cap)
+func (e *Entity) IsSpeedLimited() bool {
+	return e.Type != EntityTypeXP && e.Type != EntityTypeProjectile
 }
 
 // clampSpeed clamps the entity's velocity to the maximum speed
@@ -332,6 +480,7 @@ func (e *Entity) IsColliding(other *Entity) bool {
 
 // Reset resets the entity for reuse in pooling
 func (e *Entity) Reset() {
+	e.ID = 0 // Reused entities get a fresh ID when registered again
 	e.X = 0
 	e.Y = 0
 	e.VX = 0
@@ -343,7 +492,28 @@ func (e *Entity) Reset() {
 	e.CellX = 0
 	e.CellY = 0
 	e.Age = 0.0
+	e.Parent = nil
 	e.Faction = FactionEnemy // Reset to default
+	e.Elite = EliteNone
+	e.Shield = 0
+	e.ShieldBubble = ShieldBubble{}
 	e.NoCollision = false
 	e.Lifetime = 0.0
+	e.Trail = nil
+	e.Turrets = e.Turrets[:0]
+	e.Animation = AnimationController{}
+	e.OwnerID = 0
+	e.OwnerFaction = FactionEnemy
+	e.Ledger.Reset()
+	e.Weapon = WeaponTypeBullet
+	e.Pickup = PickupData{}
+	e.Indicator = IndicatorData{}
+	e.Asteroid = AsteroidData{}
+	e.Station = StationData{}
+	e.Cloak = CloakData{}
+	e.Sensors = 0
+	e.SalvageBeam = 0
+	e.Drones = 0
+	e.Sentries = 0
+	e.Status = StatusEffects{}
 }
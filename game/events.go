@@ -0,0 +1,58 @@
+package game
+
+// EventType identifies a gameplay event published on the EventBus
+type EventType int
+
+const (
+	EventShotFired EventType = iota // Source fired Weapon
+	EventDamage                     // Source's Weapon hit Target for Amount damage at (HitX, HitY)
+	EventKill                       // Source's Weapon destroyed Target (Amount is the killing hit)
+	EventAssist                     // Source helped an ally destroy Target (Weapon is its last hit, Amount its total damage)
+	eventTypeCount
+)
+
+// Event is a gameplay event
+// Source is the ship responsible (nil if unknown or already destroyed); Target is the ship affected (nil for shots)
+// SourceID and SourceFaction identify the responsible ship even after it died, so kills still get credited
+type Event struct {
+	Type          EventType
+	Source        *Entity
+	SourceID      uint64
+	SourceFaction Faction
+	Target        *Entity
+	Weapon        WeaponType
+	Amount        float64
+	Burn          bool    // Damage from a burn tick rather than a hit (damage events only)
+	HitX, HitY    float64 // Where the hit landed (damage events only)
+}
+
+// IsFrom returns true if entity is the ship responsible for the event
+func (e Event) IsFrom(entity *Entity) bool {
+	return entity != nil && e.SourceID != 0 && e.SourceID == entity.ID
+}
+
+// EventHandler receives published events
+type EventHandler func(event Event)
+
+// EventBus delivers gameplay events to subscribers synchronously, in subscription order
+// Systems that only observe the simulation (stats, effects, audio) subscribe here instead of being called directly
+type EventBus struct {
+	handlers [eventTypeCount][]EventHandler
+}
+
+// NewEventBus creates an event bus with no subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe registers a handler for one event type
+func (b *EventBus) Subscribe(eventType EventType, handler EventHandler) {
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish delivers an event to every handler subscribed to its type
+func (b *EventBus) Publish(event Event) {
+	for _, handler := range b.handlers[event.Type] {
+		handler(event)
+	}
+}
@@ -8,6 +8,9 @@ type Faction int
 const (
 	FactionPlayer Faction = iota
 	FactionEnemy
+	FactionNeutral     // Environment (asteroids): hit by both sides, targeted by neither
+	FactionIndependent // Independent patrols: take sides following the player's reputation (see reputation.go)
+	factionCount
 )
 
 // FactionConfig holds configuration for each faction
@@ -27,6 +30,14 @@ var (
 			Faction: FactionEnemy,
 			Color:   color.RGBA{255, 0, 0, 255}, // Red for enemy faction
 		},
+		FactionNeutral: {
+			Faction: FactionNeutral,
+			Color:   color.RGBA{140, 130, 120, 255}, // Gray-brown for asteroids
+		},
+		FactionIndependent: {
+			Faction: FactionIndependent,
+			Color:   color.RGBA{80, 170, 255, 255}, // Blue for independents
+		},
 	}
 )
 
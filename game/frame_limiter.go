@@ -0,0 +1,120 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// BackgroundTPS is the tick and frame rate while the window is unfocused in battery saver mode
+	BackgroundTPS = 10
+
+	// frameCapSlack lets a frame through slightly early, so vsync jitter doesn't drop frames at a cap equal to the refresh rate
+	frameCapSlack = time.Millisecond
+)
+
+// FrameRateCap limits how often the game draws
+type FrameRateCap int
+
+const (
+	FrameRateVsync     FrameRateCap = iota // Draw once per display refresh
+	FrameRate30                            // At most 30 frames per second (vsync on)
+	FrameRate60                            // At most 60 frames per second (vsync on)
+	FrameRate120                           // At most 120 frames per second (vsync on)
+	FrameRateUnlimited                     // Vsync off, draw as often as possible
+	frameRateCapCount
+)
+
+// frameRateCapNames are the names used by the -fps flag
+var frameRateCapNames = [frameRateCapCount]string{"vsync", "30", "60", "120", "unlimited"}
+
+// String returns the cap's flag name
+func (c FrameRateCap) String() string {
+	if c < 0 || c >= frameRateCapCount {
+		return frameRateCapNames[FrameRateVsync]
+	}
+	return frameRateCapNames[c]
+}
+
+// ParseFrameRateCap parses a frame rate cap name ("vsync", "30", "60", "120" or "unlimited")
+func ParseFrameRateCap(name string) (FrameRateCap, error) {
+	for c, capName := range frameRateCapNames {
+		if name == capName {
+			return FrameRateCap(c), nil
+		}
+	}
+	return FrameRateVsync, fmt.Errorf("unknown frame rate cap %q", name)
+}
+
+// FPS returns the frames per second the cap allows (0 = no cap on top of vsync or the loop)
+func (c FrameRateCap) FPS() int {
+	switch c {
+	case FrameRate30:
+		return 30
+	case FrameRate60:
+		return 60
+	case FrameRate120:
+		return 120
+	default:
+		return 0
+	}
+}
+
+// FrameLimiter applies the frame rate cap and the battery saver's background throttle
+// Ebiten keeps calling Draw once per refresh with vsync on, so a cap skips the Draws that come too early; the screen
+// isn't cleared between frames, so a skipped Draw shows the previous frame again. The simulation is independent of the
+// frame rate: it keeps its configured TPS, except in the background where TPS drops to BackgroundTPS (Update steps by
+// wall-clock time, so the simulation keeps its speed with coarser steps)
+type FrameLimiter struct {
+	tickRate  int
+	throttled bool
+	nextDraw  time.Time
+}
+
+// NewFrameLimiter creates a frame limiter for the simulation's tick rate
+func NewFrameLimiter(tickRate int) *FrameLimiter {
+	return &FrameLimiter{tickRate: tickRate}
+}
+
+// ApplyFrameRateCap sets up vsync and screen clearing for the cap (call before ebiten.RunGame)
+func ApplyFrameRateCap(c FrameRateCap) {
+	ebiten.SetVsyncEnabled(c != FrameRateUnlimited)
+	ebiten.SetScreenClearedEveryFrame(false) // Draw fills the screen itself, and skipped frames keep it
+}
+
+// Update throttles the game while the window is unfocused, if the battery saver is on
+func (l *FrameLimiter) Update(batterySaver bool) {
+	throttle := batterySaver && !ebiten.IsFocused()
+	if throttle == l.throttled {
+		return
+	}
+	l.throttled = throttle
+	if throttle {
+		ebiten.SetTPS(BackgroundTPS)
+		fmt.Printf("Window unfocused, throttled to %d TPS\n", BackgroundTPS)
+	} else {
+		ebiten.SetTPS(l.tickRate)
+	}
+}
+
+// ShouldDraw reports whether a frame may be drawn now under the cap (or the background throttle)
+func (l *FrameLimiter) ShouldDraw(now time.Time, c FrameRateCap) bool {
+	fps := c.FPS()
+	if l.throttled {
+		fps = BackgroundTPS
+	}
+	if fps == 0 {
+		return true
+	}
+	if now.Before(l.nextDraw.Add(-frameCapSlack)) {
+		return false
+	}
+	interval := time.Second / time.Duration(fps)
+	l.nextDraw = l.nextDraw.Add(interval)
+	if l.nextDraw.Before(now) {
+		l.nextDraw = now.Add(interval) // Fell behind (or the first frame): don't catch up with a burst
+	}
+	return true
+}
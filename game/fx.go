@@ -0,0 +1,15 @@
+package game
+
+// MaxFXEntities is the global cap on live FX entities; the oldest are evicted first when it's reached (team pings never are)
+const MaxFXEntities = 256
+
+// isFXEntity returns true for visual-only entities (destroyed indicators)
+// FX entities live in World.FX instead of the spatial grid: no cells, no collision checks, no entity update loop
+func isFXEntity(entity *Entity) bool {
+	return entity.Type == EntityTypeDestroyedIndicator
+}
+
+// UpdateFX ages FX entities and removes the ones whose lifetime has expired
+func (w *World) UpdateFX(deltaTime float64) {
+	w.FX.update(w, deltaTime, nil)
+}
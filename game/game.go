@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"image/color"
 	"math"
-	"math/rand"
 	"runtime"
 	"time"
 
+	"billionslike3/game/assets"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
@@ -20,6 +21,9 @@ type Game struct {
 	camera          *Camera
 	config          Config
 
+	// Seed of the current run's random numbers (see rng.go)
+	runSeed int64
+
 	// Player entity
 	player *Entity
 
@@ -35,12 +39,38 @@ type Game struct {
 	waveNumber             int
 	enemiesPerWave         int
 	enemiesSpawnedThisWave int
-	waveSpawnTimer         float64 // Time between enemy spawns within a wave
+	waveSpawnTimer         float64 // Time since the last enemy spawn within a wave
+	waveSpawnInterval      float64 // Time between enemy spawns within a wave
 	waveCooldown           float64 // Time between waves
 
+	// Designed waves (nil = endless random waves) and the current wave's spawn order
+	waveSet   *WaveSet
+	waveQueue []EnemyType
+
 	// Player score
 	score int
 
+	// Credits to spend at station shops (earned alongside score from pickups)
+	credits int
+
+	// Station the player is docked at (nil when undocked, see docking.go)
+	dockedStation *Entity
+
+	// Escort/defend objective of this run (nil for plain wave survival, see mission.go)
+	mission *Mission
+
+	// Drones escorting the player (see drone.go)
+	drones DroneBay
+
+	// Sentry turrets the player deployed, oldest first (see sentry.go)
+	sentries []*Entity
+
+	// Capital ships in the world, with their surviving sections (see capital.go)
+	capitals []*CapitalShip
+
+	// Time until the player can ping again (see ping.go)
+	pingCooldown float64
+
 	// FPS tracking
 	fps              float64
 	fpsUpdateCounter int
@@ -49,6 +79,18 @@ type Game struct {
 	// Performance profiling
 	profiler *Profiler
 
+	// Times each frame's sections and reports frames slower than SlowFrameThreshold (see slow_frame.go)
+	frameTimer *FrameTimer
+
+	// Caps the frame rate and throttles the game in the background (see frame_limiter.go)
+	frameLimiter *FrameLimiter
+
+	// Tunes GOGC and the memory limit from the frame times (see gc_governor.go)
+	gcGovernor *GCGovernor
+
+	// Serves the pprof endpoints while enabled (-pprof or F11)
+	pprof *PprofServer
+
 	// FPS drop detection
 	lastFPSDropTime time.Time
 	fpsDropCooldown time.Duration
@@ -58,62 +100,255 @@ type Game struct {
 
 	// Last update time for delta time calculation
 	lastUpdateTime time.Time
+
+	// Number of simulation steps run so far
+	tickCount uint64
+
+	// Incremented every player targeting pass; entities claimed by a turret carry the current value
+	targetingStamp uint64
+
+	// Spectator server receiving snapshots (arena mode only, may be nil)
+	spectator *SpectatorServer
+
+	// Photo mode (pauses the simulation and frees the camera)
+	photoMode *PhotoMode
+
+	// What the player has discovered, shown on the full-screen map (see world_map.go)
+	worldMap *WorldMap
+
+	// Navigation target set on the world map (nil when none)
+	waypoint *Waypoint
+
+	// Hostile entities on a collision course with the player (rebuilt every frame)
+	collisionThreats    []*Entity
+	collisionPathBuffer [][2]float64
+
+	// Sprite atlas shared by every renderer instance (nil if loading failed)
+	sprites *assets.Atlas
+
+	// Effect shaders shared by every renderer instance (nil if compiling failed)
+	shaders *ShaderManager
+
+	// Spreads full AI re-evaluations across ticks
+	aiScheduler *AIScheduler
+
+	// Entities near a tractor beam (reused every tick)
+	tractorCandidates []*Entity
+
+	// Gameplay events (shots, damage, kills) for observers like the weapon stats
+	events *EventBus
+
+	// Brief slow-motion on big events (heavy hits, boss phases, elite kills)
+	hitStop *HitStop
+
+	// Camera jolt on hits and big explosions (see screen_shake.go)
+	screenShake *ScreenShake
+
+	// Per-weapon statistics for the player's current run (shown on the death screen)
+	weaponStats *WeaponStats
+
+	// Hits, score sources and purchases of the current run (shown on the death screen)
+	recap *DeathRecap
+
+	// Score, kills and load sampled once a minute (graphed on the death screen)
+	runStats *RunStats
+
+	// Live entity counts and their peaks for the statistics panel (F5)
+	statsPanel *StatsPanel
+
+	// Where damage landed recently, for the damage heatmap overlay (F10)
+	heatmap *Heatmap
+
+	// Merges crowded XP orbs (see xp_merge.go)
+	xpMerger *XPMerger
+
+	// Tracks the current wave until it's destroyed, for the wave-clear bonus (see wave_clear.go)
+	waveClear *WaveClear
+
+	// The run's last checkpoint, offered on the death screen (nil until one is reached, see checkpoint.go)
+	checkpoint *Checkpoint
+	// Keep the checkpoint in the checkpoint file (see EnableCheckpointFile)
+	checkpointFile bool
+
+	// Remaining lives (nil when Config.Lives is 0: dying ends the run, see lives.go)
+	lives *Lives
+
+	// Group controllers (swarms)
+	groups []*GroupAI
+
+	// Procedural sector generator (nil unless Config.Sectors is enabled)
+	sectors *SectorGenerator
+
+	// Recent kills by and against the player's side, and a reused buffer for crediting assists
+	killFeed     *KillFeed
+	radio        *Radio
+	assistBuffer []DamageRecord
+
+	// Installed mods and the enable/disable menu (F6)
+	mods        []*Mod
+	modMenuOpen bool
+
+	// Loadout menu (F7) for per-turret targeting policies
+	loadoutMenuOpen bool
+
+	// Local co-op on a split screen (nil when playing alone, see coop.go)
+	splitScreen *SplitScreen
 }
 
 // NewGame creates a new game instance
 func NewGame(config Config) *Game {
+	// Seed first: everything below may already roll dice
+	runSeed := seedRun(config.RunSeed)
+
 	world := NewWorld(config)
 	collisionSystem := NewCollisionSystem(world)
-	camera := NewCamera(float64(config.ScreenWidth), float64(config.ScreenHeight))
-	renderer := NewRenderer(camera)
+	camera := NewCamera(viewportSize(config))
+
+	// Load embedded sprites (rendering falls back to vector shapes if this fails)
+	sprites, err := assets.Load()
+	if err != nil {
+		fmt.Printf("Failed to load assets: %v\n", err)
+	}
+
+	// Compile the effect shaders (rendering skips shader effects if this fails)
+	shaders, err := NewShaderManager()
+	if err != nil {
+		fmt.Printf("Failed to load shaders: %v\n", err)
+	}
+	renderer := NewRenderer(camera, sprites, shaders)
 
 	game := &Game{
-		world:                  world,
-		collisionSystem:        collisionSystem,
-		renderer:               renderer,
-		camera:                 camera,
-		config:                 config,
-		maxProjectiles:         1000,
-		projectiles:            make([]*Entity, 0, 1000),
-		enemySpawnRate:         0.5, // Spawn enemy every 0.5 seconds (legacy, kept for compatibility)
-		waveNumber:             1,
-		enemiesPerWave:         10, // Start with 10 enemies per wave
-		enemiesSpawnedThisWave: 0,
-		waveSpawnTimer:         0.1, // Spawn enemies quickly within a wave (0.1 seconds apart)
-		waveCooldown:           5.0, // 5 seconds between waves
-		score:                  0,
-		fps:                    60.0,
-		fpsUpdateCounter:       0,
-		fpsUpdateTimer:         0.0,
-		profiler:               NewProfiler(),
-		fpsDropCooldown:        10 * time.Second, // Don't trigger profiling more than once every 10 seconds
-		gameStartTime:          time.Now(),
-		lastUpdateTime:         time.Now(),
+		world:               world,
+		collisionSystem:     collisionSystem,
+		renderer:            renderer,
+		camera:              camera,
+		config:              config,
+		runSeed:             runSeed,
+		maxProjectiles:      1000,
+		projectiles:         make([]*Entity, 0, 1000),
+		enemySpawnRate:      0.5, // Spawn enemy every 0.5 seconds (legacy, kept for compatibility)
+		score:               0,
+		fps:                 60.0,
+		fpsUpdateCounter:    0,
+		fpsUpdateTimer:      0.0,
+		profiler:            NewProfiler(),
+		fpsDropCooldown:     10 * time.Second, // Don't trigger profiling more than once every 10 seconds
+		gameStartTime:       time.Now(),
+		lastUpdateTime:      time.Now(),
+		collisionThreats:    make([]*Entity, 0, MaxCollisionWarnings),
+		collisionPathBuffer: make([][2]float64, 0, CollisionWarningSamples),
+		photoMode:           NewPhotoMode(),
+		worldMap:            NewWorldMap(),
+		aiScheduler:         NewAIScheduler(config),
+		events:              NewEventBus(),
+		weaponStats:         NewWeaponStats(),
+		recap:               NewDeathRecap(),
+		runStats:            NewRunStats(),
+		statsPanel:          NewStatsPanel(),
+		heatmap:             NewHeatmap(),
+		xpMerger:            NewXPMerger(),
+		waveClear:           NewWaveClear(),
+		hitStop:             NewHitStop(config.HitStop),
+		screenShake:         NewScreenShake(),
+		killFeed:            NewKillFeed(),
+		radio:               NewRadio(),
+		sprites:             sprites,
+		shaders:             shaders,
+	}
+
+	game.frameTimer = NewFrameTimer(game.profiler.profilesDir)
+	game.gcGovernor = NewGCGovernor(config.GC)
+	game.frameLimiter = NewFrameLimiter(config.TickRate())
+	if config.Lives > 0 {
+		game.lives = NewLives(config.Lives)
 	}
+	game.pprof = NewPprofServer(config.PprofAddr)
+	if config.Pprof {
+		if err := game.pprof.Start(); err != nil {
+			fmt.Printf("Failed to start %v\n", err)
+		}
+	}
+
+	// Kill credit: XP for the player's kills and assists, and the kill feed
+	game.events.Subscribe(EventKill, game.onKillReward)
+	game.events.Subscribe(EventAssist, game.onAssistReward)
+	game.events.Subscribe(EventKill, game.onKillFeedKill)
+	game.events.Subscribe(EventAssist, game.onKillFeedAssist)
+
+	// Track the player's weapon statistics
+	game.events.Subscribe(EventShotFired, game.recordPlayerWeaponEvent)
+	game.events.Subscribe(EventDamage, game.recordPlayerWeaponEvent)
+	game.events.Subscribe(EventKill, game.recordPlayerWeaponEvent)
+
+	// Remember what hit and killed the player for the death recap
+	game.events.Subscribe(EventDamage, game.onRecapDamage)
+	game.events.Subscribe(EventKill, game.onRecapKill)
+
+	// Count the player's kills for the run graphs
+	game.events.Subscribe(EventKill, game.onRunStatsKill)
+
+	// Record where damage lands for the damage heatmap
+	game.events.Subscribe(EventDamage, game.onHeatmapDamage)
+
+	// Hit-stop when the player takes a heavy hit or kills an elite
+	game.events.Subscribe(EventDamage, game.onHitStopDamage)
+	game.events.Subscribe(EventKill, game.onHitStopKill)
+
+	// Chromatic aberration when the player is hit
+	game.events.Subscribe(EventDamage, game.onShaderDamage)
+
+	// Screen shake when the player is hit or dies, and when a boss goes down nearby
+	game.events.Subscribe(EventDamage, game.onScreenShakeDamage)
+	game.events.Subscribe(EventKill, game.onScreenShakeKill)
+
+	// Shield bubbles dent where they're hit
+	game.events.Subscribe(EventDamage, game.onShieldBubbleDamage)
+
+	// Shooting independents costs reputation with them, clearing enemies near them earns it
+	game.events.Subscribe(EventDamage, game.onReputationDamage)
+	game.events.Subscribe(EventKill, game.onReputationKill)
+
+	// Wingmen chatter on the radio
+	game.events.Subscribe(EventKill, game.onRadioKill)
 
 	// Set game reference in collision system for creating destroyed indicators
 	collisionSystem.SetGame(game)
 
-	// Create player
-	game.createPlayer()
+	if config.SplitScreen {
+		game.splitScreen = NewSplitScreen(config, sprites, shaders)
+	}
+
+	// Create player (arena mode is bots only)
+	if config.Mode != GameModeArena {
+		game.createPlayer()
+	}
+
+	// Scatter the asteroid field and spawn the initial wave of enemies
+	game.spawnAsteroidField()
+	game.startWave(1)
+	game.startMission()
 
-	// Spawn initial wave of enemies
-	game.enemiesPerWave = 10
-	game.enemiesSpawnedThisWave = 0
+	// Generate the sectors around the start on a worker
+	if config.Sectors.Enabled {
+		game.sectors = NewSectorGenerator(config)
+	}
 
 	return game
 }
 
 // createPlayer creates the player entity
 func (g *Game) createPlayer() {
+	g.spawnPlayerShip(g.config.WorldMinX+g.config.WorldWidth/2, g.config.WorldMinY+g.config.WorldHeight/2)
+	g.player.Sentries = SentryStartingCharges
+	if g.splitScreen != nil {
+		g.createCoopPlayer()
+	}
+}
+
+// spawnPlayerShip creates the keyboard player's ship at (x, y) and centers the camera on it
+func (g *Game) spawnPlayerShip(x, y float64) {
 	playerInput := NewPlayerInput()
-	g.player = NewEntityWithShipType(
-		g.config.WorldMinX+g.config.WorldWidth/2,
-		g.config.WorldMinY+g.config.WorldHeight/2,
-		EntityTypePlayer,
-		ShipTypePlayer,
-		playerInput,
-	)
+	g.player = NewEntityWithShipType(x, y, EntityTypePlayer, ShipTypePlayer, playerInput)
 	g.player.Faction = FactionPlayer // Set player faction
 	g.world.RegisterEntity(g.player)
 
@@ -123,15 +358,18 @@ func (g *Game) createPlayer() {
 }
 
 // respawnPlayer resets the entire game state by reconstructing it
-func (g *Game) respawnPlayer() {
+// The new run uses the given seed (0 = a fresh one); retrying with the old seed replays the same run
+func (g *Game) respawnPlayer(seed int64) {
+	g.runSeed = seedRun(seed)
+
 	// Reconstruct the entire game state - this throws away all old entities automatically
 	config := g.config
 
 	// Create new world (this discards all old entities)
 	world := NewWorld(config)
 	collisionSystem := NewCollisionSystem(world)
-	camera := NewCamera(float64(config.ScreenWidth), float64(config.ScreenHeight))
-	renderer := NewRenderer(camera)
+	camera := NewCamera(viewportSize(config))
+	renderer := NewRenderer(camera, g.sprites, g.shaders)
 
 	// Replace all game systems
 	g.world = world
@@ -146,24 +384,53 @@ func (g *Game) respawnPlayer() {
 	g.maxProjectiles = 1000
 	g.projectiles = make([]*Entity, 0, 1000)
 	g.enemySpawnRate = 0.5
-	g.waveNumber = 1
-	g.enemiesPerWave = 10
-	g.enemiesSpawnedThisWave = 0
-	g.waveSpawnTimer = 0.1
-	g.waveCooldown = 5.0
+	g.tickCount = 0
+	g.aiScheduler = NewAIScheduler(config)
 	g.score = 0
+	g.credits = 0
+	g.dockedStation = nil
 	g.fps = 60.0
 	g.fpsUpdateCounter = 0
 	g.fpsUpdateTimer = 0.0
 	g.lastUpdateTime = time.Now()
+	g.collisionThreats = g.collisionThreats[:0]
+	g.weaponStats.Reset()
+	g.recap.Reset()
+	g.runStats.Reset()
+	g.statsPanel.Reset()
+	g.heatmap.Reset()
+	g.killFeed.Reset()
+	g.radio.Reset()
+	g.waveClear.Reset()
+	g.screenShake.Reset()
+	g.setCheckpoint(nil)
+	if g.lives != nil {
+		g.lives.Reset()
+	}
+	g.groups = g.groups[:0]
+	g.drones = DroneBay{}
+	g.sentries = g.sentries[:0]
+	g.capitals = nil
+
+	// Create new player (arena mode is bots only)
+	if config.Mode != GameModeArena {
+		g.createPlayer()
+	}
 
-	// Create new player
-	g.createPlayer()
-
-	// Reset spawn timer and wave state
+	// Reset spawn timer, the asteroid field and the first wave, in the same order as NewGame so the
+	// same seed rolls the same run
 	g.enemySpawnTimer = 0
-	g.enemiesSpawnedThisWave = 0
-	g.waveSpawnTimer = 0
+	g.spawnAsteroidField()
+	g.startWave(1)
+	g.startMission()
+
+	// Sectors regenerate from the same seed: the new world is the same universe
+	if g.sectors != nil {
+		sectorConfig := config
+		sectorConfig.Sectors.Seed = g.sectors.Seed()
+		g.sectors.Close()
+		g.sectors = NewSectorGenerator(sectorConfig)
+	}
 }
 
 // isPlayerRegistered checks if the player is registered in the world
@@ -232,42 +499,49 @@ func canWeaponTargetEntity(weaponType WeaponType, target *Entity) bool {
 
 // updatePlayerTargeting finds the nearest enemy for each turret and updates turret rotation to face it
 // Each turret targets a different enemy to split fire
-func (g *Game) updatePlayerTargeting(playerInput *PlayerInput, deltaTime float64) {
-	if g.player == nil || !g.player.Active {
+func (g *Game) updatePlayerTargeting(ship *Entity, playerInput *PlayerInput, deltaTime float64) {
+	playerInput.hasTarget = false
+	if ship == nil {
+		return
+	}
+	if !ship.Active {
 		// Clear all turret targets
-		playerInput.TurretTargets = make(map[int]TurretTarget)
+		for i := range ship.Turrets {
+			ship.Turrets[i].Target = TurretTarget{}
+		}
 		return
 	}
 
-	shipConfig := GetShipTypeConfig(g.player.ShipType)
-	playerFaction := GetEntityFaction(g.player)
-
-	// Calculate ship rotation transforms once
-	cosRot := math.Cos(g.player.Rotation)
-	sinRot := math.Sin(g.player.Rotation)
+	playerFaction := GetEntityFaction(ship)
+	loadout := &GetSettings().Loadout
 
-	// Track which enemies are already targeted by other turrets
-	targetedEnemies := make(map[*Entity]bool)
+	// New stamp for this pass: enemies already targeted by other turrets carry it
+	g.targetingStamp++
+	stamp := g.targetingStamp
 
 	// Use spatial partitioning to find nearby enemies instead of iterating all entities
+	// The scan is shared with AI and rocket targeting queries over the same cells
 	maxTargetRange := playerInput.MaxTargetRange
-	candidates := g.world.GetEntitiesInRadius(g.player.X, g.player.Y, maxTargetRange*1.5) // Slightly larger radius to account for turret offsets
+	candidates := g.world.QueryTargetCandidates(ship.X, ship.Y,
+		maxTargetRange*1.5) // Slightly larger radius to account for turret offsets
 
 	// Process each turret separately
-	for turretIndex, mount := range shipConfig.TurretMounts {
-		if !mount.Active {
+	for i := range ship.Turrets {
+		turret := &ship.Turrets[i]
+		if !turret.IsOperational() {
+			turret.Target = TurretTarget{}
 			continue
 		}
 
 		// Calculate turret position in world coordinates
-		mountX := mount.OffsetX*cosRot - mount.OffsetY*sinRot
-		mountY := mount.OffsetX*sinRot + mount.OffsetY*cosRot
-		turretX := g.player.X + mountX
-		turretY := g.player.Y + mountY
+		turretX, turretY := turret.WorldPosition(ship)
 
-		// Find nearest enemy from this turret's position that isn't already targeted
+		// Find the best enemy from this turret's position that isn't already targeted
+		// "Best" follows the turret's targeting policy from the loadout
+		priority := loadout.TurretPriority(i)
 		var nearestEnemy *Entity
-		nearestDistanceSq := maxTargetRange * maxTargetRange // Use squared distance to avoid sqrt
+		nearestDistanceSq := 0.0
+		maxTargetRangeSq := maxTargetRange * maxTargetRange // Use squared distance to avoid sqrt
 
 		// Search through nearby entities instead of all entities
 		for _, entity := range candidates {
@@ -282,17 +556,22 @@ func (g *Game) updatePlayerTargeting(playerInput *PlayerInput, deltaTime float64
 
 			// Only target entities of opposite faction
 			entityFaction := GetEntityFaction(entity)
-			if entityFaction == playerFaction {
-				continue // Skip friendly entities
+			if entityFaction == playerFaction || g.world.Reputation.Peaceful(entityFaction) {
+				continue // Skip friendly entities, and independents the player is at peace with
 			}
 
 			// Skip enemies already targeted by other turrets
-			if targetedEnemies[entity] {
+			if entity.targetedStamp == stamp {
+				continue
+			}
+
+			// Skip cloaked ships the sensors haven't picked up
+			if entity.Cloak.Hidden() {
 				continue
 			}
 
 			// Check if this weapon can target this entity based on weapon config
-			if !canWeaponTargetEntity(mount.WeaponType, entity) {
+			if !canWeaponTargetEntity(turret.Mount.WeaponType, entity) {
 				continue
 			}
 
@@ -300,8 +579,21 @@ func (g *Game) updatePlayerTargeting(playerInput *PlayerInput, deltaTime float64
 			dx := entity.X - turretX
 			dy := entity.Y - turretY
 			distanceSq := dx*dx + dy*dy
+			if distanceSq >= maxTargetRangeSq {
+				continue
+			}
+
+			// Skip enemies outside the turret's firing arc
+			if !turret.InArc(ship, math.Atan2(dy, dx)) {
+				continue
+			}
+
+			// Prefer ships that can still shoot back over disarmed hulks
+			if entity.IsDisarmed() {
+				distanceSq *= disarmedTargetPenalty
+			}
 
-			if distanceSq < nearestDistanceSq {
+			if priority.better(entity, distanceSq, nearestEnemy, nearestDistanceSq) {
 				nearestDistanceSq = distanceSq
 				nearestEnemy = entity
 			}
@@ -310,177 +602,218 @@ func (g *Game) updatePlayerTargeting(playerInput *PlayerInput, deltaTime float64
 		// Update target and rotate turret
 		if nearestEnemy != nil {
 			// Mark this enemy as targeted
-			targetedEnemies[nearestEnemy] = true
+			nearestEnemy.targetedStamp = stamp
 
 			// Calculate predictive aim target from this turret's position
 			predictedX, predictedY := CalculatePredictiveAim(turretX, turretY, nearestEnemy)
 
 			// Store predicted target position for this turret
-			playerInput.TurretTargets[turretIndex] = TurretTarget{
+			turret.Target = TurretTarget{
 				TargetX:   predictedX,
 				TargetY:   predictedY,
 				HasTarget: true,
 			}
+			playerInput.hasTarget = true
 
 			// Calculate angle from turret to predicted target
 			turretDx := predictedX - turretX
 			turretDy := predictedY - turretY
 			turretTargetRotation := math.Atan2(turretDy, turretDx)
 
-			// Get current rotation for this turret (or initialize to ship rotation + mount angle)
-			currentRotation := playerInput.GetTurretRotation(turretIndex)
-			if currentRotation == 0.0 {
-				currentRotation = g.player.Rotation + mount.Angle
-			}
-
-			// Smoothly rotate turret towards target
-			maxTurretAngularVelocity := 8.0 // radians per second (faster than ship)
-			newRotation := RotateTowardsTarget(
-				currentRotation,
-				turretTargetRotation,
-				maxTurretAngularVelocity,
-				deltaTime,
-			)
-			playerInput.TurretRotations[turretIndex] = newRotation
+			// Smoothly rotate turret towards target, within its firing arc
+			turret.TurnTowards(ship, turretTargetRotation, PlayerTurretTurnRate, deltaTime)
 		} else {
 			// No target for this turret
-			playerInput.TurretTargets[turretIndex] = TurretTarget{HasTarget: false}
+			turret.Target = TurretTarget{HasTarget: false}
 		}
 	}
 }
 
-// spawnEnemy spawns a new enemy at a random position near the player
+// SetWaveSet replaces the random endless waves with designed ones (nil restores random waves)
+// Takes effect from wave 1
+func (g *Game) SetWaveSet(waveSet *WaveSet) {
+	g.waveSet = waveSet
+	g.startWave(1)
+}
+
+// startWave resets spawning for the given 1-based wave number
+func (g *Game) startWave(waveNumber int) {
+	g.waveNumber = waveNumber
+	g.enemiesSpawnedThisWave = 0
+	g.waveSpawnTimer = 0
+	g.waveClear.Start()
+	g.saveCheckpoint()
+
+	if g.waveSet == nil {
+		// Endless random waves: spend a budget that grows every wave
+		g.waveQueue = g.config.WaveBudget.GenerateWave(g.waveQueue[:0], waveNumber)
+		g.enemiesPerWave = len(g.waveQueue)
+		g.waveSpawnInterval = DefaultWaveSpawnInterval
+		g.waveCooldown = DefaultWaveCooldown
+		return
+	}
+
+	wave := g.waveSet.Wave(waveNumber)
+	g.waveQueue = wave.SpawnOrder(g.waveQueue[:0])
+	g.enemiesPerWave = len(g.waveQueue)
+	g.waveSpawnInterval, g.waveCooldown = wave.Pacing()
+}
+
+// spawnNextWaveEnemy spawns the next enemy of the current wave
+func (g *Game) spawnNextWaveEnemy() {
+	registered := len(g.world.AllEntities)
+	if g.enemiesSpawnedThisWave < len(g.waveQueue) {
+		g.spawnEnemyOfType(g.waveQueue[g.enemiesSpawnedThisWave])
+	} else {
+		g.spawnEnemy()
+	}
+	g.waveClear.track(g.world.AllEntities[registered:]) // The enemy, or a whole swarm
+	g.enemiesSpawnedThisWave++
+}
+
+// spawnEnemy spawns a new enemy of a random type at a random position near the player
 func (g *Game) spawnEnemy() {
+	g.spawnEnemyOfType(GetRandomEnemyType())
+}
+
+// spawnEnemyOfType spawns a new enemy at a random position near the player (or the mission objective)
+func (g *Game) spawnEnemyOfType(enemyType EnemyType) {
 	var x, y float64
 
-	if g.player != nil && g.player.Active {
-		// Spawn enemies around the player at a distance
-		spawnDistance := 400.0 + rand.Float64()*200.0 // 400-600 pixels away
-		angle := rand.Float64() * 2 * math.Pi
-		x = g.player.X + math.Cos(angle)*spawnDistance
-		y = g.player.Y + math.Sin(angle)*spawnDistance
+	if anchor := g.spawnAnchor(); anchor != nil {
+		// Spawn enemies around the player or the objective at a distance
+		spawnDistance := 400.0 + runRNG.Float64()*200.0 // 400-600 pixels away
+		angle := runRNG.Float64() * 2 * math.Pi
+		x = anchor.X + math.Cos(angle)*spawnDistance
+		y = anchor.Y + math.Sin(angle)*spawnDistance
 
 		// Clamp to world bounds
 		x = math.Max(g.config.WorldMinX, math.Min(x, g.config.WorldMinX+g.config.WorldWidth))
 		y = math.Max(g.config.WorldMinY, math.Min(y, g.config.WorldMinY+g.config.WorldHeight))
+	} else if g.config.Mode == GameModeArena {
+		// Arena: spawn on the arena rim so enemies converge on the bots
+		centerX, centerY := g.arenaCenter()
+		angle := runRNG.Float64() * 2 * math.Pi
+		x = centerX + math.Cos(angle)*ArenaRadius
+		y = centerY + math.Sin(angle)*ArenaRadius
 	} else {
 		// Fallback: spawn at edge of world
-		side := rand.Intn(4)
+		side := runRNG.Intn(4)
 		switch side {
 		case 0: // Top
-			x = g.config.WorldMinX + rand.Float64()*g.config.WorldWidth
+			x = g.config.WorldMinX + runRNG.Float64()*g.config.WorldWidth
 			y = g.config.WorldMinY
 		case 1: // Right
 			x = g.config.WorldMinX + g.config.WorldWidth
-			y = g.config.WorldMinY + rand.Float64()*g.config.WorldHeight
+			y = g.config.WorldMinY + runRNG.Float64()*g.config.WorldHeight
 		case 2: // Bottom
-			x = g.config.WorldMinX + rand.Float64()*g.config.WorldWidth
+			x = g.config.WorldMinX + runRNG.Float64()*g.config.WorldWidth
 			y = g.config.WorldMinY + g.config.WorldHeight
 		case 3: // Left
 			x = g.config.WorldMinX
-			y = g.config.WorldMinY + rand.Float64()*g.config.WorldHeight
+			y = g.config.WorldMinY + runRNG.Float64()*g.config.WorldHeight
 		}
 	}
 
-	// Choose random enemy type
-	enemyType := GetRandomEnemyType()
+	// Swarms spawn all their members at once under one group controller
+	if GetEnemyTypeConfig(enemyType).GroupSize > 0 {
+		g.spawnSwarm(x, y, enemyType)
+		return
+	}
+
+	// Capital ships spawn with their hull sections welded on
+	if enemyType == EnemyTypeCapital {
+		g.spawnCapitalShip(x, y)
+		g.radioCommand(RadioCueCapitalArrives)
+		return
+	}
 
 	aiInput := CreateEnemyAIWithType(enemyType)
 	enemy := NewEntityWithShipType(x, y, EntityTypeEnemy, GetEnemyTypeConfig(enemyType).ShipType, aiInput)
 	enemy.Faction = FactionEnemy // Explicitly set faction to enemy (regardless of ship type)
+
+	// Later waves upgrade more enemies to elites
+	if modifier := RollEliteModifier(g.waveNumber); modifier != EliteNone {
+		ApplyEliteModifier(enemy, modifier)
+	}
 	g.world.RegisterEntity(enemy)
+	if aiInput.miniBoss {
+		g.radioFrom(RadioCueBossArrives, enemy)
+	}
 }
 
 // spawnProjectile spawns a projectile from an entity using weapon types
 // Fires from all active turrets
 func (g *Game) spawnProjectile(entity *Entity) {
-	shipConfig := GetShipTypeConfig(entity.ShipType)
+	// Don't shoot if there are no turrets
+	if len(entity.Turrets) == 0 {
+		return
+	}
 
-	// Don't shoot if there are no turret mounts
-	if len(shipConfig.TurretMounts) == 0 {
+	// Cloaked ships must decloak (and wait for their weapons to come online) before firing
+	if !entity.Cloak.WeaponsOnline() {
 		return
 	}
 
-	// Calculate ship rotation transforms once
-	cosRot := math.Cos(entity.Rotation)
-	sinRot := math.Sin(entity.Rotation)
+	// EMP-disabled ships can't fire
+	if entity.Status.IsDisabled() {
+		return
+	}
 
-	// Fire from all active turrets (checking weapon cooldowns)
-	for i := range shipConfig.TurretMounts {
-		mount := &shipConfig.TurretMounts[i]
-		if !mount.Active {
+	// Fire from all operational turrets (checking weapon cooldowns)
+	for i := range entity.Turrets {
+		turret := &entity.Turrets[i]
+		if !turret.IsOperational() {
 			continue
 		}
+		mount := &turret.Mount
 
-		// Check weapon cooldown (per turret for player, per weapon type for AI)
-		weaponConfig := GetWeaponConfig(mount.WeaponType)
-		var timeSinceLastShot float64
-		var hasBeenFired bool
-
-		if playerInput, ok := entity.Input.(*PlayerInput); ok {
-			// Track cooldowns per turret index for independent firing
-			if playerInput.TurretCooldowns != nil {
-				timeSinceLastShot, hasBeenFired = playerInput.TurretCooldowns[i]
-			}
-		} else if aiInput, ok := entity.Input.(*AIInput); ok {
-			// AI still uses per-weapon-type cooldowns
-			if aiInput.WeaponCooldowns != nil {
-				timeSinceLastShot, hasBeenFired = aiInput.WeaponCooldowns[mount.WeaponType]
-			}
+		// Tractor beams fire continuously on demand instead (see updateTractors)
+		if GetWeaponConfig(mount.WeaponType).Tractor != nil {
+			continue
 		}
 
-		if !weaponConfig.CanShoot(timeSinceLastShot, hasBeenFired) {
+		// Check weapon cooldown (per turret, staggered between turrets on some ships)
+		if !entity.turretReady(i) {
 			continue // Skip this turret if weapon is on cooldown
 		}
-
-		// Transform mount offset from ship-local to world coordinates
-		mountX := mount.OffsetX*cosRot - mount.OffsetY*sinRot
-		mountY := mount.OffsetX*sinRot + mount.OffsetY*cosRot
+		_, isAI := entity.Input.(*AIInput)
 
 		// Calculate turret mount position in world coordinates
-		turretX := entity.X + mountX
-		turretY := entity.Y + mountY
+		turretX, turretY := turret.WorldPosition(entity)
 
-		// Use turret rotation for shooting direction (or ship rotation + mount angle for AI)
-		var shootRotation float64
-		if playerInput, ok := entity.Input.(*PlayerInput); ok {
-			// Use per-turret rotation, fallback to ship rotation + mount angle if not set
-			shootRotation = playerInput.GetTurretRotation(i)
-			if shootRotation == 0.0 {
-				shootRotation = entity.Rotation + mount.Angle
-			}
-			// Reset turret cooldown after firing (per turret index)
-			playerInput.ResetTurretCooldown(i)
-		} else if aiInput, ok := entity.Input.(*AIInput); ok {
-			shootRotation = entity.Rotation + mount.Angle
-			// Reset weapon cooldown after firing (per weapon type for AI)
-			aiInput.ResetWeaponCooldown(mount.WeaponType)
-		} else {
-			shootRotation = entity.Rotation + mount.Angle
+		// AI turrets only fire at a target they have a clear shot at (see updateAITurrets)
+		if isAI && !turret.Target.HasTarget {
+			continue
 		}
 
+		// Use turret rotation for shooting direction (ship rotation + mount angle until aimed)
+		shootRotation := turret.BarrelRotation(entity)
+
+		// Reset cooldown and consume ammo after firing
+		turret.RecordShot()
+		entity.Cloak.SinceShot = 0
+
 		// Spawn position is at the end of the barrel (turret position + barrel length in turret direction)
 		spawnX := turretX + math.Cos(shootRotation)*mount.BarrelLength
 		spawnY := turretY + math.Sin(shootRotation)*mount.BarrelLength
 
 		// Spawn weapon projectile based on turret's weapon type
 		g.spawnWeaponProjectile(mount.WeaponType, spawnX, spawnY, shootRotation, entity)
+		g.events.Publish(Event{Type: EventShotFired, Source: entity, SourceID: entity.ID, SourceFaction: entity.Faction, Weapon: mount.WeaponType})
 	}
 }
 
-// spawnWeaponProjectile spawns a projectile based on weapon type
+// spawnWeaponProjectile spawns a projectile using the weapon's registered spawn behavior
 func (g *Game) spawnWeaponProjectile(weaponType WeaponType, spawnX, spawnY, rotation float64, owner *Entity) {
 	weaponConfig := GetWeaponConfig(weaponType)
 
-	switch weaponType {
-	case WeaponTypeBullet:
+	if weaponConfig.Spawn == nil {
+		// Weapons without spawn behavior fire plain bullets with their own stats
 		g.spawnBullet(spawnX, spawnY, rotation, owner, weaponConfig)
-	case WeaponTypeHomingMissile:
-		g.spawnHomingMissile(spawnX, spawnY, rotation, owner, weaponConfig)
-	default:
-		// Fallback to bullet
-		g.spawnBullet(spawnX, spawnY, rotation, owner, GetWeaponConfig(WeaponTypeBullet))
+		return
 	}
+	weaponConfig.Spawn(g, spawnX, spawnY, rotation, owner, weaponConfig)
 }
 
 // spawnBullet spawns a bullet projectile
@@ -500,14 +833,19 @@ func (g *Game) spawnBullet(spawnX, spawnY, rotation float64, owner *Entity, weap
 		projectile.Radius = weaponConfig.Radius
 		projectile.Input = nil                       // Projectiles don't need input
 		projectile.Age = 0.0                         // Reset age
-		projectile.Owner = owner                     // Track who fired this projectile
+		projectile.OwnerID = owner.ID                // Track who fired this projectile
+		projectile.Weapon = weaponConfig.Type        // Weapon behavior delegates look this up
 		projectile.Faction = GetEntityFaction(owner) // Inherit faction from owner
+		projectile.OwnerFaction = projectile.Faction
 
 		// Set velocity based on shoot rotation, inheriting ship's velocity
 		projectile.VX = math.Cos(rotation)*weaponConfig.ProjectileSpeed + owner.VX
 		projectile.VY = math.Sin(rotation)*weaponConfig.ProjectileSpeed + owner.VY
 		projectile.Rotation = rotation // Set projectile rotation to match direction
 
+		// Despawn once the bullet has flown its max range (or lived its lifetime)
+		projectile.Lifetime = weaponConfig.ProjectileLifetime(math.Hypot(projectile.VX, projectile.VY))
+
 		g.world.RegisterEntity(projectile)
 		g.projectiles = append(g.projectiles, projectile)
 	} else {
@@ -516,14 +854,19 @@ func (g *Game) spawnBullet(spawnX, spawnY, rotation float64, owner *Entity, weap
 		projectile.Health = weaponConfig.Damage
 		projectile.MaxHealth = weaponConfig.Damage
 		projectile.Age = 0.0                         // Initialize age
-		projectile.Owner = owner                     // Track who fired this projectile
+		projectile.OwnerID = owner.ID                // Track who fired this projectile
+		projectile.Weapon = weaponConfig.Type        // Weapon behavior delegates look this up
 		projectile.Faction = GetEntityFaction(owner) // Inherit faction from owner
+		projectile.OwnerFaction = projectile.Faction
 
 		// Set velocity based on shoot rotation, inheriting ship's velocity
 		projectile.VX = math.Cos(rotation)*weaponConfig.ProjectileSpeed + owner.VX
 		projectile.VY = math.Sin(rotation)*weaponConfig.ProjectileSpeed + owner.VY
 		projectile.Rotation = rotation // Set projectile rotation to match direction
 
+		// Despawn once the bullet has flown its max range (or lived its lifetime)
+		projectile.Lifetime = weaponConfig.ProjectileLifetime(math.Hypot(projectile.VX, projectile.VY))
+
 		g.world.RegisterEntity(projectile)
 		g.projectiles = append(g.projectiles, projectile)
 	}
@@ -541,9 +884,12 @@ func (g *Game) spawnHomingMissile(spawnX, spawnY, rotation float64, owner *Entit
 	// Spawn homing rocket with same faction as owner
 	homingAI := CreateEnemyAIWithType(EnemyTypeRocket)
 	homingRocket := NewHomingRocket(spawnX, spawnY, homingAI)
-	homingRocket.Faction = ownerFaction           // Inherit faction from owner
+	homingRocket.Faction = ownerFaction // Inherit faction from owner
+	homingRocket.OwnerID = owner.ID     // Credit hits and kills to the shooter
+	homingRocket.OwnerFaction = ownerFaction
 	homingRocket.NoCollision = true               // Homing rockets don't collide with other entities (except targets)
 	homingRocket.Lifetime = weaponConfig.Lifetime // Set lifetime for auto-detonation
+	homingRocket.Weapon = weaponConfig.Type
 
 	// Give the homing rocket initial velocity in the shooting direction
 	homingRocket.VX = math.Cos(rotation) * weaponConfig.InitialVelocity
@@ -569,9 +915,9 @@ func (g *Game) createDestroyedIndicator(x, y float64, faction Faction) {
 
 // createDestroyedIndicatorYellow creates a visual indicator in yellow color
 // for enemies destroyed by player projectiles
-// Uses Owner == nil and a special Radius value as a marker for yellow color
 func (g *Game) createDestroyedIndicatorYellow(x, y float64) {
-	indicator := NewEntity(x, y, -8.0, EntityTypeDestroyedIndicator, nil) // Negative radius marks as yellow
+	indicator := NewEntity(x, y, 8.0, EntityTypeDestroyedIndicator, nil)
+	indicator.Indicator.Kind = IndicatorKindKill // Rendered yellow
 	indicator.Faction = FactionPlayer
 	indicator.Active = true
 	indicator.Health = 1.0 // Small health value so it renders
@@ -589,21 +935,37 @@ func (g *Game) spawnXPFromEnemy(enemy *Entity, target *Entity) {
 		return
 	}
 
+	// Only the player can collect XP (arena bots would just drag orbs around)
+	if target != g.player {
+		return
+	}
+
 	// Get score value from the enemy
-	shipConfig := GetShipTypeConfig(enemy.ShipType)
-	scoreValue := float64(shipConfig.Score)
+	scoreValue := enemyXPValue(enemy)
 
 	// Don't spawn XP if score value is zero
 	if scoreValue <= 0 {
 		return
 	}
 
-	xp := NewEntity(enemy.X, enemy.Y, 2.0, EntityTypeXP, nil) // Smaller radius: 2.0 instead of 4.0
-	xp.Owner = target                                         // Store target in Owner field
+	g.spawnPickup(enemy.X, enemy.Y, scoreValue, target, ScoreKill)
+}
+
+// enemyXPValue returns the XP a killed enemy is worth
+func enemyXPValue(enemy *Entity) int {
+	return GetShipTypeConfig(enemy.ShipType).Score * GetEliteModifierConfig(enemy.Elite).XPMultiplier
+}
+
+// spawnPickup creates an XP pickup worth scoreValue that homes toward target
+func (g *Game) spawnPickup(x, y float64, scoreValue int, target *Entity, source ScoreSource) {
+	xp := NewEntity(x, y, XPOrbRadius, EntityTypeXP, nil) // Smaller radius: 2.0 instead of 4.0
+	xp.Pickup.Target = target
+	xp.Pickup.Value = scoreValue
+	xp.Pickup.Source = source
 	xp.Active = true
 	xp.Health = 1.0
-	xp.MaxHealth = scoreValue // Store score value in MaxHealth
-	xp.NoCollision = true     // XP doesn't collide with anything
+	xp.MaxHealth = 1.0
+	xp.NoCollision = true // XP doesn't collide with anything
 	xp.VX = 0
 	xp.VY = 0
 	g.world.RegisterEntity(xp)
@@ -611,22 +973,179 @@ func (g *Game) spawnXPFromEnemy(enemy *Entity, target *Entity) {
 
 // Update updates the game state
 func (g *Game) Update() error {
+	// Remember the window in the settings when it's closed (main sets ebiten.SetWindowClosingHandled)
+	if ebiten.IsWindowBeingClosed() {
+		return closeWindow()
+	}
+
+	g.frameTimer.BeginFrame()
+	g.frameLimiter.Update(GetSettings().Display.BatterySaver)
+
 	// Calculate delta time
 	now := time.Now()
 	deltaTime := now.Sub(g.lastUpdateTime).Seconds()
 	g.lastUpdateTime = now
 
+	// Tune the GC from the frames drawn so far
+	g.gcGovernor.ObserveFrame(g.frameTimer.LastFrame)
+	g.gcGovernor.Update(now)
+
 	// Clamp delta time to prevent large jumps
 	if deltaTime > 0.1 {
 		deltaTime = 0.1
 	}
 
+	// Upload any sprites hot-reloaded from disk
+	g.sprites.Update()
+
+	// P toggles photo mode, which pauses the simulation while active
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.photoMode.Toggle(g.camera)
+	}
+	if g.photoMode.Active {
+		g.photoMode.Update(g.camera, deltaTime)
+		return nil
+	}
+
+	// M opens the world map, which also pauses the simulation while open
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		g.worldMap.Toggle(g.player)
+	}
+	if g.worldMap.Open {
+		g.updateWorldMapInput(deltaTime)
+		return nil
+	}
+
+	// F6 opens the mod menu; number keys toggle mods while it is open
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		g.modMenuOpen = !g.modMenuOpen
+		g.loadoutMenuOpen = false
+		g.dockedStation = nil
+	}
+	if g.modMenuOpen {
+		g.updateModMenu()
+	}
+
+	// F7 opens the loadout menu; number keys cycle each turret's targeting policy while it is open
+	if inpututil.IsKeyJustPressed(ebiten.KeyF7) {
+		g.loadoutMenuOpen = !g.loadoutMenuOpen
+		g.modMenuOpen = false
+		g.dockedStation = nil
+	}
+	if g.loadoutMenuOpen {
+		g.updateLoadoutMenu()
+	}
+
+	// E docks at a nearby station (and undocks); number keys buy from its shop while docked
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		g.toggleDock()
+	}
+	if g.dockedStation != nil {
+		g.updateShop()
+	}
+
+	// N engages the autopilot toward the world map waypoint (and disengages it)
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		g.toggleAutopilot()
+	}
+
+	// Z, X and C ping the spot under the cursor for the team (attack, help, loot)
+	g.updatePings(deltaTime)
+
+	// B deploys a sentry turret where the player is
+	g.deploySentry()
+
+	// F4 cycles the global game speed (accessibility) and persists it
+	settings := GetSettings()
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		settings.CycleGameSpeed()
+		if err := SaveSettings(); err != nil {
+			fmt.Printf("Failed to save settings: %v\n", err)
+		}
+	}
+
+	// F8 cycles the HUD compass style and persists it
+	if inpututil.IsKeyJustPressed(ebiten.KeyF8) {
+		settings.Compass = settings.Compass.Next()
+		if err := SaveSettings(); err != nil {
+			fmt.Printf("Failed to save settings: %v\n", err)
+		}
+	}
+
+	// F9 cycles the camera follow mode and persists it
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		settings.Camera.Mode = settings.Camera.Mode.Next()
+		if err := SaveSettings(); err != nil {
+			fmt.Printf("Failed to save settings: %v\n", err)
+		}
+	}
+
+	// V toggles screen shake (accessibility) and persists it
+	if inpututil.IsKeyJustPressed(ebiten.KeyV) {
+		settings.ScreenShake = !settings.ScreenShake
+		if err := SaveSettings(); err != nil {
+			fmt.Printf("Failed to save settings: %v\n", err)
+		}
+	}
+
+	// U cycles the particle density (accessibility) and persists it
+	if inpututil.IsKeyJustPressed(ebiten.KeyU) {
+		settings.CycleParticleDensity()
+		if err := SaveSettings(); err != nil {
+			fmt.Printf("Failed to save settings: %v\n", err)
+		}
+	}
+
+	// I toggles static destroyed indicators (accessibility) and persists it
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		settings.StaticIndicators = !settings.StaticIndicators
+		if err := SaveSettings(); err != nil {
+			fmt.Printf("Failed to save settings: %v\n", err)
+		}
+	}
+
+	// Slow the whole simulation uniformly when a reduced game speed is set, and briefly during a hit-stop
+	return g.Step(deltaTime * settings.GameSpeed * g.hitStop.TimeScale())
+}
+
+// Step advances the simulation by deltaTime seconds
+// Update calls this with wall-clock time; headless modes call it with a fixed timestep
+func (g *Game) Step(deltaTime float64) error {
 	// Handle debug key presses (F1 toggles grid display)
 	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
 		debugState := GetDebugState()
 		debugState.ShowGrid = !debugState.ShowGrid
 	}
 
+	// F2 toggles enemy and rocket trails
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		debugState := GetDebugState()
+		debugState.ShowTrails = !debugState.ShowTrails
+	}
+
+	// F3 toggles the player's predicted path preview
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		debugState := GetDebugState()
+		debugState.ShowPlayerPath = !debugState.ShowPlayerPath
+	}
+
+	// F5 toggles the entity statistics panel
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		debugState := GetDebugState()
+		debugState.ShowStats = !debugState.ShowStats
+	}
+
+	// F10 cycles the heatmap overlay: off, entity density, recent damage
+	if inpututil.IsKeyJustPressed(ebiten.KeyF10) {
+		debugState := GetDebugState()
+		debugState.Heatmap = debugState.Heatmap.Next()
+	}
+
+	// F11 starts or stops the pprof server
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		g.pprof.Toggle()
+	}
+
 	// Update FPS calculation (update every 0.5 seconds)
 	g.fpsUpdateTimer += deltaTime
 	g.fpsUpdateCounter++
@@ -675,16 +1194,74 @@ func (g *Game) Update() error {
 
 		// Check for respawn
 		if playerInput, ok := g.player.Input.(*PlayerInput); ok {
-			if playerInput.ShouldRespawn() {
-				g.respawnPlayer()
+			inPlace := g.config.Respawn.Mode == RespawnInPlace && g.lives == nil // Lives respawn the player themselves
+			switch {
+			case playerInput.ShouldContinue() && g.lives != nil && g.lives.OutOfLives:
+				g.continueRun()
+				playerInput = g.player.Input.(*PlayerInput)
+			case playerInput.ShouldLoadCheckpoint() && g.checkpoint != nil && (!g.player.Active || g.player.Health <= 0):
+				g.loadCheckpoint()
+				playerInput = g.player.Input.(*PlayerInput)
+			case playerInput.ShouldRetry():
+				g.respawnPlayer(g.runSeed)
+			case playerInput.ShouldRespawn() && inPlace && (!g.player.Active || g.player.Health <= 0):
+				g.respawnInPlace(g.config.Respawn.ScorePenalty)
+				playerInput = g.player.Input.(*PlayerInput)
+			case playerInput.ShouldRespawn() && !inPlace:
+				g.respawnPlayer(0)
 			}
 
 			// Update player target acquisition AI
-			g.updatePlayerTargeting(playerInput, deltaTime)
+			g.updatePlayerTargeting(g.player, playerInput, deltaTime)
 		}
 	}
+	if g.splitScreen != nil {
+		g.updateCoopPlayer(deltaTime)
+	}
+
+	// Death cam and respawn while lives last
+	g.updateLives(deltaTime)
+
+	// Fly toward the waypoint while the autopilot is engaged
+	g.updateAutopilot()
+
+	g.frameTimer.Mark("input")
+
+	// Populate sectors the player is approaching
+	g.updateSectors()
+
+	// Record what's around the player for the world map
+	g.updateWorldMap(deltaTime)
+
+	// Age and expire destroyed indicators and drift debris (they live outside the entity loop)
+	g.world.UpdateFX(deltaTime)
+	g.world.UpdateDebris(deltaTime)
+	g.killFeed.Update(deltaTime)
+	g.radio.Update(deltaTime)
+	g.shaders.Update(deltaTime)
+	g.screenShake.Update(deltaTime)
+	g.recap.Update(deltaTime, g.player)
+	g.runStats.Update(deltaTime, g.player, g.score, len(g.world.AllEntities), g.fps)
+	g.statsPanel.Update(deltaTime, g.world, len(g.projectiles), g.maxProjectiles)
+	g.heatmap.Update(deltaTime)
+	g.waveClear.Update(deltaTime, g.player)
+
+	g.frameTimer.Mark("sectors, map and effects")
 
-	// Update all entities
+	// Start a fresh AI think budget
+	g.aiScheduler.BeginTick()
+
+	// Group brains pick targets and sub-goals before their members steer
+	g.updateGroups(deltaTime)
+
+	// Steer the convoy and check whether the objective is done for
+	g.updateMission()
+
+	// Launch, steer and recall the player's drones; aim the deployed sentries
+	g.updateDrones(deltaTime)
+	g.updateSentries(deltaTime)
+
+	// Update input/AI and move all entities
 	for _, entity := range g.world.AllEntities {
 		if !entity.Active {
 			continue
@@ -697,24 +1274,85 @@ func (g *Game) Update() error {
 			// Update AI if it's an enemy or homing rocket
 			if entity.Type == EntityTypeEnemy || entity.Type == EntityTypeHomingRocket {
 				if aiInput, ok := entity.Input.(*AIInput); ok {
-					UpdateAI(aiInput, entity, g.player, g.world, deltaTime)
+					if aiInput.miniBoss {
+						g.updateMiniBoss(entity, aiInput, deltaTime)
+					}
+					think := g.aiScheduler.ShouldThink(aiInput, entity)
+					UpdateAI(aiInput, entity, g.player, g.world, deltaTime, think)
 				}
 			}
 		}
 
 		// Update entity
 		entity.Update(deltaTime)
+	}
+
+	g.frameTimer.Mark("ai and physics")
+
+	// Move attached parts with their parents before anything collides, then aim the capital ships' sections
+	g.world.UpdateAttachments()
+	g.updateCapitalShips(deltaTime)
 
-		// Check lifetime for homing missiles (auto-detonate after lifetime expires)
+	g.frameTimer.Mark("attachments")
+
+	// Update everything that depends on the new positions
+	for _, entity := range g.world.AllEntities {
+		if !entity.Active {
+			continue
+		}
+
+		// Record breadcrumbs for trail rendering
+		if shouldTrackTrail(entity) {
+			updateTrail(entity, deltaTime)
+		}
+
+		// Advance sprite animations for ships
+		if entity.Type == EntityTypePlayer || entity.Type == EntityTypeEnemy {
+			entity.Animation.Update(entity, deltaTime)
+		}
+
+		// Elite effects (regeneration)
+		if entity.Elite != EliteNone {
+			updateElite(entity, deltaTime)
+		}
+
+		// Cloak timers and fading in and out of view
+		if entity.Cloak.Capable {
+			g.updateCloak(entity, deltaTime)
+		}
+
+		// Burning ships take damage over time; then count down status effects
+		if damage := entity.Status.Burn(deltaTime); damage > 0 {
+			record := entity.Status.BurnSource
+			record.Amount = damage
+			g.collisionSystem.applyDamage(entity, record, true, entity.X, entity.Y)
+		}
+		entity.Status.Update(deltaTime)
+
+		// Run custom weapon behavior for projectiles and rockets
+		if entity.Type == EntityTypeProjectile || entity.Type == EntityTypeHomingRocket {
+			if update := GetWeaponConfig(entity.Weapon).Update; update != nil {
+				update(g, entity, deltaTime)
+			}
+		}
+
+		// Check lifetime for homing missiles and bullets (missiles auto-detonate, bullets past their range despawn)
 		if entity.Lifetime > 0 && entity.Age >= entity.Lifetime {
 			// Lifetime expired - detonate the missile
 			if entity.Type == EntityTypeHomingRocket {
 				// Create destroyed indicator at missile position
 				g.createDestroyedIndicator(entity.X, entity.Y, entity.Faction)
 				entity.Health = 0 // Mark for removal (don't set Active=false, let update loop handle cleanup)
+			} else if entity.Type == EntityTypeProjectile {
+				entity.Health = 0 // Out of range, just disappear
 			}
 		}
 
+		// Tow beams (player only: AI ships don't carry tractors)
+		if playerInput, ok := entity.Input.(*PlayerInput); ok {
+			g.updateTractors(entity, playerInput, deltaTime)
+		}
+
 		// Handle shooting
 		if entity.Input != nil && entity.Input.ShouldShoot() {
 			if entity.Type == EntityTypePlayer || entity.Type == EntityTypeEnemy {
@@ -729,21 +1367,48 @@ func (g *Game) Update() error {
 		// Update entity cell membership
 		g.collisionSystem.MoveEntity(entity)
 
-		// Remove dead entities, expired destroyed indicators, and collected XP
+		// Remove dead entities and collected XP
 		// Also remove XP if its target is inactive (player died/respawned)
 		shouldRemove := false
 		if entity.Health <= 0 {
 			shouldRemove = true
-		} else if entity.Type == EntityTypeDestroyedIndicator && entity.Lifetime > 0 && entity.Age >= entity.Lifetime {
-			shouldRemove = true
 		} else if entity.Type == EntityTypeXP {
 			// Remove XP if target is inactive or doesn't exist
-			if entity.Owner == nil || !entity.Owner.Active {
+			if entity.Pickup.Target == nil || !entity.Pickup.Target.Active {
 				shouldRemove = true
 			}
 		}
 
 		if shouldRemove {
+			// Ships killed by ramming or the zone go to whoever hit them last
+			if entity.Health <= 0 {
+				g.creditUnattributedDeath(entity)
+			}
+
+			// Splitting elites break apart into regular enemies, asteroids into smaller fragments,
+			// derelict stations spill their loot
+			if entity.Health <= 0 && GetEliteModifierConfig(entity.Elite).SplitCount > 0 {
+				g.splitElite(entity)
+			}
+			if entity.Health <= 0 && entity.Type == EntityTypeAsteroid {
+				g.breakAsteroid(entity)
+			}
+			if entity.Health <= 0 && entity.Type == EntityTypeStation {
+				g.lootStation(entity)
+			}
+
+			// Destroyed ships leave drifting wreckage behind
+			if entity.Health <= 0 && (entity.Type == EntityTypePlayer || entity.Type == EntityTypeEnemy) {
+				g.spawnDebris(entity)
+			}
+
+			// Missiles with a blast (EMP) go off however they were destroyed
+			if entity.Health <= 0 && entity.Type == EntityTypeHomingRocket {
+				if detonate := GetWeaponConfig(entity.Weapon).Detonate; detonate != nil {
+					detonate(g, entity)
+				}
+			}
+
 			// Don't award score immediately - XP will handle that when collected
 			entity.Active = false
 			if entity.Type == EntityTypeProjectile {
@@ -761,22 +1426,38 @@ func (g *Game) Update() error {
 		// Projectiles can exist outside world bounds - no removal check needed
 	}
 
+	g.frameTimer.Mark("entity updates")
+
 	// Check collisions
 	g.collisionSystem.CheckCollisions()
+	g.frameTimer.Mark("collisions")
+
+	// Shrink the safe zone and damage ships caught outside it
+	if g.world.SafeZone != nil {
+		g.world.SafeZone.Update(deltaTime)
+		g.applySafeZoneDamage(deltaTime)
+	}
+
+	// Flag threats on a collision course with the player
+	g.updateCollisionWarnings()
+
+	// Merge orbs where large waves left too many
+	g.xpMerger.Update(deltaTime, g.world)
 
 	// Check XP pickup range for all XP entities near player
 	if g.player != nil && g.player.Active {
 		for _, entity := range g.world.AllEntities {
-			if entity.Type == EntityTypeXP && entity.Active && entity.Owner == g.player {
+			if entity.Type == EntityTypeXP && entity.Active && entity.Pickup.Target == g.player {
 				pickupRange := 30.0
 				distance := entity.DistanceTo(g.player)
 				if distance <= pickupRange {
 					// Award score
-					scoreValue := int(entity.MaxHealth)
+					scoreValue := entity.Pickup.Value
 					if scoreValue == 0 {
 						scoreValue = 10
 					}
-					g.score += scoreValue
+					g.addScore(entity.Pickup.Source, scoreValue)
+					g.credits += scoreValue
 
 					// Mark XP for removal (don't set Active=false, let update loop handle cleanup)
 					entity.Health = 0
@@ -785,47 +1466,205 @@ func (g *Game) Update() error {
 		}
 	}
 
+	// Collect salvage and reel it in with the salvage beam
+	g.updateSalvage()
+
 	// Update camera to follow player
-	if g.player != nil && g.player.Active {
-		// Smooth camera follow
-		dx := g.player.X - g.camera.X
-		dy := g.player.Y - g.camera.Y
-		g.camera.X += dx * 0.1
-		g.camera.Y += dy * 0.1
-	}
-
-	// Wave-based enemy spawning
-	if g.enemiesSpawnedThisWave < g.enemiesPerWave {
-		// Still spawning enemies for current wave
-		g.waveSpawnTimer += deltaTime
-		if g.waveSpawnTimer >= 0.1 { // Spawn every 0.1 seconds within wave
-			g.waveSpawnTimer = 0
-			g.spawnEnemy()
-			g.enemiesSpawnedThisWave++
-		}
-	} else {
-		// Wave complete, wait for cooldown before next wave
-		g.enemySpawnTimer += deltaTime
-		if g.enemySpawnTimer >= g.waveCooldown {
-			g.enemySpawnTimer = 0
-			// Start next wave with +1 enemy
-			g.waveNumber++
-			g.enemiesPerWave++
-			g.enemiesSpawnedThisWave = 0
-			g.waveSpawnTimer = 0
+	g.updateCamera(deltaTime)
+
+	// Stations are safe harbors: no new enemies arrive while the player stays docked
+	g.updateDocking()
+
+	// Wave-based enemy spawning (paused while docked)
+	if g.dockedStation == nil {
+		if g.enemiesSpawnedThisWave < g.enemiesPerWave {
+			// Still spawning enemies for current wave
+			g.waveSpawnTimer += deltaTime
+			if g.waveSpawnTimer >= g.waveSpawnInterval {
+				g.waveSpawnTimer = 0
+				g.spawnNextWaveEnemy()
+			}
+		} else {
+			// Reward destroying the whole wave
+			g.updateWaveClear()
+
+			// Wave complete, wait for cooldown before next wave
+			g.enemySpawnTimer += deltaTime
+			if g.enemySpawnTimer >= g.waveCooldown {
+				g.enemySpawnTimer = 0
+				g.startWave(g.waveNumber + 1)
+			}
 		}
 	}
 
+	g.frameTimer.Mark("zone, pickups and waves")
+
+	g.tickCount++
+	g.logChecksum()
+
+	// Arena bookkeeping (bot roster and spectator stream)
+	if g.config.Mode == GameModeArena {
+		g.updateArena()
+	}
+
 	return nil
 }
 
 // Draw renders the game
 func (g *Game) Draw(screen *ebiten.Image) {
+	defer g.frameTimer.EndFrame()
+	if !g.frameLimiter.ShouldDraw(time.Now(), GetSettings().Display.FrameRateCap) {
+		return // The screen keeps the last frame
+	}
 	screen.Fill(color.RGBA{20, 20, 40, 255}) // Dark blue background
+	hud := g.hudRenderer()
+	if g.worldMap.Open {
+		hud.RenderWorldMap(screen, g.worldMap, g.world, g.player, g.mission, g.waypoint)
+		return
+	}
+	hideUI := g.photoMode.Active && g.photoMode.HideUI
+	if g.splitScreen != nil {
+		g.drawSplitScreen(screen, hideUI)
+	} else {
+		g.drawView(screen, hideUI)
+	}
+	g.frameTimer.Mark("draw world")
+
+	// Death screen: how the player died and how each weapon performed this run (once out of lives, if lives are on)
+	dead := g.player == nil || !g.player.Active || g.player.Health <= 0
+	if !hideUI && g.config.Mode != GameModeArena && dead && (g.lives == nil || g.lives.OutOfLives) {
+		hud.RenderDeathRecap(screen, g.recap, g.waveNumber, g.score, g.runSeed)
+		hud.RenderWeaponStats(screen, g.weaponStats.Stats())
+		hud.RenderRunGraphs(screen, g.runStats.Samples)
+	}
+
+	if g.modMenuOpen && !hideUI {
+		hud.RenderModMenu(screen, g.mods)
+	}
+	if !hideUI {
+		hud.RenderKillFeed(screen, g.killFeed.Entries)
+		hud.RenderRadio(screen, g.radio.Messages)
+	}
+	if !hideUI && g.waveClear.Popup != nil {
+		hud.RenderWaveClear(screen, g.waveClear.Popup)
+	}
+	if !hideUI && GetDebugState().ShowStats {
+		hud.RenderStatsPanel(screen, g.statsPanel)
+	}
+	if g.loadoutMenuOpen && !hideUI {
+		hud.RenderLoadoutMenu(screen, g.player, &GetSettings().Loadout)
+	}
+	if !hideUI && g.dockedStation != nil {
+		hud.RenderShop(screen, g.dockedStation, g.player, g.credits)
+	} else if !hideUI && g.player != nil && g.player.Active && g.nearestDockableStation() != nil {
+		hud.RenderDockHint(screen)
+	}
+	if g.photoMode.Active {
+		if g.photoMode.Glow {
+			g.photoMode.ApplyGlow(screen)
+		}
+		if !hideUI {
+			hud.RenderPhotoModeHint(screen)
+		}
+		g.photoMode.SaveScreenshotIfRequested(screen)
+	}
+	g.frameTimer.Mark("draw overlays")
+}
+
+// drawView draws the world around the player with their HUD: warnings, objective, compass and waypoint
+func (g *Game) drawView(screen *ebiten.Image, hideUI bool) {
+	g.renderer.HideUI = hideUI
+	g.renderer.Heatmap = g.heatmap
+	g.renderer.Checkpoint = g.checkpoint
+	g.renderer.Lives = g.lives
+	g.renderer.Reputation = &g.world.Reputation
+	// The shake only moves the camera for this draw (never in photo mode, which frames shots by hand)
+	shakeX, shakeY := 0.0, 0.0
+	if !g.photoMode.Active {
+		shakeX, shakeY = g.screenShake.Offset()
+	}
+	g.camera.X += shakeX
+	g.camera.Y += shakeY
 	g.renderer.Render(screen, g.world, g.player, g.score, g.fps)
+	g.camera.X -= shakeX
+	g.camera.Y -= shakeY
+	if hideUI {
+		return
+	}
+	g.renderer.RenderCollisionWarnings(screen, g.player, g.collisionThreats)
+	if g.mission != nil {
+		g.renderer.RenderObjective(screen, g.mission, g.waveNumber)
+	}
+	if style := GetSettings().Compass; style != CompassOff && g.player != nil && g.player.Active {
+		g.renderer.RenderCompass(screen, style, g.player, g.waypoint, nearestCompassThreat(g.world, g.player))
+	}
+	if g.waypoint != nil {
+		g.renderer.RenderWaypoint(screen, g.waypoint, g.player, g.autopilotEngaged())
+	}
 }
 
 // Layout returns the game's screen size
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return g.config.ScreenWidth, g.config.ScreenHeight
 }
+
+// SetMods sets the installed mods shown in the mod menu
+func (g *Game) SetMods(mods []*Mod) {
+	g.mods = mods
+}
+
+// updateModMenu toggles mods with the number keys (changes apply on the next launch)
+func (g *Game) updateModMenu() {
+	settings := GetSettings()
+	for i, mod := range g.mods {
+		if i >= 9 {
+			break
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyDigit1 + ebiten.Key(i)) {
+			mod.Enabled = !mod.Enabled
+			settings.SetModEnabled(mod.Manifest.ID, mod.Enabled)
+			if err := SaveSettings(); err != nil {
+				fmt.Printf("Failed to save settings: %v\n", err)
+			}
+		}
+	}
+}
+
+// updateLoadoutMenu cycles the targeting policy of the turret whose number key was pressed and persists it
+func (g *Game) updateLoadoutMenu() {
+	if g.player == nil {
+		return
+	}
+	settings := GetSettings()
+	for i := range g.player.Turrets {
+		if i >= 9 {
+			break
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyDigit1 + ebiten.Key(i)) {
+			settings.Loadout.CycleTurretPriority(i)
+			if err := SaveSettings(); err != nil {
+				fmt.Printf("Failed to save settings: %v\n", err)
+			}
+		}
+	}
+}
+
+// updateShop buys the docked station's item whose number key was pressed
+func (g *Game) updateShop() {
+	for i := range g.dockedStation.Station.Inventory {
+		if i >= 9 {
+			break
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyDigit1 + ebiten.Key(i)) {
+			g.buyShopItem(i)
+		}
+	}
+}
+
+// WatchAssets hot-reloads sprites from an assets directory on disk (development mode)
+func (g *Game) WatchAssets(dir string) {
+	if g.sprites == nil {
+		return
+	}
+	g.sprites.Watch(dir, assets.DefaultReloadInterval)
+}
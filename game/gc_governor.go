@@ -0,0 +1,172 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+	"time"
+)
+
+const (
+	// GCGovernorInterval is the time between two adjustments of the garbage collector settings
+	GCGovernorInterval = 5 * time.Second
+
+	// GCSlowFrame is the frame time above which a frame that ran a GC cycle counts as hurt by it
+	GCSlowFrame = 20 * time.Millisecond
+
+	// GCSlowFrameShare raises GOGC when more than this share of the frames that ran a GC cycle were slow
+	GCSlowFrameShare = 0.2
+
+	// DefaultMemoryLimit is the soft memory limit the governor starts with (bytes)
+	DefaultMemoryLimit = 1 << 30
+
+	// gcMinFrames is the number of frames with a GC cycle needed before the governor judges their impact
+	gcMinFrames = 3
+
+	// GOGC range and step used by the governor (the Go default is 100)
+	gcDefaultPercent = 100
+	gcMaxPercent     = 400
+	gcPercentStep    = 50
+
+	// gcHeadroom is the share of the memory limit the heap may grow to at the chosen GOGC
+	gcHeadroom = 0.6
+)
+
+// GCConfig fixes the garbage collector settings instead of letting the governor tune them
+type GCConfig struct {
+	// GOGC is the GC target percentage (0 = tuned automatically, -1 = GC off like GOGC=off)
+	GOGC int
+
+	// MemoryLimitMB is the soft memory limit in megabytes (0 = tuned automatically)
+	MemoryLimitMB int
+}
+
+// FrameGC is what the frame timer saw of the garbage collector in the last frame
+type FrameGC struct {
+	Frame    uint64
+	Duration time.Duration
+	Cycles   uint64
+}
+
+// GCGovernor tunes GOGC and the soft memory limit while the game runs
+// Frames that ran a GC cycle are watched: if too many of them are slow, GOGC is raised so the GC runs less often,
+// and if the heap at that GOGC would come close to the memory limit, it is lowered again. The memory limit starts
+// at DefaultMemoryLimit and grows to twice the live heap if the game needs more. A setting fixed in the config
+// (or by the GOGC and GOMEMLIMIT environment variables) is applied once and left alone
+type GCGovernor struct {
+	autoPercent bool
+	autoLimit   bool
+	percent     int
+	limit       int64
+
+	// Frames seen since the last adjustment
+	lastFrame    uint64
+	gcFrames     int
+	slowGCFrames int
+	adjusted     time.Time
+
+	heapLive []metrics.Sample
+}
+
+// NewGCGovernor applies the configured settings and creates the governor for the rest
+func NewGCGovernor(config GCConfig) *GCGovernor {
+	g := &GCGovernor{
+		autoPercent: config.GOGC == 0 && os.Getenv("GOGC") == "",
+		autoLimit:   config.MemoryLimitMB == 0 && os.Getenv("GOMEMLIMIT") == "",
+		percent:     gcDefaultPercent,
+		limit:       DefaultMemoryLimit,
+		adjusted:    time.Now(),
+		heapLive:    []metrics.Sample{{Name: "/gc/heap/live:bytes"}},
+	}
+
+	switch {
+	case config.GOGC != 0:
+		g.percent = config.GOGC
+		debug.SetGCPercent(g.percent)
+	case !g.autoPercent:
+		g.percent = debug.SetGCPercent(-1) // Read back the environment's value
+		debug.SetGCPercent(g.percent)
+	default:
+		debug.SetGCPercent(g.percent)
+	}
+
+	switch {
+	case config.MemoryLimitMB != 0:
+		g.limit = int64(config.MemoryLimitMB) << 20
+		debug.SetMemoryLimit(g.limit)
+	case !g.autoLimit:
+		g.limit = debug.SetMemoryLimit(-1) // Negative reads the current limit
+	default:
+		debug.SetMemoryLimit(g.limit)
+	}
+
+	fmt.Printf("GC tuning: GOGC=%d (%s), memory limit %d MB (%s)\n",
+		g.percent, tuningName(g.autoPercent), g.limit>>20, tuningName(g.autoLimit))
+	return g
+}
+
+// tuningName describes whether a setting is tuned by the governor
+func tuningName(auto bool) string {
+	if auto {
+		return "auto"
+	}
+	return "fixed"
+}
+
+// ObserveFrame counts the last frame if it ran a GC cycle (repeated calls for the same frame are ignored)
+func (g *GCGovernor) ObserveFrame(frame FrameGC) {
+	if frame.Frame == g.lastFrame {
+		return
+	}
+	g.lastFrame = frame.Frame
+	if frame.Cycles == 0 {
+		return
+	}
+	g.gcFrames++
+	if frame.Duration > GCSlowFrame {
+		g.slowGCFrames++
+	}
+}
+
+// Update adjusts the settings once per GCGovernorInterval
+func (g *GCGovernor) Update(now time.Time) {
+	if now.Sub(g.adjusted) < GCGovernorInterval || (!g.autoPercent && !g.autoLimit) {
+		return
+	}
+	g.adjusted = now
+	gcFrames, slowGCFrames := g.gcFrames, g.slowGCFrames
+	g.gcFrames, g.slowGCFrames = 0, 0
+
+	metrics.Read(g.heapLive)
+	live := int64(g.heapLive[0].Value.Uint64())
+
+	// Make room for the live heap before judging GOGC against the limit
+	if g.autoLimit && live > g.limit/2 {
+		g.limit = 2 * live
+		debug.SetMemoryLimit(g.limit)
+		fmt.Printf("GC tuning: live heap %d MB, memory limit raised to %d MB\n", live>>20, g.limit>>20)
+	}
+
+	if !g.autoPercent {
+		return
+	}
+	heapAt := func(percent int) float64 {
+		return float64(live) * (1 + float64(percent)/100)
+	}
+	budget := gcHeadroom * float64(g.limit)
+	percent := g.percent
+	switch {
+	case heapAt(percent) > budget && percent > gcDefaultPercent:
+		percent -= gcPercentStep
+	case gcFrames >= gcMinFrames && float64(slowGCFrames) > GCSlowFrameShare*float64(gcFrames) &&
+		percent < gcMaxPercent && heapAt(percent+gcPercentStep) <= budget:
+		percent += gcPercentStep
+	}
+	if percent != g.percent {
+		g.percent = percent
+		debug.SetGCPercent(percent)
+		fmt.Printf("GC tuning: %d of %d frames with a GC were slow, live heap %d MB, GOGC set to %d\n",
+			slowGCFrames, gcFrames, live>>20, percent)
+	}
+}
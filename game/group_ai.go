@@ -0,0 +1,178 @@
+package game
+
+import "math"
+
+const (
+	// GroupSlotRadius is the radius of the ring of slots a group holds around its target (pixels)
+	GroupSlotRadius = 160.0
+
+	// GroupSlotSpin is how fast the ring of slots turns around the target (radians per second)
+	GroupSlotSpin = 0.6
+
+	// GroupSurroundTime is how long a group circles its target before diving at it (seconds)
+	GroupSurroundTime = 4.0
+
+	// GroupDiveTime is how long a dive lasts before the group falls back to its ring (seconds)
+	GroupDiveTime = 1.5
+
+	// GroupRetargetInterval is the time between the group's target searches (seconds)
+	GroupRetargetInterval = 0.5
+
+	// GroupSlotArrivalDistance is the distance from its slot at which a member throttles down (pixels)
+	GroupSlotArrivalDistance = 40.0
+
+	// GroupSpawnSpread is how far from the spawn point group members appear (pixels)
+	GroupSpawnSpread = 40.0
+)
+
+// GroupGoal is the sub-goal a group controller is pursuing
+type GroupGoal int
+
+const (
+	GroupGoalRegroup  GroupGoal = iota // No target: gather around the group's center
+	GroupGoalSurround                  // Circle the target, each member in its own slot
+	GroupGoalDive                      // Every member rams the target at once
+)
+
+// GroupAI is one brain for many ships: it searches for a target and picks the sub-goal for the whole group,
+// and gives every member a slot to fly to
+// Members skip the per-ship target search and only steer toward their slot, so a swarm of tiny ships
+// costs about as much as one full AI
+type GroupAI struct {
+	Members []*Entity
+	Target  *Entity
+	Goal    GroupGoal
+
+	goalTime     float64 // Time spent on the current goal
+	retargetTime float64 // Time until the next target search
+	spin         float64 // Current rotation of the slot ring
+	centerX      float64 // Center of the live members (regroup point)
+	centerY      float64
+}
+
+// NewGroupAI creates a group controller with no members
+func NewGroupAI() *GroupAI {
+	return &GroupAI{
+		Members: make([]*Entity, 0, 16),
+		spin:    runRNG.Float64() * 2 * math.Pi,
+	}
+}
+
+// Join adds a ship to the group; its AI input follows the group from now on
+func (g *GroupAI) Join(member *Entity, aiInput *AIInput) {
+	aiInput.Group = g
+	aiInput.groupSlot = len(g.Members)
+	g.Members = append(g.Members, member)
+}
+
+// Alive returns true while the group has members left
+func (g *GroupAI) Alive() bool {
+	return len(g.Members) > 0
+}
+
+// Update drops dead members, re-acquires the target and advances the sub-goal
+func (g *GroupAI) Update(player *Entity, world *World, deltaTime float64) {
+	// Compact dead members away; survivors keep their slot order
+	alive := g.Members[:0]
+	g.centerX, g.centerY = 0, 0
+	for _, member := range g.Members {
+		if member.Active && member.Health > 0 {
+			if aiInput, ok := member.Input.(*AIInput); ok {
+				aiInput.groupSlot = len(alive)
+			}
+			alive = append(alive, member)
+			g.centerX += member.X
+			g.centerY += member.Y
+		}
+	}
+	for i := len(alive); i < len(g.Members); i++ {
+		g.Members[i] = nil // Don't keep dead ships reachable
+	}
+	g.Members = alive
+	if len(g.Members) == 0 {
+		return
+	}
+	g.centerX /= float64(len(g.Members))
+	g.centerY /= float64(len(g.Members))
+
+	// One target search for the whole group, from its first member
+	g.retargetTime -= deltaTime
+	if g.retargetTime <= 0 || (g.Target != nil && (!g.Target.Active || g.Target.Health <= 0)) {
+		g.retargetTime = GroupRetargetInterval
+		leader := g.Members[0]
+		g.Target = findAITarget(leader, player, world, GetOppositeFaction(GetEntityFaction(leader)))
+	}
+
+	// Sub-goals: surround the target, dive at it, fall back to the ring, repeat
+	g.goalTime += deltaTime
+	g.spin += GroupSlotSpin * deltaTime
+	switch {
+	case g.Target == nil:
+		g.setGoal(GroupGoalRegroup)
+	case g.Goal == GroupGoalRegroup:
+		g.setGoal(GroupGoalSurround)
+	case g.Goal == GroupGoalSurround && g.goalTime >= GroupSurroundTime:
+		g.setGoal(GroupGoalDive)
+	case g.Goal == GroupGoalDive && g.goalTime >= GroupDiveTime:
+		g.setGoal(GroupGoalSurround)
+	}
+}
+
+// setGoal switches the group's sub-goal
+func (g *GroupAI) setGoal(goal GroupGoal) {
+	if g.Goal != goal {
+		g.Goal = goal
+		g.goalTime = 0
+	}
+}
+
+// SlotPosition returns where the member in the given slot should fly
+func (g *GroupAI) SlotPosition(slot int) (float64, float64) {
+	centerX, centerY := g.centerX, g.centerY
+	radius := GroupSlotRadius * 0.4
+	switch g.Goal {
+	case GroupGoalSurround:
+		centerX, centerY = g.Target.X, g.Target.Y
+		radius = GroupSlotRadius
+	case GroupGoalDive:
+		return g.Target.X, g.Target.Y
+	}
+
+	angle := g.spin + float64(slot)/float64(max(len(g.Members), 1))*2*math.Pi
+	return centerX + math.Cos(angle)*radius, centerY + math.Sin(angle)*radius
+}
+
+// spawnSwarm spawns a group of swarm ships sharing one GroupAI around a point
+func (g *Game) spawnSwarm(x, y float64, enemyType EnemyType) {
+	enemyConfig := GetEnemyTypeConfig(enemyType)
+	group := NewGroupAI()
+	for i := 0; i < enemyConfig.GroupSize; i++ {
+		angle := float64(i) / float64(enemyConfig.GroupSize) * 2 * math.Pi
+		aiInput := CreateEnemyAIWithType(enemyType)
+		member := NewEntityWithShipType(
+			x+math.Cos(angle)*GroupSpawnSpread,
+			y+math.Sin(angle)*GroupSpawnSpread,
+			EntityTypeEnemy, enemyConfig.ShipType, aiInput,
+		)
+		member.Faction = FactionEnemy
+		member.Rotation = angle
+		group.Join(member, aiInput)
+		g.world.RegisterEntity(member)
+	}
+	g.groups = append(g.groups, group)
+}
+
+// updateGroups runs every group controller once and forgets groups whose members are all dead
+func (g *Game) updateGroups(deltaTime float64) {
+	alive := g.groups[:0]
+	for _, group := range g.groups {
+		group.Update(g.player, g.world, deltaTime)
+		if group.Alive() {
+			alive = append(alive, group)
+		}
+	}
+	for i := len(alive); i < len(g.groups); i++ {
+		g.groups[i] = nil
+	}
+	g.groups = alive
+}
@@ -0,0 +1,87 @@
+package game
+
+import "fmt"
+
+const (
+	// HealthBarMaxDistance hides the health bars of ships farther than this from the camera center (pixels)
+	HealthBarMaxDistance = 1200.0
+
+	// HealthBarEliteDistance is the farther cutoff for elite and boss bars, which also carry a name (pixels)
+	HealthBarEliteDistance = 2000.0
+
+	// healthBarEliteSegments and healthBarBossSegments divide the bars of elites and bosses into segments
+	healthBarEliteSegments = 4
+	healthBarBossSegments  = 10
+)
+
+// HealthBarMode selects when health bars are drawn over ships
+type HealthBarMode int
+
+const (
+	HealthBarsOnDamage HealthBarMode = iota // Once the ship has lost health
+	HealthBarsAlways
+	HealthBarsNever
+	healthBarModeCount
+)
+
+// healthBarModeNames are the names used by the -health-bars flag
+var healthBarModeNames = [healthBarModeCount]string{"on-damage", "always", "never"}
+
+// String returns the mode's flag name
+func (m HealthBarMode) String() string {
+	if m < 0 || m >= healthBarModeCount {
+		return healthBarModeNames[HealthBarsOnDamage]
+	}
+	return healthBarModeNames[m]
+}
+
+// ParseHealthBarMode parses a health bar mode name ("on-damage", "always" or "never")
+func ParseHealthBarMode(name string) (HealthBarMode, error) {
+	for mode, modeName := range healthBarModeNames {
+		if name == modeName {
+			return HealthBarMode(mode), nil
+		}
+	}
+	return HealthBarsOnDamage, fmt.Errorf("unknown health bar mode %q", name)
+}
+
+// healthBarRank sorts ships by how much their bar stands out: regular, elite or boss
+type healthBarRank int
+
+const (
+	healthBarRegular healthBarRank = iota
+	healthBarElite
+	healthBarBoss
+)
+
+// healthBarStyle returns how an entity's health bar is drawn: its rank, and the name shown above elite and boss bars
+func healthBarStyle(entity *Entity) (healthBarRank, string) {
+	if isMiniBoss(entity) {
+		return healthBarBoss, "Mini-boss " + GetShipTypeConfig(entity.ShipType).Name
+	}
+	if entity.Elite != EliteNone {
+		return healthBarElite, GetEliteModifierConfig(entity.Elite).Name + " " + GetShipTypeConfig(entity.ShipType).Name
+	}
+	return healthBarRegular, ""
+}
+
+// healthBarRange returns how far from the camera center a ship of the given rank still gets its health bar
+// and status icons (pixels)
+func healthBarRange(rank healthBarRank) float64 {
+	if rank == healthBarRegular {
+		return HealthBarMaxDistance
+	}
+	return HealthBarEliteDistance
+}
+
+// showHealthBar returns true if the mode shows a bar over the entity (always: every ship, and anything damaged)
+func (m HealthBarMode) showHealthBar(entity *Entity) bool {
+	switch m {
+	case HealthBarsAlways:
+		return entity.Type == EntityTypePlayer || entity.Type == EntityTypeEnemy || entity.Health < entity.MaxHealth
+	case HealthBarsNever:
+		return false
+	default:
+		return entity.Health < entity.MaxHealth
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import "math"
+
+const (
+	// HeatmapCellSize is the side length of the squares the heatmap overlay colors (pixels)
+	HeatmapCellSize = 256.0
+
+	// HeatmapDamageHalfLife is how long recorded damage takes to fade to half on the damage heatmap (seconds)
+	HeatmapDamageHalfLife = 10.0
+
+	// heatmapMinDamage is the faded damage below which a cell is forgotten
+	heatmapMinDamage = 1.0
+)
+
+// HeatmapMode selects what the debug heatmap overlay shows
+type HeatmapMode int
+
+const (
+	HeatmapOff     HeatmapMode = iota
+	HeatmapDensity             // Live entities per cell
+	HeatmapDamage              // Recent damage dealt in each cell
+	heatmapModeCount
+)
+
+// heatmapModeNames are the names shown in the overlay legend
+var heatmapModeNames = [heatmapModeCount]string{"Off", "Entity density", "Recent damage"}
+
+// String returns the mode's display name
+func (m HeatmapMode) String() string {
+	if m < 0 || m >= heatmapModeCount {
+		return heatmapModeNames[HeatmapOff]
+	}
+	return heatmapModeNames[m]
+}
+
+// Next returns the following mode, wrapping around (used to cycle it with F10)
+func (m HeatmapMode) Next() HeatmapMode {
+	return (m + 1) % heatmapModeCount
+}
+
+// HeatCell identifies a heatmap square by its column and row, counted from the world origin
+type HeatCell struct {
+	X, Y int
+}
+
+// HeatCellAt returns the heatmap cell containing a world position
+func HeatCellAt(x, y float64) HeatCell {
+	return HeatCell{X: int(math.Floor(x / HeatmapCellSize)), Y: int(math.Floor(y / HeatmapCellSize))}
+}
+
+// Heatmap accumulates where damage lands, fading over time, for the damage heatmap
+// Entity density needs no history and is counted when the overlay is drawn
+type Heatmap struct {
+	Damage map[HeatCell]float64
+}
+
+// NewHeatmap creates an empty heatmap
+func NewHeatmap() *Heatmap {
+	return &Heatmap{Damage: make(map[HeatCell]float64, 64)}
+}
+
+// Reset forgets all recorded damage (a new run)
+func (h *Heatmap) Reset() {
+	clear(h.Damage)
+}
+
+// AddDamage records damage dealt at a world position
+func (h *Heatmap) AddDamage(x, y, amount float64) {
+	h.Damage[HeatCellAt(x, y)] += amount
+}
+
+// Update fades the recorded damage
+func (h *Heatmap) Update(deltaTime float64) {
+	if len(h.Damage) == 0 {
+		return
+	}
+	fade := math.Exp2(-deltaTime / HeatmapDamageHalfLife)
+	for cell, damage := range h.Damage {
+		if damage *= fade; damage < heatmapMinDamage {
+			delete(h.Damage, cell)
+		} else {
+			h.Damage[cell] = damage
+		}
+	}
+}
+
+// onHeatmapDamage records every hit for the damage heatmap
+func (g *Game) onHeatmapDamage(event Event) {
+	if event.Target != nil {
+		g.heatmap.AddDamage(event.Target.X, event.Target.Y, event.Amount)
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+// HitStopKind identifies the big event that triggered a hit-stop (each has its own duration)
+type HitStopKind int
+
+const (
+	HitStopHeavyDamage HitStopKind = iota // Player took a heavy hit
+	HitStopBossPhase                      // A boss changed phase
+	HitStopEliteKill                      // Player landed the killing blow on an elite
+	hitStopKindCount
+)
+
+// HitStopConfig configures the brief slow-motion pause on big events
+type HitStopConfig struct {
+	// Scale is the time scale during a hit-stop (0.1 = 10% speed; 1 disables hit-stop)
+	Scale float64
+
+	// Frames is the hit-stop duration per event kind, in rendered frames (0 = no hit-stop for that event)
+	Frames [hitStopKindCount]int
+
+	// HeavyDamageThreshold is the damage in one hit that counts as heavy for the player
+	HeavyDamageThreshold float64
+}
+
+// DefaultHitStopConfig returns a subtle 2-4 frame hit-stop
+func DefaultHitStopConfig() HitStopConfig {
+	return HitStopConfig{
+		Scale: 0.1,
+		Frames: [hitStopKindCount]int{
+			HitStopHeavyDamage: 3,
+			HitStopBossPhase:   4,
+			HitStopEliteKill:   2,
+		},
+		HeavyDamageThreshold: 40,
+	}
+}
+
+// HitStop slows the simulation for a few frames after big events
+// It is one more factor in the time scale Update passes to Step, next to the game speed setting
+type HitStop struct {
+	config HitStopConfig
+	frames int // Frames of hit-stop left
+}
+
+// NewHitStop creates a hit-stop with the given configuration
+func NewHitStop(config HitStopConfig) *HitStop {
+	return &HitStop{config: config}
+}
+
+// Trigger starts a hit-stop for the event kind; overlapping triggers keep the longest remaining duration
+func (h *HitStop) Trigger(kind HitStopKind) {
+	h.frames = max(h.frames, h.config.Frames[kind])
+}
+
+// TimeScale consumes one frame of hit-stop and returns the time scale for this frame
+func (h *HitStop) TimeScale() float64 {
+	if h.frames <= 0 {
+		return 1
+	}
+	h.frames--
+	return h.config.Scale
+}
+
+// onHitStopDamage triggers a hit-stop when the player takes a heavy hit
+func (g *Game) onHitStopDamage(event Event) {
+	if event.Target != nil && event.Target == g.player && event.Amount >= g.config.HitStop.HeavyDamageThreshold {
+		g.hitStop.Trigger(HitStopHeavyDamage)
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import "math"
+
+const (
+	// ImpactSparkCount is the number of sparks in a hit's burst at full particle density
+	ImpactSparkCount = 6
+
+	// ImpactSparkLifetime is how long a hit's spark burst lasts (seconds)
+	ImpactSparkLifetime = 0.25
+
+	// ImpactSparkSpread is the half angle of the cone the sparks fly in, around the projectile's heading (radians)
+	ImpactSparkSpread = 0.7
+
+	// ScorchMinRadius is the radius from which hit entities are scarred with scorch decals (big ships, asteroids, stations)
+	ScorchMinRadius = 20.0
+
+	// ScorchLifetime is how long a scorch decal stays on its entity (seconds)
+	ScorchLifetime = 4.0
+
+	// MaxScorchesPerEntity caps the scorch decals on one entity; hits beyond it leave none
+	MaxScorchesPerEntity = 5
+
+	// maxImpactFXDrawn caps the sparks and scorches drawn per frame
+	maxImpactFXDrawn = 64
+)
+
+// isImpactKind returns true for the FX left by projectile hits (spark bursts, scorch decals and chain lightning)
+func isImpactKind(kind IndicatorKind) bool {
+	return kind == IndicatorKindImpactSparks || kind == IndicatorKindScorch || kind == IndicatorKindArc
+}
+
+// spawnImpactFX adds the effects of a projectile hitting target: a spark burst at the impact, flying on along
+// the projectile's heading, and a scorch decal on big entities and bosses
+func (g *Game) spawnImpactFX(projectile, target *Entity) {
+	if GetSettings().ParticleDensity <= 0 {
+		return
+	}
+
+	// The impact point is on the target's surface, facing the projectile
+	dx, dy := projectile.X-target.X, projectile.Y-target.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	hitX, hitY := projectile.X, projectile.Y
+	if distance > target.Radius {
+		hitX = target.X + dx/distance*target.Radius
+		hitY = target.Y + dy/distance*target.Radius
+	}
+
+	sparks := NewEntity(hitX, hitY, 0, EntityTypeDestroyedIndicator, nil)
+	sparks.Indicator.Kind = IndicatorKindImpactSparks
+	sparks.Rotation = math.Atan2(projectile.VY, projectile.VX)
+	sparks.Active = true
+	sparks.Health = 1.0 // Small health value so it renders
+	sparks.MaxHealth = 1.0
+	sparks.Lifetime = ImpactSparkLifetime
+	sparks.NoCollision = true
+	g.world.RegisterEntity(sparks)
+
+	if target.Radius < ScorchMinRadius && !isMiniBoss(target) {
+		return
+	}
+	if g.scorchCount(target) >= MaxScorchesPerEntity {
+		return
+	}
+
+	// Decals are kept in the target's own frame so they turn and move with it
+	offsetX, offsetY := hitX-target.X, hitY-target.Y
+	cos, sin := math.Cos(-target.Rotation), math.Sin(-target.Rotation)
+	scorch := NewEntity(hitX, hitY, math.Max(target.Radius*0.2, 4), EntityTypeDestroyedIndicator, nil)
+	scorch.Indicator.Kind = IndicatorKindScorch
+	scorch.Indicator.HostID = target.ID
+	scorch.Indicator.OffsetX = offsetX*cos - offsetY*sin
+	scorch.Indicator.OffsetY = offsetX*sin + offsetY*cos
+	scorch.Rotation = sparks.Rotation
+	scorch.Active = true
+	scorch.Health = 1.0
+	scorch.MaxHealth = 1.0
+	scorch.Lifetime = ScorchLifetime
+	scorch.NoCollision = true
+	g.world.RegisterEntity(scorch)
+}
+
+// scorchCount returns the number of live scorch decals on the entity
+func (g *Game) scorchCount(host *Entity) int {
+	count := 0
+	for _, fx := range g.world.FX {
+		if fx.Active && fx.Indicator.Kind == IndicatorKindScorch && fx.Indicator.HostID == host.ID {
+			count++
+		}
+	}
+	return count
+}
+
+// isMiniBoss returns true for AI ships spawned as a wave's mini-boss
+func isMiniBoss(entity *Entity) bool {
+	aiInput, ok := entity.Input.(*AIInput)
+	return ok && aiInput.miniBoss
+}
+
+// scorchPosition returns where a scorch decal is in the world: on its host, turned with it
+// It returns false once the host is gone
+func scorchPosition(world *World, scorch *Entity) (float64, float64, bool) {
+	host := world.GetEntityByID(scorch.Indicator.HostID)
+	if host == nil || !host.Active || host.Health <= 0 {
+		return 0, 0, false
+	}
+	cos, sin := math.Cos(host.Rotation), math.Sin(host.Rotation)
+	offsetX, offsetY := scorch.Indicator.OffsetX, scorch.Indicator.OffsetY
+	return host.X + offsetX*cos - offsetY*sin, host.Y + offsetX*sin + offsetY*cos, true
+}
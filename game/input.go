@@ -1,10 +1,15 @@
 package game
 
 import (
+	"math"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
+// GamepadDeadZone is how far a stick must move before it counts as input (0-1)
+const GamepadDeadZone = 0.2
+
 // InputProvider defines the interface for entity input/behavior
 type InputProvider interface {
 	// GetThrust returns the thrust amount (-1 to 1, where 1 is forward, -1 is backward)
@@ -23,62 +28,148 @@ type InputProvider interface {
 	Update(deltaTime float64)
 }
 
+// InputFrame is the player's input for one tick
+type InputFrame struct {
+	Thrust     float64 // -1 to 1, like GetThrust
+	Rotation   float64 // -1 to 1, like GetRotation
+	Shoot      bool    // Manual fire (space)
+	Tractor    bool    // Tractor beam (T)
+	Respawn    bool    // Respawn (R)
+	Retry      bool    // Respawn on the same seed (Y)
+	Checkpoint bool    // Restart from the last checkpoint (K)
+	Continue   bool    // Continue when out of lives (Enter)
+}
+
+// InputScript returns the player's input for a tick, counted from when the script was set
+// Scripts replace the keyboard for recorded replays and scripted bots (automated tests)
+type InputScript func(tick int) InputFrame
+
+// ReplayInput returns a script that plays back recorded frames, then releases every key
+func ReplayInput(frames []InputFrame) InputScript {
+	return func(tick int) InputFrame {
+		if tick < len(frames) {
+			return frames[tick]
+		}
+		return InputFrame{}
+	}
+}
+
 // PlayerInput provides input from keyboard/gamepad
 type PlayerInput struct {
 	keys []ebiten.Key
 
-	// Target acquisition AI (per turret)
-	TurretTargets  map[int]TurretTarget // Target info per turret index
-	MaxTargetRange float64              // Maximum range to acquire targets
+	// Scripted input replacing the keyboard while set
+	script InputScript
+	tick   int
+	frame  InputFrame
 
-	// Turret rotations (per turret index)
-	TurretRotations map[int]float64 // Current rotation of each turret
+	// Frames recorded from the live input while recording
+	recording []InputFrame
+	recordOn  bool
 
-	// Weapon cooldowns (tracked per turret index to allow independent firing)
-	TurretCooldowns map[int]float64 // Time since last shot per turret index
-}
+	// Target acquisition AI (per-turret targets live on the entity's TurretState)
+	MaxTargetRange float64 // Maximum range to acquire targets
 
-// TurretTarget contains target information for a single turret
-type TurretTarget struct {
-	TargetX, TargetY float64
-	HasTarget        bool
+	// Whether any turret currently has a target (set by player targeting)
+	hasTarget bool
+
+	// Flies the ship instead of the keys while engaged (see autopilot.go)
+	autopilot Autopilot
+
+	// Reads the first connected gamepad instead of the keyboard (the co-op player, see coop.go)
+	gamepad    bool
+	gamepadIDs []ebiten.GamepadID
 }
 
 // NewPlayerInput creates a new player input provider
 func NewPlayerInput() *PlayerInput {
 	return &PlayerInput{
-		keys:            make([]ebiten.Key, 0, 10),
-		MaxTargetRange:  1000.0, // 1000 pixels max range
-		TurretTargets:   make(map[int]TurretTarget),
-		TurretRotations: make(map[int]float64),
-		TurretCooldowns: make(map[int]float64),
+		keys:           make([]ebiten.Key, 0, 10),
+		MaxTargetRange: 1000.0, // 1000 pixels max range
 	}
 }
 
-// GetThrust returns forward/backward thrust based on W/S or Up/Down keys
+// NewGamepadPlayerInput creates a player input provider reading the first connected gamepad
+// Left stick steers and thrusts, A fires, B holds the tractor beam
+func NewGamepadPlayerInput() *PlayerInput {
+	input := NewPlayerInput()
+	input.gamepad = true
+	input.gamepadIDs = make([]ebiten.GamepadID, 0, 4)
+	return input
+}
+
+// GetThrust returns forward/backward thrust based on W/S or Up/Down keys (or the autopilot's)
 // Returns -1 to 1, where 1 is forward thrust, -1 is backward thrust
 func (p *PlayerInput) GetThrust() float64 {
-	thrust := 0.0
-	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
-		thrust += 1.0 // Forward
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
-		thrust -= 1.0 // Backward
+	if p.autopilot.Engaged {
+		return p.autopilot.Thrust
 	}
+	thrust, _ := p.manualControls()
 	return thrust
 }
 
-// GetRotation returns manual rotation from A/D or Left/Right keys
+// GetRotation returns manual rotation from A/D or Left/Right keys (or the autopilot's)
 // Returns -1 to 1, where 1 is clockwise rotation
 func (p *PlayerInput) GetRotation() float64 {
-	rotation := 0.0
+	if p.autopilot.Engaged {
+		return p.autopilot.Rotation
+	}
+	_, rotation := p.manualControls()
+	return rotation
+}
+
+// manualControls returns the thrust and rotation from the keys (or the script), ignoring the autopilot
+func (p *PlayerInput) manualControls() (thrust, rotation float64) {
+	if p.script != nil {
+		return p.frame.Thrust, p.frame.Rotation
+	}
+	if p.gamepad {
+		return p.gamepadControls()
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
+		thrust += 1.0 // Forward
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
+		thrust -= 1.0 // Backward
+	}
 	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
 		rotation -= 1.0 // Counter-clockwise
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
 		rotation += 1.0 // Clockwise
 	}
-	return rotation
+	return thrust, rotation
+}
+
+// gamepadControls returns the thrust and rotation from the gamepad's left stick
+func (p *PlayerInput) gamepadControls() (thrust, rotation float64) {
+	id, ok := p.gamepadID()
+	if !ok {
+		return 0, 0
+	}
+	thrust = -ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical) // Up is negative
+	rotation = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+	if math.Abs(thrust) < GamepadDeadZone {
+		thrust = 0
+	}
+	if math.Abs(rotation) < GamepadDeadZone {
+		rotation = 0
+	}
+	return thrust, rotation
+}
+
+// gamepadButton returns true while a button of the gamepad is held
+func (p *PlayerInput) gamepadButton(button ebiten.StandardGamepadButton) bool {
+	id, ok := p.gamepadID()
+	return ok && ebiten.IsStandardGamepadButtonPressed(id, button)
+}
+
+// gamepadID returns the gamepad read by a gamepad input (false if none with a standard layout is connected)
+func (p *PlayerInput) gamepadID() (ebiten.GamepadID, bool) {
+	if len(p.gamepadIDs) == 0 || !ebiten.IsStandardGamepadLayoutAvailable(p.gamepadIDs[0]) {
+		return 0, false
+	}
+	return p.gamepadIDs[0], true
 }
 
 // ShouldShoot returns true if there's a target (auto-shoot) or spacebar is pressed
@@ -89,58 +180,123 @@ func (p *PlayerInput) ShouldShoot() bool {
 		return true
 	}
 	// Fallback to manual shooting
+	return p.manualShoot()
+}
+
+// manualShoot returns true while space (or the gamepad's A button) is held, or the script fires
+func (p *PlayerInput) manualShoot() bool {
+	if p.script != nil {
+		return p.frame.Shoot
+	}
+	if p.gamepad {
+		return p.gamepadButton(ebiten.StandardGamepadButtonRightBottom)
+	}
 	return ebiten.IsKeyPressed(ebiten.KeySpace)
 }
 
 // HasTarget returns true if the player has a valid target (for any turret)
 func (p *PlayerInput) HasTarget() bool {
-	for _, target := range p.TurretTargets {
-		if target.HasTarget {
-			return true
-		}
+	return p.hasTarget
+}
+
+// ShouldFireTractor returns true while T (or the gamepad's B button) is held (tractor beam turrets fire only on demand)
+func (p *PlayerInput) ShouldFireTractor() bool {
+	if p.script != nil {
+		return p.frame.Tractor
 	}
-	return false
+	if p.gamepad {
+		return p.gamepadButton(ebiten.StandardGamepadButtonRightRight)
+	}
+	return ebiten.IsKeyPressed(ebiten.KeyT)
 }
 
-// GetTurretTarget returns target info for a specific turret index
-func (p *PlayerInput) GetTurretTarget(turretIndex int) TurretTarget {
-	if target, ok := p.TurretTargets[turretIndex]; ok {
-		return target
+// ShouldRespawn returns true if R key is pressed
+func (p *PlayerInput) ShouldRespawn() bool {
+	if p.script != nil {
+		return p.frame.Respawn
 	}
-	return TurretTarget{HasTarget: false}
+	if p.gamepad {
+		return false // The keyboard player restarts the run
+	}
+	return ebiten.IsKeyPressed(ebiten.KeyR)
 }
 
-// GetTurretRotation returns the rotation for a specific turret index
-func (p *PlayerInput) GetTurretRotation(turretIndex int) float64 {
-	if rotation, ok := p.TurretRotations[turretIndex]; ok {
-		return rotation
+// ShouldLoadCheckpoint returns true if K key is pressed (restart from the last checkpoint)
+func (p *PlayerInput) ShouldLoadCheckpoint() bool {
+	if p.script != nil {
+		return p.frame.Checkpoint
+	}
+	if p.gamepad {
+		return false
 	}
-	return 0.0
+	return ebiten.IsKeyPressed(ebiten.KeyK)
 }
 
-// ResetTurretCooldown resets cooldown for a specific turret index
-func (p *PlayerInput) ResetTurretCooldown(turretIndex int) {
-	if p.TurretCooldowns == nil {
-		p.TurretCooldowns = make(map[int]float64)
+// ShouldContinue returns true if Enter is pressed (continue when out of lives)
+func (p *PlayerInput) ShouldContinue() bool {
+	if p.script != nil {
+		return p.frame.Continue
 	}
-	p.TurretCooldowns[turretIndex] = 0.0
+	if p.gamepad {
+		return false
+	}
+	return ebiten.IsKeyPressed(ebiten.KeyEnter)
 }
 
-// ShouldRespawn returns true if R key is pressed
-func (p *PlayerInput) ShouldRespawn() bool {
-	return ebiten.IsKeyPressed(ebiten.KeyR)
+// ShouldRetry returns true if Y key is pressed (restart on the same seed)
+func (p *PlayerInput) ShouldRetry() bool {
+	if p.script != nil {
+		return p.frame.Retry
+	}
+	if p.gamepad {
+		return false
+	}
+	return ebiten.IsKeyPressed(ebiten.KeyY)
+}
+
+// SetScript replaces the keyboard with scripted input from the next tick on (nil restores the keyboard)
+func (p *PlayerInput) SetScript(script InputScript) {
+	p.script = script
+	p.tick = 0
+	p.frame = InputFrame{}
+}
+
+// StartRecording starts capturing one InputFrame per tick, discarding any earlier recording
+func (p *PlayerInput) StartRecording() {
+	p.recording = p.recording[:0]
+	p.recordOn = true
+}
+
+// StopRecording stops capturing and returns the recorded frames (replay them with ReplayInput)
+func (p *PlayerInput) StopRecording() []InputFrame {
+	p.recordOn = false
+	return p.recording
 }
 
 // Update updates the input state
 func (p *PlayerInput) Update(deltaTime float64) {
-	// Update pressed keys
-	p.keys = inpututil.AppendPressedKeys(p.keys[:0])
+	if p.script != nil {
+		p.frame = p.script(p.tick)
+		p.tick++
+	} else if p.gamepad {
+		// Gamepads can be plugged in and out at any time
+		p.gamepadIDs = ebiten.AppendGamepadIDs(p.gamepadIDs[:0])
+	} else {
+		// Update pressed keys
+		p.keys = inpututil.AppendPressedKeys(p.keys[:0])
+	}
 
-	// Update turret cooldowns
-	if p.TurretCooldowns != nil {
-		for turretIndex := range p.TurretCooldowns {
-			p.TurretCooldowns[turretIndex] += deltaTime
-		}
+	if p.recordOn {
+		p.recording = append(p.recording, InputFrame{
+			Thrust:     p.GetThrust(),
+			Rotation:   p.GetRotation(),
+			Shoot:      p.manualShoot(),
+			Tractor:    p.ShouldFireTractor(),
+			Respawn:    p.ShouldRespawn(),
+			Retry:      p.ShouldRetry(),
+			Checkpoint: p.ShouldLoadCheckpoint(),
+			Continue:   p.ShouldContinue(),
+		})
 	}
 }
 
@@ -155,8 +311,9 @@ type AIInput struct {
 	// Current behavior state
 	State AIState
 
-	// Time since last shot
+	// Time since last shot, and this ship's own cooldown (rolled at spawn, see RollShootCooldown)
 	TimeSinceLastShot float64
+	ShootCooldown     float64
 
 	// Movement pattern parameters
 	PatternX, PatternY float64
@@ -171,8 +328,32 @@ type AIInput struct {
 	// Whether a valid target is currently acquired
 	hasTarget bool
 
-	// Weapon cooldowns (tracked per weapon type)
-	WeaponCooldowns map[WeaponType]float64 // Time since last shot per weapon type
+	// Ticks until the next full re-evaluation (see AIScheduler)
+	thinkCountdown int
+
+	// Group controller steering this ship (swarm members), its slot in the group and the thrust toward it
+	Group       *GroupAI
+	groupSlot   int
+	groupThrust float64
+
+	// Projectile evasion (see dodge.go): reaction delay left, time until the projectile passes,
+	// and the sideways direction to push in
+	dodgeReaction  float64
+	dodgeTime      float64
+	dodgeX, dodgeY float64
+
+	// Patrol post (sector patrols, see sectors.go): the ship circles it and only engages nearby targets
+	patrol           bool
+	patrolX, patrolY float64
+
+	// Chasing a target near a team ping (see ping.go); overrides the patrol leash
+	answeringPing bool
+
+	// Mini-boss profile (see miniboss.go): fights like a shooter, but flees to heal when hurt
+	// A cornered mini-boss fights on until it dies or heals up
+	miniBoss bool
+	fleeing  bool
+	cornered bool
 }
 
 // AIState represents the current AI behavior state
@@ -190,24 +371,52 @@ func NewAIInput() *AIInput {
 		State:           AIStateMoving,
 		EnemyType:       EnemyTypeRocket, // Default
 		DesiredRotation: 0.0,
-		WeaponCooldowns: make(map[WeaponType]float64),
 	}
 }
 
 // NewAIInputWithType creates a new AI input provider with a specific enemy type
+// The ship's shoot cooldown is rolled from runRNG, so call it while spawning, from the game loop
 func NewAIInputWithType(enemyType EnemyType) *AIInput {
+	return newAIInputWithCooldown(enemyType, GetEnemyTypeConfig(enemyType).RollShootCooldown(runRNG))
+}
+
+// newAIInputWithCooldown creates an AI input provider with a specific enemy type and an already rolled shoot cooldown
+func newAIInputWithCooldown(enemyType EnemyType, shootCooldown float64) *AIInput {
 	ai := &AIInput{
 		State:           AIStateMoving,
 		EnemyType:       enemyType,
 		DesiredRotation: 0.0,
-		WeaponCooldowns: make(map[WeaponType]float64),
+		ShootCooldown:   shootCooldown,
+	}
+
+	// Mini-bosses, stalkers and capital ships fight like shooters, with their own profile on top
+	switch enemyType {
+	case EnemyTypeMiniBoss:
+		ai.EnemyType = EnemyTypeShooter
+		ai.miniBoss = true
+	case EnemyTypeStalker:
+		ai.EnemyType = EnemyTypeShooter // Decloak-to-attack runs on the ship's cloak (see cloak.go)
+	case EnemyTypeCapital:
+		ai.EnemyType = EnemyTypeShooter // The sections' turrets are aimed by the capital ship (see capital.go)
 	}
 	return ai
 }
 
+// SetPatrol makes the ship guard a post instead of hunting the player across the map
+func (a *AIInput) SetPatrol(x, y float64) {
+	a.patrol = true
+	a.patrolX = x
+	a.patrolY = y
+}
+
 // GetThrust returns forward thrust towards target
 // Returns -1 to 1, where 1 is forward thrust, -1 is backward thrust
 func (a *AIInput) GetThrust() float64 {
+	// Group members ease off near their slot so they hold formation
+	if a.Group != nil {
+		return a.groupThrust
+	}
+
 	// AI always tries to move forward (thrust = 1.0)
 	// Turning will handle direction changes
 	return 1.0
@@ -232,23 +441,8 @@ func (a *AIInput) HasTarget() bool {
 	return a.hasTarget
 }
 
-// ResetWeaponCooldown resets cooldown for a specific weapon type
-func (a *AIInput) ResetWeaponCooldown(weaponType WeaponType) {
-	if a.WeaponCooldowns == nil {
-		a.WeaponCooldowns = make(map[WeaponType]float64)
-	}
-	a.WeaponCooldowns[weaponType] = 0.0
-}
-
 // Update updates the AI state
 func (a *AIInput) Update(deltaTime float64) {
 	a.TimeSinceLastShot += deltaTime
 	a.PatternTime += deltaTime
-
-	// Update weapon cooldowns
-	if a.WeaponCooldowns != nil {
-		for weaponType := range a.WeaponCooldowns {
-			a.WeaponCooldowns[weaponType] += deltaTime
-		}
-	}
 }
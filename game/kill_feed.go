@@ -0,0 +1,87 @@
+package game
+
+const (
+	// KillFeedSize is the number of kills listed at once
+	KillFeedSize = 5
+
+	// KillFeedDuration is how long a kill stays listed (seconds)
+	KillFeedDuration = 4.0
+)
+
+// KillFeedEntry is one line of the kill feed
+type KillFeedEntry struct {
+	Killer  string
+	Weapon  WeaponType // The weapon that landed the killing hit
+	Victim  string
+	Assists int
+	Age     float64
+
+	targetID uint64 // Victim, to attach the assists published right after the kill
+}
+
+// KillFeed lists recent kills involving the player's side, newest last
+type KillFeed struct {
+	Entries []KillFeedEntry
+}
+
+// NewKillFeed creates an empty kill feed
+func NewKillFeed() *KillFeed {
+	return &KillFeed{Entries: make([]KillFeedEntry, 0, KillFeedSize)}
+}
+
+// Update ages entries and drops expired ones
+func (f *KillFeed) Update(deltaTime float64) {
+	kept := f.Entries[:0]
+	for _, entry := range f.Entries {
+		entry.Age += deltaTime
+		if entry.Age < KillFeedDuration {
+			kept = append(kept, entry)
+		}
+	}
+	f.Entries = kept
+}
+
+// Reset clears the feed
+func (f *KillFeed) Reset() {
+	f.Entries = f.Entries[:0]
+}
+
+// add appends an entry, dropping the oldest when full
+func (f *KillFeed) add(entry KillFeedEntry) {
+	if len(f.Entries) == KillFeedSize {
+		copy(f.Entries, f.Entries[1:])
+		f.Entries = f.Entries[:KillFeedSize-1]
+	}
+	f.Entries = append(f.Entries, entry)
+}
+
+// killFeedName returns how a ship is named in the kill feed
+func (g *Game) killFeedName(entity *Entity, id uint64) string {
+	if g.player != nil && id == g.player.ID {
+		return "You"
+	}
+	if entity == nil {
+		return "Wreck" // The shooter died before its shot landed
+	}
+	return GetShipTypeConfig(entity.ShipType).Name
+}
+
+// onKillFeedKill lists kills scored by or against the player's side
+func (g *Game) onKillFeedKill(event Event) {
+	if event.SourceFaction != FactionPlayer && event.Target.Faction != FactionPlayer {
+		return
+	}
+	g.killFeed.add(KillFeedEntry{
+		Killer:   g.killFeedName(event.Source, event.SourceID),
+		Weapon:   event.Weapon,
+		Victim:   g.killFeedName(event.Target, event.Target.ID),
+		targetID: event.Target.ID,
+	})
+}
+
+// onKillFeedAssist counts an assist on the kill it belongs to
+func (g *Game) onKillFeedAssist(event Event) {
+	if n := len(g.killFeed.Entries); n > 0 && g.killFeed.Entries[n-1].targetID == event.Target.ID {
+		g.killFeed.Entries[n-1].Assists++
+	}
+}
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// MaxLights caps the lights drawn per frame; explosions claim slots first, then engines, then projectiles
+	MaxLights = 128
+
+	// LightingAmbient is how bright the scene is away from any light (0-1)
+	LightingAmbient = 0.45
+
+	// ProjectileLightRadius, EngineLightRadius and ExplosionLightRadius are the reach of each light source (pixels)
+	ProjectileLightRadius = 40.0
+	EngineLightRadius     = 70.0
+	ExplosionLightRadius  = 140.0
+
+	// lightFalloffSize is the size of the radial falloff image every light is drawn with (pixels)
+	lightFalloffSize = 128
+)
+
+// engineLightColor is the warm glow behind thrusting ships
+var engineLightColor = color.RGBA{255, 170, 90, 255}
+
+// blendMultiply multiplies the destination by the source color, darkening everything a light doesn't reach
+var blendMultiply = ebiten.Blend{
+	BlendFactorSourceRGB:        ebiten.BlendFactorZero,
+	BlendFactorSourceAlpha:      ebiten.BlendFactorZero,
+	BlendFactorDestinationRGB:   ebiten.BlendFactorSourceColor,
+	BlendFactorDestinationAlpha: ebiten.BlendFactorOne,
+	BlendOperationRGB:           ebiten.BlendOperationAdd,
+	BlendOperationAlpha:         ebiten.BlendOperationAdd,
+}
+
+// Light is a radial light in screen space
+type Light struct {
+	X, Y      float64
+	Radius    float64
+	Color     color.RGBA
+	Intensity float64 // 0-1
+}
+
+// lightingEnabled returns true if the world should be drawn with the lighting pass
+// Low-end mode bypasses it, like the other post-processing
+func lightingEnabled() bool {
+	settings := GetSettings()
+	return settings.Lighting && !settings.LowEnd
+}
+
+// Lighting darkens the scene to an ambient level and lights it up around projectiles, engines and explosions
+// Lights are added to a light buffer, which is then multiplied over the scene
+type Lighting struct {
+	buffer  *ebiten.Image // Ambient plus every light, sized like the screen
+	falloff *ebiten.Image // White radial gradient, scaled and tinted per light
+
+	// Lights gathered this frame (the backing array is reused, at most MaxLights)
+	lights []Light
+}
+
+// Reset drops the lights of the previous frame
+func (l *Lighting) Reset() {
+	l.lights = l.lights[:0]
+}
+
+// Add queues a light for this frame; returns false once MaxLights is reached
+func (l *Lighting) Add(light Light) bool {
+	if len(l.lights) >= MaxLights {
+		return false
+	}
+	l.lights = append(l.lights, light)
+	return true
+}
+
+// Apply multiplies the light buffer over screen
+// It returns the number of draw calls made
+func (l *Lighting) Apply(screen *ebiten.Image) int {
+	bounds := screen.Bounds()
+	if l.buffer == nil || l.buffer.Bounds().Size() != bounds.Size() {
+		if l.buffer != nil {
+			l.buffer.Deallocate()
+		}
+		l.buffer = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	if l.falloff == nil {
+		l.falloff = newLightFalloff()
+	}
+
+	ambient := uint8(math.Round(255 * LightingAmbient))
+	l.buffer.Fill(color.RGBA{ambient, ambient, ambient, 255})
+	for _, light := range l.lights {
+		scale := light.Radius * 2 / lightFalloffSize
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(light.X-light.Radius, light.Y-light.Radius)
+		op.ColorScale.Scale(
+			float32(light.Color.R)/255*float32(light.Intensity),
+			float32(light.Color.G)/255*float32(light.Intensity),
+			float32(light.Color.B)/255*float32(light.Intensity),
+			float32(light.Intensity),
+		)
+		op.Blend = ebiten.BlendLighter
+		op.Filter = ebiten.FilterLinear
+		l.buffer.DrawImage(l.falloff, op)
+	}
+
+	screen.DrawImage(l.buffer, &ebiten.DrawImageOptions{Blend: blendMultiply})
+	return len(l.lights) + 2
+}
+
+// newLightFalloff draws the radial gradient lights are made of: white in the middle, fading out quadratically
+func newLightFalloff() *ebiten.Image {
+	pixels := make([]byte, lightFalloffSize*lightFalloffSize*4)
+	center := float64(lightFalloffSize) / 2
+	for y := 0; y < lightFalloffSize; y++ {
+		for x := 0; x < lightFalloffSize; x++ {
+			distance := math.Hypot(float64(x)+0.5-center, float64(y)+0.5-center) / center
+			falloff := math.Max(1-distance, 0)
+			value := byte(255 * falloff * falloff)
+			i := (y*lightFalloffSize + x) * 4
+			pixels[i], pixels[i+1], pixels[i+2], pixels[i+3] = value, value, value, value // Premultiplied white
+		}
+	}
+	image := ebiten.NewImage(lightFalloffSize, lightFalloffSize)
+	image.WritePixels(pixels)
+	return image
+}
+
+// gatherLights queues this frame's lights: explosions and blasts, then thrusting engines, then projectiles
+func (r *Renderer) gatherLights(world *World, visibleCells []*Cell) {
+	lighting := &r.lighting
+	lighting.Reset()
+	zoom := r.camera.Zoom
+
+	for _, entity := range world.FX {
+		if !entity.Active || entity.Lifetime <= 0 {
+			continue
+		}
+		fade := math.Max(1-entity.Age/entity.Lifetime, 0)
+		light := Light{Radius: ExplosionLightRadius * zoom, Intensity: fade}
+		switch entity.Indicator.Kind {
+		case IndicatorKindTimeout:
+			light.Color = GetFactionConfig(entity.Faction).Color
+		case IndicatorKindKill:
+			light.Color = color.RGBA{255, 230, 120, 255}
+		case IndicatorKindEMP:
+			light.Color = color.RGBA{120, 220, 255, 255}
+			light.Radius = entity.Radius * zoom
+		case IndicatorKindArc:
+			light.Color = arcColor
+			light.Radius = ExplosionLightRadius * 0.5 * zoom
+		case IndicatorKindImpactSparks:
+			light.Color = color.RGBA{255, 220, 140, 255}
+			light.Radius = ExplosionLightRadius * 0.3 * zoom
+		default:
+			continue
+		}
+		light.X, light.Y = r.camera.WorldToScreen(entity.X, entity.Y)
+		if r.lightVisible(light) && !lighting.Add(light) {
+			return
+		}
+	}
+
+	// Engines, then projectiles: two passes over the visible cells so bullets can't crowd out ships
+	for pass := 0; pass < 2; pass++ {
+		for _, cell := range visibleCells {
+			for i := 0; i < cell.Count; i++ {
+				entity := cell.Entities[i]
+				if !entity.Active || entity.Health <= 0 {
+					continue
+				}
+				var light Light
+				switch {
+				case pass == 0 && (entity.Type == EntityTypePlayer || entity.Type == EntityTypeEnemy):
+					if entity.Input == nil || entity.Input.GetThrust() < 0.1 || (entity.Cloak.Capable && entity.Cloak.Visibility < 1) {
+						continue
+					}
+					// Behind the ship, where the engine is
+					backX := entity.X - math.Cos(entity.Rotation)*entity.Radius
+					backY := entity.Y - math.Sin(entity.Rotation)*entity.Radius
+					light = Light{Radius: EngineLightRadius * zoom, Color: engineLightColor, Intensity: 0.6}
+					light.X, light.Y = r.camera.WorldToScreen(backX, backY)
+				case pass == 1 && entity.Type == EntityTypeProjectile:
+					light = Light{Radius: ProjectileLightRadius * zoom, Color: GetFactionConfig(entity.Faction).Color, Intensity: 0.5}
+					light.X, light.Y = r.camera.WorldToScreen(entity.X, entity.Y)
+				default:
+					continue
+				}
+				if r.lightVisible(light) && !lighting.Add(light) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// lightVisible returns true if any part of the light reaches the screen
+func (r *Renderer) lightVisible(light Light) bool {
+	return light.Intensity > 0 && light.X > -light.Radius && light.X < r.camera.Width+light.Radius &&
+		light.Y > -light.Radius && light.Y < r.camera.Height+light.Radius
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DeathCamDuration is how long the camera lingers on the wreck before the player respawns (seconds)
+	DeathCamDuration = 2.5
+
+	// DeathCamZoom is the zoom the death cam eases in to
+	DeathCamZoom = 1.6
+
+	// deathCamStiffness is how quickly the death cam pans and zooms onto the wreck (per second)
+	deathCamStiffness = 2.0
+)
+
+// Lives counts the player's remaining ships when Config.Lives is set
+// Losing a ship with lives left plays a short death cam on the wreck and respawns the player in place; losing the
+// last one shows the continue screen: continue with a full set of lives and the score reset, restart from the last
+// checkpoint, or start a new run
+type Lives struct {
+	Left       int  // Ships left, counting the one flying
+	OutOfLives bool // The continue screen is up
+	Continues  int  // Continues used this run
+
+	// Death cam time left (0 = not playing) and the wreck it looks at
+	DeathCam       float64
+	wreckX, wreckY float64
+
+	count int // Lives at the start of a run
+}
+
+// NewLives creates a full set of lives
+func NewLives(count int) *Lives {
+	return &Lives{Left: count, count: count}
+}
+
+// Reset refills the lives (a new run)
+func (l *Lives) Reset() {
+	*l = Lives{Left: l.count, count: l.count}
+}
+
+// updateLives starts the death cam or the continue screen when the player dies, and respawns them when the
+// death cam ends
+func (g *Game) updateLives(deltaTime float64) {
+	lives := g.lives
+	if lives == nil || g.player == nil {
+		return
+	}
+
+	dead := !g.player.Active || g.player.Health <= 0
+	if dead && lives.DeathCam == 0 && !lives.OutOfLives {
+		if lives.Left--; lives.Left <= 0 {
+			lives.Left = 0
+			lives.OutOfLives = true
+			fmt.Printf("Out of lives on wave %d\n", g.waveNumber)
+			return
+		}
+		lives.DeathCam = DeathCamDuration
+		lives.wreckX, lives.wreckY = g.player.X, g.player.Y
+	}
+	if lives.DeathCam == 0 {
+		return
+	}
+
+	// Pan and zoom in on the wreck, then bring the player back
+	blend := 1 - math.Exp(-deathCamStiffness*deltaTime)
+	g.camera.X += (lives.wreckX - g.camera.X) * blend
+	g.camera.Y += (lives.wreckY - g.camera.Y) * blend
+	g.camera.Zoom += (DeathCamZoom - g.camera.Zoom) * blend
+	if lives.DeathCam = math.Max(lives.DeathCam-deltaTime, 0); lives.DeathCam == 0 {
+		g.camera.Zoom = 1
+		g.respawnInPlace(0)
+	}
+}
+
+// continueRun spends a continue on the continue screen: a full set of lives and a new ship in place, with the score
+// starting over (the wave and the world carry on)
+func (g *Game) continueRun() {
+	lives := g.lives
+	lives.Continues++
+	lives.Left = lives.count
+	lives.OutOfLives = false
+	g.score = 0
+	g.recap.Score = [scoreSourceCount]int{}
+	g.respawnInPlace(0)
+	fmt.Printf("Continue %d on wave %d\n", lives.Continues, g.waveNumber)
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// MiniBossFleeHealth is the share of max health below which a mini-boss disengages
+	MiniBossFleeHealth = 0.3
+
+	// MiniBossReengageHealth is the share of max health at which a fleeing mini-boss turns back
+	MiniBossReengageHealth = 0.8
+
+	// MiniBossRegenPerSecond is the share of max health a fleeing mini-boss repairs per second
+	MiniBossRegenPerSecond = 0.05
+
+	// MiniBossCorneredDistance: a fleeing mini-boss turns to fight when its pursuer gets this close (pixels)
+	MiniBossCorneredDistance = 120.0
+
+	// MiniBossWallMargin: a fleeing mini-boss this close to the world edge has nowhere left to run (pixels)
+	MiniBossWallMargin = 150.0
+
+	// MiniBossReinforcements is the number of ships a mini-boss calls in each time it flees
+	MiniBossReinforcements = 4
+
+	// MiniBossFleeLead is how far ahead of itself a fleeing mini-boss steers (pixels)
+	MiniBossFleeLead = 200.0
+)
+
+// fleePoint returns a point straight away from the pursuer, for a fleeing ship to steer toward
+func fleePoint(entity *Entity, pursuer *Entity) (float64, float64) {
+	dx := entity.X - pursuer.X
+	dy := entity.Y - pursuer.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance < 1 {
+		// On top of the pursuer: keep going the way the ship is facing
+		return entity.X + math.Cos(entity.Rotation)*MiniBossFleeLead, entity.Y + math.Sin(entity.Rotation)*MiniBossFleeLead
+	}
+	return entity.X + dx/distance*MiniBossFleeLead, entity.Y + dy/distance*MiniBossFleeLead
+}
+
+// updateMiniBoss switches a mini-boss between fighting and fleeing, and heals it while it flees
+// It disengages at MiniBossFleeHealth, calling in reinforcements, and re-engages once healed
+// or when it's cornered (its pursuer caught up, or it reached the edge of the world)
+func (g *Game) updateMiniBoss(entity *Entity, aiInput *AIInput, deltaTime float64) {
+	if entity.Health <= 0 {
+		return
+	}
+
+	if !aiInput.fleeing {
+		if aiInput.cornered && entity.Health >= entity.MaxHealth*MiniBossReengageHealth {
+			aiInput.cornered = false // Healed up some other way (elite regeneration)
+		}
+		if entity.Health < entity.MaxHealth*MiniBossFleeHealth && !aiInput.cornered {
+			aiInput.fleeing = true
+			g.spawnReinforcements(entity)
+			g.hitStop.Trigger(HitStopBossPhase)
+			g.radioFrom(RadioCueBossFlees, entity)
+		}
+		return
+	}
+
+	entity.Health = math.Min(entity.Health+entity.MaxHealth*MiniBossRegenPerSecond*deltaTime, entity.MaxHealth)
+	if entity.Health >= entity.MaxHealth*MiniBossReengageHealth {
+		aiInput.fleeing = false
+		g.hitStop.Trigger(HitStopBossPhase)
+		g.radioFrom(RadioCueBossReengages, entity)
+	} else if g.miniBossCornered(entity, aiInput) {
+		aiInput.fleeing = false
+		aiInput.cornered = true
+		g.hitStop.Trigger(HitStopBossPhase)
+		g.radioFrom(RadioCueBossReengages, entity)
+	}
+}
+
+// miniBossCornered returns true if a fleeing mini-boss can't get away from its pursuer
+func (g *Game) miniBossCornered(entity *Entity, aiInput *AIInput) bool {
+	// Only ships count: shooters also target incoming missiles, which always catch up
+	if pursuer := aiInput.TargetEntity; pursuer != nil && pursuer.Active &&
+		(pursuer.Type == EntityTypePlayer || pursuer.Type == EntityTypeEnemy) &&
+		entity.DistanceTo(pursuer) < MiniBossCorneredDistance+entity.Radius+pursuer.Radius {
+		return true
+	}
+	return entity.X < g.config.WorldMinX+MiniBossWallMargin ||
+		entity.X > g.config.WorldMinX+g.config.WorldWidth-MiniBossWallMargin ||
+		entity.Y < g.config.WorldMinY+MiniBossWallMargin ||
+		entity.Y > g.config.WorldMinY+g.config.WorldHeight-MiniBossWallMargin
+}
+
+// spawnReinforcements brings in a handful of rockets and shooters around a fleeing mini-boss
+// to cover its escape; they don't count toward the current wave
+func (g *Game) spawnReinforcements(boss *Entity) {
+	fmt.Printf("Mini-boss called reinforcements\n")
+	for i := 0; i < MiniBossReinforcements; i++ {
+		enemyType, _ := pickWeightedEnemyType([]EnemyType{EnemyTypeRocket, EnemyTypeShooter})
+		angle := runRNG.Float64() * 2 * math.Pi
+		distance := 150.0 + runRNG.Float64()*100.0
+		x := math.Max(g.config.WorldMinX, math.Min(boss.X+math.Cos(angle)*distance, g.config.WorldMinX+g.config.WorldWidth))
+		y := math.Max(g.config.WorldMinY, math.Min(boss.Y+math.Sin(angle)*distance, g.config.WorldMinY+g.config.WorldHeight))
+
+		aiInput := CreateEnemyAIWithType(enemyType)
+		ship := NewEntityWithShipType(x, y, EntityTypeEnemy, GetEnemyTypeConfig(enemyType).ShipType, aiInput)
+		ship.Faction = boss.Faction
+		g.world.RegisterEntity(ship)
+	}
+}
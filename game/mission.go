@@ -0,0 +1,206 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// MissionAttackerBias is the share of wave enemies spawned around the objective instead of the player
+	MissionAttackerBias = 0.6
+
+	// MissionReward is the score dropped for the player when a mission succeeds
+	MissionReward = 500
+
+	// ConvoyRouteLength is the distance the convoy travels to its destination (pixels)
+	ConvoyRouteLength = 6000.0
+
+	// ConvoyArrivalDistance is how close to the destination the convoy must get (pixels)
+	ConvoyArrivalDistance = 200.0
+
+	// DefendWaves is the number of waves the defended station must survive
+	DefendWaves = 5
+
+	// DefendStationHealth is the hull of the defended station (sturdier than a derelict)
+	DefendStationHealth = 1500.0
+)
+
+// MissionType selects the objective of a run
+type MissionType int
+
+const (
+	// MissionNone is plain wave survival
+	MissionNone MissionType = iota
+
+	// MissionEscort: a freighter flies to a destination and must arrive intact
+	MissionEscort
+
+	// MissionDefend: a station must survive DefendWaves waves
+	MissionDefend
+)
+
+// ParseMissionType parses a mission name ("none", "escort" or "defend")
+func ParseMissionType(name string) (MissionType, error) {
+	switch name {
+	case "none":
+		return MissionNone, nil
+	case "escort":
+		return MissionEscort, nil
+	case "defend":
+		return MissionDefend, nil
+	default:
+		return MissionNone, fmt.Errorf("unknown mission %q", name)
+	}
+}
+
+// MissionState tracks how a mission is going
+type MissionState int
+
+const (
+	MissionActive MissionState = iota
+	MissionComplete
+	MissionFailed
+)
+
+// Mission is the objective of the current run
+type Mission struct {
+	Type      MissionType
+	State     MissionState
+	Objective *Entity // Convoy or station (stays set after the mission ends)
+
+	// Escort: where the convoy is headed
+	DestX, DestY float64
+
+	// Defend: the wave the defense started on
+	StartWave int
+}
+
+// ConvoyInput steers an escorted freighter; the mission sets its controls every tick
+type ConvoyInput struct {
+	Thrust   float64
+	Rotation float64
+}
+
+// GetThrust returns the convoy's thrust
+func (c *ConvoyInput) GetThrust() float64 {
+	return c.Thrust
+}
+
+// GetRotation returns the convoy's rotation input
+func (c *ConvoyInput) GetRotation() float64 {
+	return c.Rotation
+}
+
+// ShouldShoot returns false: convoys are unarmed
+func (c *ConvoyInput) ShouldShoot() bool {
+	return false
+}
+
+// HasTarget returns false: convoys don't target anything
+func (c *ConvoyInput) HasTarget() bool {
+	return false
+}
+
+// Update does nothing: the mission steers the convoy
+func (c *ConvoyInput) Update(deltaTime float64) {
+}
+
+// startMission places the objective of the configured mission near the player
+func (g *Game) startMission() {
+	g.mission = nil
+	if g.config.Mission == MissionNone || g.player == nil {
+		return
+	}
+
+	angle := runRNG.Float64() * 2 * math.Pi
+	mission := &Mission{Type: g.config.Mission}
+	switch g.config.Mission {
+	case MissionEscort:
+		convoy := NewEntityWithShipType(
+			g.player.X+math.Cos(angle)*150,
+			g.player.Y+math.Sin(angle)*150,
+			EntityTypeEnemy, ShipTypeFreighter, &ConvoyInput{},
+		)
+		convoy.Faction = FactionPlayer
+		routeAngle := runRNG.Float64() * 2 * math.Pi
+		convoy.Rotation = routeAngle
+		mission.DestX = convoy.X + math.Cos(routeAngle)*ConvoyRouteLength
+		mission.DestY = convoy.Y + math.Sin(routeAngle)*ConvoyRouteLength
+		mission.Objective = convoy
+	case MissionDefend:
+		station := NewStation(g.player.X+math.Cos(angle)*300, g.player.Y+math.Sin(angle)*300, 0)
+		station.Faction = FactionPlayer
+		station.MaxHealth = DefendStationHealth
+		station.Health = DefendStationHealth
+		station.AngularVelocity = 0
+		mission.StartWave = g.waveNumber
+		mission.Objective = station
+	}
+	g.world.RegisterEntity(mission.Objective)
+	g.mission = mission
+	g.radioCommand(RadioCueMissionStart)
+}
+
+// updateMission steers the convoy and checks the mission's success and failure conditions
+func (g *Game) updateMission() {
+	mission := g.mission
+	if mission == nil || mission.State != MissionActive {
+		return
+	}
+	objective := mission.Objective
+	if !objective.Active || objective.Health <= 0 {
+		mission.State = MissionFailed
+		fmt.Printf("Mission failed: objective destroyed\n")
+		g.radioCommand(RadioCueMissionFailed)
+		return
+	}
+
+	switch mission.Type {
+	case MissionEscort:
+		dx := mission.DestX - objective.X
+		dy := mission.DestY - objective.Y
+		if dx*dx+dy*dy <= ConvoyArrivalDistance*ConvoyArrivalDistance {
+			// The convoy jumps out at its destination
+			g.completeMission()
+			objective.Active = false
+			g.world.UnregisterEntity(objective)
+			return
+		}
+
+		// Turn toward the destination, thrusting only when roughly facing it
+		input := objective.Input.(*ConvoyInput)
+		angleDiff := math.Remainder(math.Atan2(dy, dx)-objective.Rotation, 2*math.Pi)
+		input.Rotation = math.Max(-1, math.Min(1, angleDiff/(math.Pi/4)))
+		input.Thrust = 0
+		if math.Abs(angleDiff) < math.Pi/4 {
+			input.Thrust = 1
+		}
+	case MissionDefend:
+		if g.waveNumber >= mission.StartWave+DefendWaves {
+			g.completeMission()
+		}
+	}
+}
+
+// completeMission marks the mission won and rewards the player
+func (g *Game) completeMission() {
+	g.mission.State = MissionComplete
+	fmt.Printf("Mission complete\n")
+	g.radioCommand(RadioCueMissionComplete)
+	if g.player != nil && g.player.Active {
+		g.spawnPickup(g.player.X, g.player.Y, MissionReward, g.player, ScoreMission)
+	}
+}
+
+// spawnAnchor returns the entity new wave enemies gather around: usually the player,
+// but often the objective of an active mission (nil if there is neither)
+func (g *Game) spawnAnchor() *Entity {
+	if mission := g.mission; mission != nil && mission.State == MissionActive &&
+		mission.Objective.Active && runRNG.Float64() < MissionAttackerBias {
+		return mission.Objective
+	}
+	if g.player != nil && g.player.Active {
+		return g.player
+	}
+	return nil
+}
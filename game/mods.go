@@ -0,0 +1,307 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// modManifestFile marks a directory as a mod and describes it
+	modManifestFile = "mod.json"
+
+	// modWeaponsFile holds new or replacement weapon definitions
+	modWeaponsFile = "weapons.json"
+
+	// modShipsFile holds stat patches for existing ship types
+	modShipsFile = "ships.json"
+)
+
+// ModManifest describes a mod (mod.json at the root of the mod directory)
+type ModManifest struct {
+	ID          string // Unique identifier (defaults to the directory name)
+	Name        string // Display name (defaults to the ID)
+	Version     string
+	Description string
+	LoadOrder   int // Mods load in ascending order; later mods override earlier ones
+}
+
+// Mod is a discovered mod directory
+// Supported data files: weapons.json ([]ModWeapon) and ships.json ([]ModShipPatch)
+type Mod struct {
+	Manifest ModManifest
+	Dir      string
+	Enabled  bool  // Not disabled in settings
+	Loaded   bool  // Data was applied successfully
+	Err      error // Why the mod failed to load (it is skipped; the base game keeps running)
+}
+
+// ModWeapon is a weapon definition from a mod's weapons.json
+// Unset stats are inherited from the base weapon
+type ModWeapon struct {
+	Name string // Weapon name; replaces a registered weapon with the same name
+	Base string // Built-in weapon whose behavior and defaults are reused (e.g. "Bullet", "Homing Missile")
+
+	Damage          *float64
+	ProjectileSpeed *float64
+	Cooldown        *float64
+	Radius          *float64
+	InitialVelocity *float64
+	Lifetime        *float64
+	MaxRange        *float64
+	BurnDPS         *float64
+	BurnDuration    *float64
+	SlowDuration    *float64
+}
+
+// ModShipPatch is a stat override for an existing ship type from a mod's ships.json
+// Unset fields keep their current values
+type ModShipPatch struct {
+	Ship string // Ship type name (e.g. "Shooter")
+
+	Health        *float64
+	Speed         *float64
+	Acceleration  *float64
+	Radius        *float64
+	ShootCooldown *float64
+	Score         *int
+
+	StaggeredTurrets *bool // Fire turrets sharing a weapon in turns instead of volleys
+
+	TurretWeapons []string // Weapon names for the ship's turret mounts in mount order (optional)
+}
+
+// DefaultModsDir returns the default directory scanned for mods
+func DefaultModsDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(configDir, settingsDirName, "mods"), nil
+}
+
+// DiscoverMods lists the mod directories under dir sorted by load order
+// A missing mods directory is not an error
+func DiscoverMods(dir string) ([]*Mod, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mods directory: %w", err)
+	}
+
+	var mods []*Mod
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		modDir := filepath.Join(dir, entry.Name())
+
+		mod := &Mod{Dir: modDir}
+		found, err := readModJSON(modDir, modManifestFile, &mod.Manifest)
+		if !found {
+			continue // Not a mod directory
+		}
+		if mod.Manifest.ID == "" {
+			mod.Manifest.ID = entry.Name()
+		}
+		if mod.Manifest.Name == "" {
+			mod.Manifest.Name = mod.Manifest.ID
+		}
+		mod.Err = err
+		mods = append(mods, mod)
+	}
+
+	// Sort by load order, then ID so the order is stable across platforms
+	sort.SliceStable(mods, func(i, j int) bool {
+		if mods[i].Manifest.LoadOrder != mods[j].Manifest.LoadOrder {
+			return mods[i].Manifest.LoadOrder < mods[j].Manifest.LoadOrder
+		}
+		return mods[i].Manifest.ID < mods[j].Manifest.ID
+	})
+	return mods, nil
+}
+
+// LoadMods discovers the mods under dir and applies every enabled one
+// A broken mod is reported in its Err field and skipped; it never stops the others from loading
+func LoadMods(dir string) ([]*Mod, error) {
+	mods, err := DiscoverMods(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := GetSettings()
+	for _, mod := range mods {
+		mod.Enabled = !settings.IsModDisabled(mod.Manifest.ID)
+		if !mod.Enabled || mod.Err != nil {
+			continue
+		}
+		if err := applyMod(mod); err != nil {
+			mod.Err = err
+			fmt.Printf("Skipping mod %s: %v\n", mod.Manifest.ID, err)
+			continue
+		}
+		mod.Loaded = true
+		fmt.Printf("Loaded mod %s %s\n", mod.Manifest.Name, mod.Manifest.Version)
+	}
+	return mods, nil
+}
+
+// applyMod parses and validates all of a mod's data before registering anything,
+// so a half-broken mod never leaves partial changes behind; panics are turned into errors
+func applyMod(mod *Mod) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("mod panicked: %v", r)
+		}
+	}()
+
+	var weapons []ModWeapon
+	if _, err := readModJSON(mod.Dir, modWeaponsFile, &weapons); err != nil {
+		return err
+	}
+	var ships []ModShipPatch
+	if _, err := readModJSON(mod.Dir, modShipsFile, &ships); err != nil {
+		return err
+	}
+
+	// Validate weapons and build their configs
+	weaponConfigs := make([]WeaponConfig, 0, len(weapons))
+	modWeaponNames := make(map[string]bool, len(weapons))
+	for _, weapon := range weapons {
+		config, err := weapon.toWeaponConfig()
+		if err != nil {
+			return fmt.Errorf("%s: %w", modWeaponsFile, err)
+		}
+		if modWeaponNames[weapon.Name] {
+			return fmt.Errorf("%s: weapon %q is defined twice", modWeaponsFile, weapon.Name)
+		}
+		weaponConfigs = append(weaponConfigs, config)
+		modWeaponNames[weapon.Name] = true
+	}
+
+	// Validate ship patches (turret weapons may refer to weapons from this mod)
+	shipTypes := make([]ShipType, len(ships))
+	for i, patch := range ships {
+		shipType, ok := GetShipTypeByName(patch.Ship)
+		if !ok {
+			return fmt.Errorf("%s: unknown ship %q", modShipsFile, patch.Ship)
+		}
+		for _, name := range patch.TurretWeapons {
+			if _, ok := GetWeaponTypeByName(name); !ok && !modWeaponNames[name] {
+				return fmt.Errorf("%s: ship %q uses unknown weapon %q", modShipsFile, patch.Ship, name)
+			}
+		}
+		shipTypes[i] = shipType
+	}
+
+	// Everything checked out, register it
+	for _, config := range weaponConfigs {
+		id, ok := GetWeaponTypeByName(config.Name)
+		if !ok {
+			id = NewWeaponType()
+		}
+		RegisterWeapon(id, config)
+	}
+	// Turret weapon names resolve once here, now that this mod's weapons are registered
+	for i, patch := range ships {
+		turretWeapons := make([]WeaponType, len(patch.TurretWeapons))
+		for j, name := range patch.TurretWeapons {
+			turretWeapons[j], _ = GetWeaponTypeByName(name)
+		}
+		PatchShipType(shipTypes[i], patch.apply(turretWeapons))
+	}
+	return nil
+}
+
+// toWeaponConfig validates the definition and builds a config from its base weapon
+func (w ModWeapon) toWeaponConfig() (WeaponConfig, error) {
+	if w.Name == "" {
+		return WeaponConfig{}, errors.New("weapon without a name")
+	}
+	baseType, ok := GetWeaponTypeByName(w.Base)
+	if !ok {
+		return WeaponConfig{}, fmt.Errorf("weapon %q has unknown base %q", w.Name, w.Base)
+	}
+
+	config := GetWeaponConfig(baseType)
+	config.Name = w.Name
+	for _, stat := range []struct {
+		value *float64
+		field *float64
+	}{
+		{w.Damage, &config.Damage},
+		{w.ProjectileSpeed, &config.ProjectileSpeed},
+		{w.Cooldown, &config.Cooldown},
+		{w.Radius, &config.Radius},
+		{w.InitialVelocity, &config.InitialVelocity},
+		{w.Lifetime, &config.Lifetime},
+		{w.MaxRange, &config.MaxRange},
+		{w.BurnDPS, &config.BurnDPS},
+		{w.BurnDuration, &config.BurnDuration},
+		{w.SlowDuration, &config.SlowDuration},
+	} {
+		if stat.value == nil {
+			continue
+		}
+		if *stat.value < 0 {
+			return WeaponConfig{}, fmt.Errorf("weapon %q has a negative stat", w.Name)
+		}
+		*stat.field = *stat.value
+	}
+	return config, nil
+}
+
+// apply returns a ship config patch function for PatchShipType
+// turretWeapons holds the resolved TurretWeapons, in mount order
+func (p ModShipPatch) apply(turretWeapons []WeaponType) func(*ShipTypeConfig) {
+	return func(config *ShipTypeConfig) {
+		if p.Health != nil {
+			config.Health = *p.Health
+		}
+		if p.Speed != nil {
+			config.Speed = *p.Speed
+		}
+		if p.Acceleration != nil {
+			config.Acceleration = *p.Acceleration
+		}
+		if p.Radius != nil {
+			config.Radius = *p.Radius
+		}
+		if p.ShootCooldown != nil {
+			config.ShootCooldown = *p.ShootCooldown
+		}
+		if p.Score != nil {
+			config.Score = *p.Score
+		}
+		if p.StaggeredTurrets != nil {
+			config.StaggeredTurrets = *p.StaggeredTurrets
+		}
+		for i, weaponType := range turretWeapons {
+			if i >= len(config.TurretMounts) {
+				break
+			}
+			config.TurretMounts[i].WeaponType = weaponType
+		}
+	}
+}
+
+// readModJSON decodes a mod data file into v, reporting whether the file exists
+func readModJSON(dir, name string, v any) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// PhotoModePanSpeed is the free camera pan speed in screen pixels per second
+	PhotoModePanSpeed = 600.0
+
+	// PhotoModeZoomSpeed is the zoom rate multiplier per second
+	PhotoModeZoomSpeed = 1.5
+
+	// PhotoModeMinZoom and PhotoModeMaxZoom bound the free camera zoom
+	PhotoModeMinZoom = 0.1
+	PhotoModeMaxZoom = 4.0
+
+	// photoModeGlowAlpha is how strongly the glow pass is added on top of the scene
+	photoModeGlowAlpha = 0.35
+)
+
+// PhotoMode holds state for the paused free-camera photo mode
+// Controls: P toggles, WASD/arrows pan, Q/E zoom, H hides UI, G toggles glow, F12 saves a screenshot
+type PhotoMode struct {
+	Active bool // Simulation is paused and the camera is free
+	HideUI bool // Hide score, FPS and warnings
+	Glow   bool // Apply the additive glow/contrast pass
+
+	// Screenshot requested this frame (saved at the end of Draw)
+	screenshotRequested bool
+
+	// Offscreen copy of the frame used by the glow pass
+	glowBuffer *ebiten.Image
+}
+
+// NewPhotoMode creates an inactive photo mode
+func NewPhotoMode() *PhotoMode {
+	return &PhotoMode{}
+}
+
+// Toggle enters or leaves photo mode
+func (p *PhotoMode) Toggle(camera *Camera) {
+	p.Active = !p.Active
+	if !p.Active {
+		// Restore the gameplay camera zoom; the follow logic re-centers on the player
+		camera.Zoom = 1.0
+		p.HideUI = false
+	}
+}
+
+// Update handles free camera and photo mode hotkeys (only while active)
+func (p *PhotoMode) Update(camera *Camera, deltaTime float64) {
+	// Pan in screen space so speed feels the same at every zoom level
+	pan := PhotoModePanSpeed * deltaTime / camera.Zoom
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
+		camera.X -= pan
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
+		camera.X += pan
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
+		camera.Y -= pan
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
+		camera.Y += pan
+	}
+
+	// Zoom (E in, Q out)
+	if ebiten.IsKeyPressed(ebiten.KeyE) {
+		camera.Zoom *= 1 + PhotoModeZoomSpeed*deltaTime
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyQ) {
+		camera.Zoom /= 1 + PhotoModeZoomSpeed*deltaTime
+	}
+	camera.Zoom = math.Max(PhotoModeMinZoom, math.Min(camera.Zoom, PhotoModeMaxZoom))
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		p.HideUI = !p.HideUI
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		p.Glow = !p.Glow
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF12) {
+		p.screenshotRequested = true
+	}
+}
+
+// ApplyGlow adds a brightened copy of the frame on top of itself for a cheap bloom/contrast look
+func (p *PhotoMode) ApplyGlow(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	if p.glowBuffer == nil || p.glowBuffer.Bounds() != bounds {
+		p.glowBuffer = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+
+	// Ebiten can't draw an image onto itself, so copy the frame first
+	p.glowBuffer.Clear()
+	p.glowBuffer.DrawImage(screen, nil)
+
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(photoModeGlowAlpha)
+	op.Blend = ebiten.BlendLighter
+	screen.DrawImage(p.glowBuffer, op)
+}
+
+// SaveScreenshotIfRequested writes the frame to the user's pictures directory as a timestamped PNG
+// Encoding happens on a goroutine so the frame isn't held up by disk I/O
+func (p *PhotoMode) SaveScreenshotIfRequested(screen *ebiten.Image) {
+	if !p.screenshotRequested {
+		return
+	}
+	p.screenshotRequested = false
+
+	bounds := screen.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	screen.ReadPixels(img.Pix)
+
+	go func() {
+		path, err := saveScreenshot(img)
+		if err != nil {
+			fmt.Printf("Failed to save screenshot: %v\n", err)
+			return
+		}
+		fmt.Printf("Screenshot saved to: %s\n", path)
+	}()
+}
+
+// saveScreenshot encodes img as PNG into the pictures directory and returns the file path
+func saveScreenshot(img image.Image) (string, error) {
+	dir, err := picturesDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create screenshot directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("20060102-150405")
+	path := filepath.Join(dir, fmt.Sprintf("space-shooter-%s.png", timestamp))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create screenshot file: %w", err)
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, img); err != nil {
+		return "", fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return path, nil
+}
+
+// picturesDir returns the user's pictures directory (XDG_PICTURES_DIR, or ~/Pictures)
+func picturesDir() (string, error) {
+	if dir := os.Getenv("XDG_PICTURES_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, "Pictures"), nil
+}
@@ -0,0 +1,15 @@
+package game
+
+import "math"
+
+// FrictionReferenceTPS is the tick rate friction factors are tuned for: a factor of 0.99 removes 1% of velocity per 1/60 s
+const FrictionReferenceTPS = 60.0
+
+// StepFriction converts a per-reference-tick friction factor to the factor for a step of deltaTime seconds
+// so damping is the same per second at any simulation tick rate
+func StepFriction(friction, deltaTime float64) float64 {
+	if friction == 1 {
+		return 1
+	}
+	return math.Pow(friction, deltaTime*FrictionReferenceTPS)
+}
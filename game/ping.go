@@ -0,0 +1,101 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// PingLifetime is how long a ping stays in the world (seconds)
+	PingLifetime = 8.0
+
+	// PingRadius is the size of a ping marker (pixels)
+	PingRadius = 30.0
+
+	// PingCooldown is the minimum time between two pings from the local player (seconds)
+	PingCooldown = 0.5
+
+	// PingResponseRange is how far from a ping friendly AI ships answer it (pixels)
+	PingResponseRange = 2500.0
+
+	// PingEngageRadius is how far around an answered ping wingmen look for targets (pixels)
+	PingEngageRadius = 600.0
+)
+
+// isPingKind returns true for indicator kinds that are team pings rather than effects
+func isPingKind(kind IndicatorKind) bool {
+	return kind == IndicatorKindPingAttack || kind == IndicatorKindPingHelp || kind == IndicatorKindPingLoot
+}
+
+// PlacePing marks a spot for a faction's players
+// Pings are FX entities, so snapshots replicate them to every client like any other indicator;
+// a host applies a remote player's ping by calling this with that player's faction
+func (w *World) PlacePing(kind IndicatorKind, x, y float64, faction Faction) *Entity {
+	ping := NewEntity(x, y, PingRadius, EntityTypeDestroyedIndicator, nil)
+	ping.Indicator.Kind = kind
+	ping.Faction = faction
+	ping.Active = true
+	ping.Health = 1.0 // Small health value so it renders
+	ping.MaxHealth = 1.0
+	ping.Lifetime = PingLifetime
+	ping.NoCollision = true
+	w.RegisterEntity(ping)
+	return ping
+}
+
+// updatePings places a ping under the cursor: Z for attack, X for help, C for loot
+func (g *Game) updatePings(deltaTime float64) {
+	g.pingCooldown = math.Max(g.pingCooldown-deltaTime, 0)
+	if g.player == nil || !g.player.Active || g.pingCooldown > 0 {
+		return
+	}
+
+	var kind IndicatorKind
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyZ):
+		kind = IndicatorKindPingAttack
+	case inpututil.IsKeyJustPressed(ebiten.KeyX):
+		kind = IndicatorKindPingHelp
+	case inpututil.IsKeyJustPressed(ebiten.KeyC):
+		kind = IndicatorKindPingLoot
+	default:
+		return
+	}
+
+	cursorX, cursorY := ebiten.CursorPosition()
+	x, y := g.camera.ScreenToWorld(float64(cursorX), float64(cursorY))
+	g.world.PlacePing(kind, x, y, g.player.Faction)
+	g.pingCooldown = PingCooldown
+}
+
+// nearestPing returns the closest live attack or help ping of the entity's faction in response range (nil if none)
+// Loot pings are for players only
+func nearestPing(world *World, entity *Entity) *Entity {
+	var nearest *Entity
+	nearestDistanceSq := PingResponseRange * PingResponseRange
+	faction := GetEntityFaction(entity)
+	for _, fx := range world.FX {
+		kind := fx.Indicator.Kind
+		if !fx.Active || fx.Faction != faction || (kind != IndicatorKindPingAttack && kind != IndicatorKindPingHelp) {
+			continue
+		}
+		dx := fx.X - entity.X
+		dy := fx.Y - entity.Y
+		if distanceSq := dx*dx + dy*dy; distanceSq < nearestDistanceSq {
+			nearest = fx
+			nearestDistanceSq = distanceSq
+		}
+	}
+	return nearest
+}
+
+// answerPing picks a wingman's target near the closest attack or help ping (nil if there is none to fight)
+func answerPing(entity *Entity, world *World, targetFaction Faction) *Entity {
+	ping := nearestPing(world, entity)
+	if ping == nil {
+		return nil
+	}
+	return findAITargetNear(entity, ping.X, ping.Y, PingEngageRadius, world, targetFaction)
+}
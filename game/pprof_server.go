@@ -0,0 +1,94 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+)
+
+// DefaultPprofAddr is the address the pprof server listens on unless configured otherwise
+const DefaultPprofAddr = "localhost:6060"
+
+// PprofServer serves the net/http/pprof profiling endpoints on demand
+// It has no authentication, so it's off unless started with -pprof or toggled on with F11,
+// and only listens beyond localhost if the address says so
+type PprofServer struct {
+	addr string
+
+	mu     sync.Mutex
+	server *http.Server
+}
+
+// NewPprofServer creates a stopped pprof server for addr (DefaultPprofAddr if empty)
+func NewPprofServer(addr string) *PprofServer {
+	if addr == "" {
+		addr = DefaultPprofAddr
+	}
+	return &PprofServer{addr: addr}
+}
+
+// Running reports whether the server is listening
+func (s *PprofServer) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.server != nil
+}
+
+// Start starts listening (a no-op if already running)
+func (s *PprofServer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return nil
+	}
+
+	// Listen here rather than in the goroutine so a port in use is reported to the caller
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("pprof server: %w", err)
+	}
+
+	// Register the handlers on our own mux, not http.DefaultServeMux, so nothing else exposes them
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	server := &http.Server{Handler: mux}
+	s.server = server
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("pprof server stopped: %v\n", err)
+		}
+	}()
+	fmt.Printf("pprof server on http://%s/debug/pprof/\n", listener.Addr())
+	return nil
+}
+
+// Stop closes the server and any open connections (a no-op if not running)
+func (s *PprofServer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server == nil {
+		return
+	}
+	s.server.Close()
+	s.server = nil
+	fmt.Println("pprof server stopped")
+}
+
+// Toggle starts the server if it's stopped and stops it if it's running
+func (s *PprofServer) Toggle() {
+	if s.Running() {
+		s.Stop()
+		return
+	}
+	if err := s.Start(); err != nil {
+		fmt.Printf("Failed to start %v\n", err)
+	}
+}
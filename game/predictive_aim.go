@@ -17,26 +17,14 @@ type AimResult struct {
 // GetAimPoint calculates the position from which an entity should aim
 // For entities with active turrets, returns turret position; otherwise returns ship center
 func GetAimPoint(entity *Entity) (aimX, aimY float64, hasTurret bool) {
-	shipConfig := GetShipTypeConfig(entity.ShipType)
-
-	// Check for active turret mount
-	var activeMount *TurretMountPoint
-	for i := range shipConfig.TurretMounts {
-		if shipConfig.TurretMounts[i].Active {
-			activeMount = &shipConfig.TurretMounts[i]
-			break
+	// Aim from the first operational turret
+	for i := range entity.Turrets {
+		if entity.Turrets[i].IsOperational() {
+			aimX, aimY = entity.Turrets[i].WorldPosition(entity)
+			return aimX, aimY, true
 		}
 	}
 
-	if activeMount != nil {
-		// Calculate turret position in world space
-		cosRot := math.Cos(entity.Rotation)
-		sinRot := math.Sin(entity.Rotation)
-		mountX := activeMount.OffsetX*cosRot - activeMount.OffsetY*sinRot
-		mountY := activeMount.OffsetX*sinRot + activeMount.OffsetY*cosRot
-		return entity.X + mountX, entity.Y + mountY, true
-	}
-
 	// No turret, use ship center
 	return entity.X, entity.Y, false
 }
@@ -0,0 +1,188 @@
+package game
+
+import "image/color"
+
+const (
+	// RadioLogSize is the number of radio messages listed at once
+	RadioLogSize = 4
+
+	// RadioMessageDuration is how long a radio message stays listed (seconds)
+	RadioMessageDuration = 6.0
+
+	// RadioChatterCooldown is the least time between two chatter messages on the same channel (seconds)
+	RadioChatterCooldown = 8.0
+)
+
+// RadioChannel groups radio messages by who sends them
+type RadioChannel int
+
+const (
+	RadioChannelAlly    RadioChannel = iota // Wingmen and escorts
+	RadioChannelVendor                      // Station vendors
+	RadioChannelBoss                        // Boss taunts
+	RadioChannelCommand                     // Mission control and faction hails
+	radioChannelCount
+)
+
+// RadioCue identifies a moment that triggers a radio message
+type RadioCue int
+
+const (
+	RadioCueAllyKill RadioCue = iota
+	RadioCueAllyDown
+	RadioCueVendorGreeting
+	RadioCueBossArrives
+	RadioCueBossFlees
+	RadioCueBossReengages
+	RadioCueCapitalArrives
+	RadioCueMissionStart
+	RadioCueMissionComplete
+	RadioCueMissionFailed
+	RadioCueFactionHostile
+	RadioCueFactionNeutral
+	RadioCueFactionFriendly
+)
+
+// RadioCueConfig holds the lines a cue picks from
+type RadioCueConfig struct {
+	Channel RadioChannel
+	Chatter bool // Dropped while the channel is cooling down, instead of always getting through
+	Lines   []string
+}
+
+// radioCues are the lines of every cue; each use takes the next line, so they don't draw from runRNG
+var radioCues = map[RadioCue]RadioCueConfig{
+	RadioCueAllyKill: {Channel: RadioChannelAlly, Chatter: true, Lines: []string{
+		"Splash one!", "Got him!", "Target destroyed.", "That one's not getting up.",
+	}},
+	RadioCueAllyDown: {Channel: RadioChannelAlly, Lines: []string{
+		"I'm hit, I'm going down!", "Mayday, mayday!", "Losing power... sorry, boss.",
+	}},
+	RadioCueVendorGreeting: {Channel: RadioChannelVendor, Lines: []string{
+		"Welcome aboard. Credits up front.", "Docking clamps engaged. Take a look around.", "Back again? Good, I've got stock.",
+	}},
+	RadioCueBossArrives: {Channel: RadioChannelBoss, Lines: []string{
+		"So you're the one thinning my ranks.", "This sector belongs to me.", "Let's see how you fly against a real pilot.",
+	}},
+	RadioCueBossFlees: {Channel: RadioChannelBoss, Lines: []string{
+		"You'll pay for that! All ships, cover me!", "Lucky shot. Reinforcements, to me!", "Fall back and regroup!",
+	}},
+	RadioCueBossReengages: {Channel: RadioChannelBoss, Lines: []string{
+		"Round two.", "Nowhere left to run? Then neither do you!", "Repairs done. Where were we?",
+	}},
+	RadioCueCapitalArrives: {Channel: RadioChannelCommand, Lines: []string{
+		"Heavy contact inbound: a capital ship. Knock out its sections or go for the core.",
+	}},
+	RadioCueMissionStart: {Channel: RadioChannelCommand, Lines: []string{
+		"Objective is marked on your compass. Keep it alive.",
+	}},
+	RadioCueMissionComplete: {Channel: RadioChannelCommand, Lines: []string{
+		"Objective secured. Good work out there.",
+	}},
+	RadioCueMissionFailed: {Channel: RadioChannelCommand, Lines: []string{
+		"We've lost the objective. Just stay alive.",
+	}},
+	RadioCueFactionHostile: {Channel: RadioChannelCommand, Lines: []string{
+		"You've made enemies today. All patrols, weapons free on that ship.",
+	}},
+	RadioCueFactionNeutral: {Channel: RadioChannelCommand, Lines: []string{
+		"We're keeping our distance. Don't give us a reason.",
+	}},
+	RadioCueFactionFriendly: {Channel: RadioChannelCommand, Lines: []string{
+		"You've been a friend to us. Our patrols will fly with you.",
+	}},
+}
+
+// RadioMessage is one line of the radio log
+type RadioMessage struct {
+	Speaker  string
+	Text     string
+	Portrait string     // Sprite shown next to the message ("" = no portrait)
+	Color    color.RGBA // Speaker's faction color
+	Age      float64
+}
+
+// Radio lists recent radio messages, newest last
+type Radio struct {
+	Messages []RadioMessage
+
+	// Time until each channel takes chatter again
+	cooldowns [radioChannelCount]float64
+
+	// Uses of each cue so far, selecting its next line
+	uses map[RadioCue]int
+}
+
+// NewRadio creates an empty radio log
+func NewRadio() *Radio {
+	return &Radio{Messages: make([]RadioMessage, 0, RadioLogSize), uses: make(map[RadioCue]int)}
+}
+
+// Update ages messages, drops expired ones and cools the channels down
+func (r *Radio) Update(deltaTime float64) {
+	kept := r.Messages[:0]
+	for _, message := range r.Messages {
+		message.Age += deltaTime
+		if message.Age < RadioMessageDuration {
+			kept = append(kept, message)
+		}
+	}
+	r.Messages = kept
+	for i := range r.cooldowns {
+		r.cooldowns[i] -= deltaTime
+	}
+}
+
+// Reset clears the log and the channel cooldowns
+func (r *Radio) Reset() {
+	r.Messages = r.Messages[:0]
+	r.cooldowns = [radioChannelCount]float64{}
+	clear(r.uses)
+}
+
+// Say adds the next line of cue from speaker, unless it's chatter and its channel is cooling down
+func (r *Radio) Say(cue RadioCue, speaker, portrait string, clr color.RGBA) {
+	config := radioCues[cue]
+	if len(config.Lines) == 0 || (config.Chatter && r.cooldowns[config.Channel] > 0) {
+		return
+	}
+	r.cooldowns[config.Channel] = RadioChatterCooldown
+	line := config.Lines[r.uses[cue]%len(config.Lines)]
+	r.uses[cue]++
+
+	if len(r.Messages) == RadioLogSize {
+		copy(r.Messages, r.Messages[1:])
+		r.Messages = r.Messages[:RadioLogSize-1]
+	}
+	r.Messages = append(r.Messages, RadioMessage{Speaker: speaker, Text: line, Portrait: portrait, Color: clr})
+}
+
+// radioFrom sends cue from a ship, with its name and sprite as the portrait
+func (g *Game) radioFrom(cue RadioCue, ship *Entity) {
+	shipConfig := GetShipTypeConfig(ship.ShipType)
+	g.radio.Say(cue, shipConfig.Name, shipConfig.Sprite, GetFactionConfig(ship.Faction).Color)
+}
+
+// radioCommand sends cue from mission control, without a portrait
+func (g *Game) radioCommand(cue RadioCue) {
+	g.radio.Say(cue, "Command", "", color.RGBA{80, 200, 255, 255})
+}
+
+// onRadioKill has wingmen call their kills and call for help when they go down
+func (g *Game) onRadioKill(event Event) {
+	if isRadioWingman(event.Source, g.player) && event.SourceFaction == FactionPlayer && event.Target.Faction != FactionPlayer {
+		g.radioFrom(RadioCueAllyKill, event.Source)
+	}
+	if isRadioWingman(event.Target, g.player) {
+		g.radioFrom(RadioCueAllyDown, event.Target)
+	}
+}
+
+// isRadioWingman returns true if ship is an AI ship flying for the player's side
+func isRadioWingman(ship, player *Entity) bool {
+	if ship == nil || ship == player || ship.Type != EntityTypeEnemy || ship.Faction != FactionPlayer {
+		return false
+	}
+	_, ok := ship.Input.(*AIInput)
+	return ok
+}
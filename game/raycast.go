@@ -0,0 +1,118 @@
+package game
+
+import "math"
+
+// raycastMargin pads the ray's bounding box when gathering cells
+// Entities are only stored in their center cell, so ones centered just outside the box can still overlap the ray;
+// it has to cover the largest collision radius (stations)
+const raycastMargin = StationRadius + 10.0
+
+// RaycastHit describes the first entity a ray runs into
+type RaycastHit struct {
+	Entity   *Entity
+	X, Y     float64 // Point where the ray enters the entity's collision circle
+	Distance float64 // Distance from the ray start to the hit point
+}
+
+// Raycast casts a segment from (x1, y1) to (x2, y2) and returns the closest active, live entity
+// it touches for which blocks returns true (nil blocks accepts every entity)
+func (w *World) Raycast(x1, y1, x2, y2 float64, blocks func(*Entity) bool) (RaycastHit, bool) {
+	dx := x2 - x1
+	dy := y2 - y1
+	lengthSq := dx*dx + dy*dy
+
+	minCellX, minCellY := w.WorldToCell(math.Min(x1, x2)-raycastMargin, math.Min(y1, y2)-raycastMargin)
+	maxCellX, maxCellY := w.WorldToCell(math.Max(x1, x2)+raycastMargin, math.Max(y1, y2)+raycastMargin)
+
+	var hit RaycastHit
+	found := false
+	bestT := math.Inf(1)
+
+	for cellX := minCellX; cellX <= maxCellX; cellX++ {
+		for cellY := minCellY; cellY <= maxCellY; cellY++ {
+			cell := w.GetCell(cellX, cellY)
+			if cell == nil {
+				continue
+			}
+
+			// Iterate directly over cell entities to avoid allocation
+			for i := 0; i < cell.Count; i++ {
+				entity := cell.Entities[i]
+				if !entity.Active || entity.Health <= 0 {
+					continue
+				}
+
+				t, ok := segmentCircleEntry(x1, y1, dx, dy, lengthSq, entity.X, entity.Y, entity.Radius)
+				if !ok || t >= bestT {
+					continue
+				}
+				if blocks != nil && !blocks(entity) {
+					continue
+				}
+
+				bestT = t
+				hit.Entity = entity
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return RaycastHit{}, false
+	}
+	hit.X = x1 + dx*bestT
+	hit.Y = y1 + dy*bestT
+	hit.Distance = bestT * math.Sqrt(lengthSq)
+	return hit, true
+}
+
+// HasLineOfSight returns true if nothing blocks a shot from shooter to (targetX, targetY)
+// target is ignored (it's what we're shooting at) and may be nil
+func (w *World) HasLineOfSight(shooter, target *Entity, fromX, fromY, targetX, targetY float64) bool {
+	_, blocked := w.Raycast(fromX, fromY, targetX, targetY, func(entity *Entity) bool {
+		return entity != target && BlocksLineOfSight(entity, shooter)
+	})
+	return !blocked
+}
+
+// BlocksLineOfSight returns true if entity should stop shooter's shots and aim lines
+// Allied ships block (we don't shoot through friends), as do asteroids and stations (solid cover);
+// enemy ships are what we're shooting at, and projectiles, XP and indicators never block
+func BlocksLineOfSight(entity, shooter *Entity) bool {
+	if entity == shooter {
+		return false
+	}
+	switch entity.Type {
+	case EntityTypePlayer, EntityTypeEnemy:
+		return GetEntityFaction(entity) == GetEntityFaction(shooter)
+	case EntityTypeAsteroid, EntityTypeStation:
+		return true
+	default:
+		return false
+	}
+}
+
+// segmentCircleEntry returns the segment parameter t in [0, 1] where a segment starting at (x1, y1)
+// with direction (dx, dy) first touches a circle; a segment starting inside the circle hits at t = 0
+func segmentCircleEntry(x1, y1, dx, dy, lengthSq, cx, cy, radius float64) (float64, bool) {
+	fx := x1 - cx
+	fy := y1 - cy
+	c := fx*fx + fy*fy - radius*radius
+	if c <= 0 {
+		return 0, true // Starts inside
+	}
+	if lengthSq == 0 {
+		return 0, false
+	}
+
+	b := 2 * (fx*dx + fy*dy)
+	discriminant := b*b - 4*lengthSq*c
+	if discriminant < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(discriminant)) / (2 * lengthSq)
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+	return t, true
+}
@@ -0,0 +1,31 @@
+package game
+
+import "testing"
+
+// TestAsteroidBlocksLineOfSight checks a shot past an asteroid is blocked,
+// and clears once the asteroid is out of the way
+func TestAsteroidBlocksLineOfSight(t *testing.T) {
+	world := NewWorld(DefaultConfig())
+	shooter := NewEntityWithShipType(0, 0, EntityTypeEnemy, ShipTypeShooter, nil)
+	target := NewEntityWithShipType(400, 0, EntityTypePlayer, ShipTypePlayer, nil)
+	asteroid := NewAsteroid(200, 0, AsteroidSizeLarge)
+	world.RegisterEntity(shooter)
+	world.RegisterEntity(target)
+	world.RegisterEntity(asteroid)
+
+	if world.HasLineOfSight(shooter, target, shooter.X, shooter.Y, target.X, target.Y) {
+		t.Fatal("shot through an asteroid has line of sight")
+	}
+	hit, ok := world.Raycast(shooter.X, shooter.Y, target.X, target.Y, func(entity *Entity) bool {
+		return entity != target && BlocksLineOfSight(entity, shooter)
+	})
+	if !ok || hit.Entity != asteroid {
+		t.Fatalf("shot hit %v, want the asteroid", hit.Entity)
+	}
+
+	asteroid.Y = 200
+	world.UpdateEntityCell(asteroid)
+	if !world.HasLineOfSight(shooter, target, shooter.X, shooter.Y, target.X, target.Y) {
+		t.Fatal("shot past a moved asteroid is still blocked")
+	}
+}
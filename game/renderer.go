@@ -6,6 +6,8 @@ import (
 	"image/color"
 	"math"
 
+	"billionslike3/game/assets"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -97,8 +99,32 @@ func (c *Camera) GetVisibleCells(world *World) []*Cell {
 	return cells
 }
 
+// minAITurretDrawRadius is the on-screen ship radius below which AI turrets aren't drawn
+const minAITurretDrawRadius = 8.0
+
+// spriteRadiusScale is the sprite's drawn width relative to the entity's collision radius
+const spriteRadiusScale = 2.6
+
+// tracerFadeFraction is the final fraction of a projectile's lifetime spent fading out
+const tracerFadeFraction = 0.3
+
 // Renderer handles rendering of game entities
 type Renderer struct {
+	// HideUI skips score, FPS and other HUD text (photo mode)
+	HideUI bool
+
+	// Recorded damage for the damage heatmap overlay (see DebugState.Heatmap)
+	Heatmap *Heatmap
+
+	// The run's last checkpoint, offered on the death screen (nil if none)
+	Checkpoint *Checkpoint
+
+	// Remaining lives, shown next to the score (nil when lives are off)
+	Lives *Lives
+
+	// The player's standing with the factions that take sides, shown once it has changed
+	Reputation *Reputation
+
 	camera               *Camera
 	faceSource           *text.GoTextFaceSource
 	fpsTextUpdateCounter int
@@ -113,16 +139,49 @@ type Renderer struct {
 	turretCount           int
 	circleCount           int
 	lineCount             int
+
+	// Scratch buffer for predicted trail points (reused every frame)
+	predictedPathBuffer [][2]float64
+
+	// Scratch buffer for the player path preview (reused every frame)
+	playerPathBuffer [][2]float64
+
+	// Scratch buffer for an entity's status icons, and the icon images (drawn once, reused every frame)
+	statusIcons      []StatusIcon
+	statusIconImages [statusIconCount]*ebiten.Image
+
+	// Post-processing glow on the world (see bloom.go)
+	bloom Bloom
+
+	// Light buffer multiplied over the world, and its pooled lights (see lighting.go)
+	lighting Lighting
+
+	// Sprite atlas (nil falls back to vector shapes)
+	sprites *assets.Atlas
+
+	// Effect shaders (nil draws no shader effects)
+	shaders *ShaderManager
+
+	// Animation sheet names per ship sprite (avoids building strings every frame)
+	sheetNames map[string]animationSheetNames
+
+	// World being rendered this frame (used to clip aim lines at blockers)
+	world *World
 }
 
 // NewRenderer creates a new renderer
-func NewRenderer(camera *Camera) *Renderer {
+func NewRenderer(camera *Camera, sprites *assets.Atlas, shaders *ShaderManager) *Renderer {
 	faceSource, _ := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
 	return &Renderer{
 		camera:               camera,
+		sprites:              sprites,
+		shaders:              shaders,
+		sheetNames:           make(map[string]animationSheetNames),
 		faceSource:           faceSource,
 		fpsTextUpdateCounter: 0,
 		cachedFPSText:        "FPS: 60",
+		predictedPathBuffer:  make([][2]float64, 0, PredictedTrailSteps),
+		playerPathBuffer:     make([][2]float64, 0, PlayerPathPoints),
 	}
 }
 
@@ -137,6 +196,13 @@ func (r *Renderer) Render(screen *ebiten.Image, world *World, player *Entity, sc
 	r.turretCount = 0
 	r.circleCount = 0
 	r.lineCount = 0
+	r.world = world
+
+	// With bloom on, the world is drawn offscreen and composited with its glow before the UI
+	output := screen
+	if bloomEnabled() {
+		screen = r.bloom.Begin(output)
+	}
 
 	// Render cell grid on background (if debug flag is enabled)
 	debugState := GetDebugState()
@@ -144,15 +210,29 @@ func (r *Renderer) Render(screen *ebiten.Image, world *World, player *Entity, sc
 		r.renderCellGrid(screen, world)
 	}
 
+	// Render the safe zone edge and the next zone below everything else
+	if world.SafeZone != nil {
+		r.renderSafeZone(screen, world.SafeZone)
+	}
+
 	// Get visible cells
 	visibleCells := r.camera.GetVisibleCells(world)
 
+	// Debug heatmap below the entities (if enabled)
+	if debugState.Heatmap != HeatmapOff {
+		r.renderHeatmap(screen, debugState.Heatmap, visibleCells)
+	}
+
 	// Count entities for performance optimizations
 	entityCount := 0
 	for _, cell := range visibleCells {
 		entityCount += cell.Count
 	}
 
+	// Wreckage drifts below everything else in the world
+	r.renderDebris(screen, world)
+	r.renderSalvageBeam(screen, world, player)
+
 	// Limit destroyed indicator rendering when there are many entities (performance optimization)
 	maxDestroyedIndicators := 10 // Reduced from 20 to 10
 	destroyedIndicatorCount := 0
@@ -174,6 +254,16 @@ func (r *Renderer) Render(screen *ebiten.Image, world *World, player *Entity, sc
 		}
 	}
 
+	// Render breadcrumb and predicted trails below ships (if enabled)
+	if debugState.ShowTrails {
+		r.renderTrails(screen, visibleCells)
+	}
+
+	// Render the player's predicted path below ships (if enabled)
+	if debugState.ShowPlayerPath {
+		r.renderPlayerPath(screen, player)
+	}
+
 	// Second pass: render non-projectile entities
 	for _, cell := range visibleCells {
 		for i := 0; i < cell.Count; i++ {
@@ -187,14 +277,6 @@ func (r *Renderer) Render(screen *ebiten.Image, world *World, player *Entity, sc
 				continue
 			}
 
-			// Skip destroyed indicators if we've hit the limit
-			if entity.Type == EntityTypeDestroyedIndicator {
-				if destroyedIndicatorCount >= maxDestroyedIndicators {
-					continue
-				}
-				destroyedIndicatorCount++
-			}
-
 			r.entityRenderCount++
 			// Only draw aim lines for the player (major performance optimization)
 			drawAimLines := (entity == player && entity != nil)
@@ -202,8 +284,40 @@ func (r *Renderer) Render(screen *ebiten.Image, world *World, player *Entity, sc
 		}
 	}
 
+	// Hit sparks and scorch decals have their own limit, so a firefight doesn't crowd out the kill indicators
+	r.renderImpactFX(screen, world)
+
+	// Render destroyed indicators newest first, so the freshest events win when over the limit
+	// Team pings don't count toward the limit and are drawn on top (see renderPings)
+	for i := len(world.FX) - 1; i >= 0 && destroyedIndicatorCount < maxDestroyedIndicators; i-- {
+		entity := world.FX[i]
+		if !entity.Active || isPingKind(entity.Indicator.Kind) || isImpactKind(entity.Indicator.Kind) ||
+			!r.renderDestroyedIndicator(screen, entity) {
+			continue
+		}
+		destroyedIndicatorCount++
+	}
+	r.renderPings(screen, world, player)
+
+	// Light the world up around projectiles, engines and explosions (before it glows)
+	if lightingEnabled() {
+		r.gatherLights(world, visibleCells)
+		r.drawCallCount += r.lighting.Apply(screen)
+	}
+
+	if screen != output {
+		r.drawCallCount += r.bloom.Apply(output)
+		screen = output
+	}
+
+	// EMP blasts send shockwaves through the world, and hits on the player pull its colors apart
+	r.queueShockwaves(world)
+	r.drawCallCount += r.shaders.ApplyScreenEffects(screen)
+
 	// Render UI (score, FPS, and restart message)
-	r.RenderUI(screen, player, score, fps)
+	if !r.HideUI {
+		r.RenderUI(screen, player, score, fps)
+	}
 
 	// Print draw call statistics (disabled for performance - this is VERY expensive)
 	// Only uncomment for debugging
@@ -261,15 +375,39 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 	var clr = factionConfig.Color
 	if entity.Type == EntityTypeProjectile {
 		// Color bullets by owner's ship type
-		if entity.Owner != nil {
+		if entity.OwnerID != 0 {
 			clr = factionConfig.Color
 		} else {
 			clr = color.RGBA{255, 255, 0, 255} // Yellow fallback if no owner
 		}
+
+		// Tracers fade out as they near the end of their range
+		if entity.Lifetime > 0 && GetWeaponConfig(entity.Weapon).TracerFade {
+			remaining := (entity.Lifetime - entity.Age) / (entity.Lifetime * tracerFadeFraction)
+			if remaining < 1 {
+				clr.A = uint8(float64(clr.A) * math.Max(remaining, 0))
+			}
+		}
 	} else {
 		clr = factionConfig.Color
 	}
 
+	// Cloaked ships are invisible until detected, then shimmer in as they fade up
+	cloaking := entity.Cloak.Capable && entity.Cloak.Visibility < 1
+	if cloaking {
+		if entity.Cloak.Visibility <= 0 {
+			return
+		}
+		r.drawCloakShimmer(screen, entity, sx, sy, radius)
+		visibility := entity.Cloak.Visibility
+		clr = color.RGBA{
+			uint8(float64(clr.R) * visibility),
+			uint8(float64(clr.G) * visibility),
+			uint8(float64(clr.B) * visibility),
+			uint8(float64(clr.A) * visibility),
+		}
+	}
+
 	// Clamp minimum radius for rendering
 	if radius < 1 {
 		radius = 1
@@ -282,6 +420,13 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 		shipConfig = GetShipTypeConfig(entity.ShipType)
 	}
 
+	// Elites glow with their modifier's aura
+	if entity.Elite != EliteNone && radius >= 3.0 {
+		r.circleCount++
+		r.drawCallCount++
+		r.drawTransparentCircle(screen, sx, sy, radius+5, GetEliteModifierConfig(entity.Elite).AuraColor)
+	}
+
 	// Draw entity based on type and shape
 	// For small entities (radius < 3), always use circles to reduce draw calls
 	if radius < 3.0 {
@@ -289,8 +434,19 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 		r.drawCallCount++
 		vector.DrawFilledCircle(screen, float32(sx), float32(sy), float32(radius), clr, true)
 	} else if entity.Type == EntityTypeHomingRocket {
-		// Homing rockets are always rendered as triangles pointing at target
-		r.drawTriangle(screen, sx, sy, radius, entity.Rotation, clr, ShipTypePlayer, true) // true = is homing rocket
+		// Homing rockets are always rendered pointing at target (sprite if available, else triangle)
+		if sprite := r.sprites.Image(GetShipTypeConfig(ShipTypeHomingSuicide).Sprite); sprite != nil {
+			r.drawSprite(screen, sprite, sx, sy, radius, entity.Rotation, clr)
+		} else {
+			r.drawTriangle(screen, sx, sy, radius, entity.Rotation, clr, ShipTypePlayer, true) // true = is homing rocket
+		}
+	} else if entity.Type == EntityTypeAsteroid {
+		r.drawAsteroid(screen, sx, sy, radius, entity.Rotation, entity.ID, clr)
+	} else if entity.Type == EntityTypeStation {
+		r.drawStation(screen, sx, sy, radius, entity.Rotation, clr)
+	} else if sprite := r.shipSpriteFrame(shipConfig.Sprite, &entity.Animation); sprite != nil {
+		// Ships with art use their animated sprite instead of a vector shape
+		r.drawSprite(screen, sprite, sx, sy, radius, entity.Rotation, clr)
 	} else {
 		switch shipConfig.Shape {
 		case ShipShapeCircle:
@@ -310,6 +466,25 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 		}
 	}
 
+	// Shields ripple around the ships they cover, dented where they're hit
+	if entity.Shield > 0 && !cloaking && radius >= 3.0 {
+		shieldColor := statusIconColors[StatusIconShielded]
+		shieldColor.A = 200
+		if r.shaders.DrawShieldRipple(screen, sx, sy, radius*ShieldRippleScale, entity.Age, shieldColor, entity.ShieldBubble.Uniform(entity.Age)) {
+			r.drawCallCount++
+		}
+	}
+
+	// EMP-disabled ships crackle with sparks
+	if entity.Status.IsDisabled() && radius >= 3.0 {
+		r.drawSparks(screen, entity, sx, sy, radius)
+	}
+
+	// Burning ships shed embers
+	if entity.Status.IsBurning() && radius >= 3.0 {
+		r.drawEmbers(screen, entity, sx, sy, radius)
+	}
+
 	// Draw direction indicator (small line) - only for player to save draw calls
 	// Skip for projectiles (they're too small and numerous)
 	if entity.Type != EntityTypeProjectile && entity == player && radius >= 3.0 {
@@ -348,27 +523,20 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 	}
 
 	// Draw turret mount points (only for ships, not projectiles)
-	// Only draw turrets for player to save draw calls (performance optimization)
-	// Skip if entity is too small (performance optimization)
-	// Reuse shipConfig we already fetched above
-	if entity.Type != EntityTypeProjectile && entity == player && radius >= 3.0 {
-		for turretIndex, mount := range shipConfig.TurretMounts {
+	// Other ships' turrets are only drawn when big enough on screen to read (performance optimization)
+	// and not while a cloaked ship is still fading in
+	if entity.Type != EntityTypeProjectile && !cloaking && ((entity == player && radius >= 3.0) || radius >= minAITurretDrawRadius) {
+		for i := range entity.Turrets {
+			turret := &entity.Turrets[i]
+			mount := &turret.Mount
+
 			// Only draw active turrets
 			if !mount.Active {
 				continue
 			}
 
-			// Calculate turret position relative to ship center
-			// Rotate the offset by the ship's rotation
-			cosRot := math.Cos(entity.Rotation)
-			sinRot := math.Sin(entity.Rotation)
-
-			// Transform mount offset from ship-local to world coordinates
-			mountX := mount.OffsetX*cosRot - mount.OffsetY*sinRot
-			mountY := mount.OffsetX*sinRot + mount.OffsetY*cosRot
-
-			// Convert to screen coordinates
-			turretSx, turretSy := r.camera.WorldToScreen(entity.X+mountX, entity.Y+mountY)
+			// Convert turret position to screen coordinates
+			turretSx, turretSy := r.camera.WorldToScreen(turret.WorldPosition(entity))
 
 			// Draw turret as a circle and a line (barrel)
 			turretRadius := 4.0 * r.camera.Zoom
@@ -384,6 +552,22 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 				255,
 			}
 
+			// Destroyed turrets are drawn as a dark, barrel-less stump
+			if turret.Destroyed {
+				r.turretCount++
+				r.circleCount++
+				r.drawCallCount++
+				vector.StrokeCircle(screen, float32(turretSx), float32(turretSy), float32(turretRadius), 1.5, color.RGBA{90, 90, 90, 255}, true)
+				continue
+			}
+
+			// Tractor beams draw their cone while firing
+			if turret.BeamActive {
+				if tractor := GetWeaponConfig(mount.WeaponType).Tractor; tractor != nil {
+					r.drawTractorBeam(screen, turretSx, turretSy, turret.BarrelRotation(entity), tractor)
+				}
+			}
+
 			r.turretCount++
 			r.circleCount++
 			r.lineCount++
@@ -396,21 +580,8 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 			vector.StrokeCircle(screen, float32(turretSx), float32(turretSy), float32(turretRadius), 1.5, turretColor, true)
 
 			// Draw turret barrel (line showing direction)
-			// For player, use per-turret rotation; for others, use ship rotation + mount angle
-			var turretRotation float64
-			if entity.Type == EntityTypePlayer {
-				if playerInput, ok := entity.Input.(*PlayerInput); ok {
-					// Use per-turret rotation if available, fallback to ship rotation + mount angle
-					turretRotation = playerInput.GetTurretRotation(turretIndex)
-					if turretRotation == 0.0 {
-						turretRotation = entity.Rotation + mount.Angle
-					}
-				} else {
-					turretRotation = entity.Rotation + mount.Angle
-				}
-			} else {
-				turretRotation = entity.Rotation + mount.Angle
-			}
+			// Use per-turret rotation, falling back to ship rotation + mount angle until aimed
+			turretRotation := turret.BarrelRotation(entity)
 
 			// Barrel extends from center of turret circle
 			// Use barrel length from mount point, or default to 3x turret radius if not set
@@ -446,22 +617,13 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 		}
 	}
 
-	// Draw health bar for damaged entities (only if health is below 50% to reduce draw calls)
-	if entity.Health < entity.MaxHealth && entity.Health < entity.MaxHealth*0.5 {
-		r.healthBarCount++
-		r.drawCallCount += 2 // Background + health bar
-		barWidth := radius * 2
-		barHeight := 4.0 * r.camera.Zoom
-		barX := sx - barWidth/2
-		barY := sy - radius - barHeight - 2
-
-		// Background (red)
-		vector.DrawFilledRect(screen, float32(barX), float32(barY), float32(barWidth), float32(barHeight), color.RGBA{100, 0, 0, 255}, true)
-
-		// Health (green)
-		healthPercent := entity.Health / entity.MaxHealth
-		healthWidth := barWidth * healthPercent
-		vector.DrawFilledRect(screen, float32(barX), float32(barY), float32(healthWidth), float32(barHeight), color.RGBA{0, 255, 0, 255}, true)
+	// Draw the health bar when the health bar setting calls for one, and the status icons above it
+	if entity.Type != EntityTypeProjectile {
+		top := sy - radius
+		if GetSettings().HealthBars.showHealthBar(entity) {
+			top = r.drawHealthBar(screen, entity, sx, sy, radius)
+		}
+		r.drawStatusIcons(screen, entity, sx, top)
 	}
 
 	// Render cell pointer ID (cell coordinates) on entity (if debug flag is enabled)
@@ -472,8 +634,354 @@ func (r *Renderer) renderEntityWithAim(screen *ebiten.Image, entity *Entity, pla
 	}
 }
 
+// inHealthBarRange returns true if the entity is close enough to the camera center for its health bar and
+// status icons (elites and bosses are shown farther out)
+func (r *Renderer) inHealthBarRange(entity *Entity, rank healthBarRank) bool {
+	maxDistance := healthBarRange(rank)
+	dx, dy := entity.X-r.camera.X, entity.Y-r.camera.Y
+	return dx*dx+dy*dy <= maxDistance*maxDistance
+}
+
+// drawHealthBar draws an entity's health bar in its faction's color, skipping ships far from the camera
+// Elites get a segmented bar with their name; bosses a wider bar with more segments (see health_bars.go)
+// It returns the screen Y of the top of the bar (and name), or of the entity when the bar is skipped
+func (r *Renderer) drawHealthBar(screen *ebiten.Image, entity *Entity, sx, sy, radius float64) float64 {
+	rank, name := healthBarStyle(entity)
+	if !r.inHealthBarRange(entity, rank) {
+		return sy - radius
+	}
+
+	barWidth := radius * 2
+	barHeight := 4.0 * r.camera.Zoom
+	segments := 0
+	switch rank {
+	case healthBarElite:
+		barWidth = math.Max(barWidth, 36*r.camera.Zoom)
+		segments = healthBarEliteSegments
+	case healthBarBoss:
+		barWidth = math.Max(radius*3, 60*r.camera.Zoom)
+		barHeight = 6.0 * r.camera.Zoom
+		segments = healthBarBossSegments
+	}
+	barX := sx - barWidth/2
+	barY := sy - radius - barHeight - 2
+
+	r.healthBarCount++
+	r.drawCallCount += 2 // Background + health bar
+	fill := GetFactionConfig(GetEntityFaction(entity)).Color
+	background := color.RGBA{fill.R / 4, fill.G / 4, fill.B / 4, 255}
+	health := math.Max(entity.Health, 0) / entity.MaxHealth
+	vector.DrawFilledRect(screen, float32(barX), float32(barY), float32(barWidth), float32(barHeight), background, true)
+	vector.DrawFilledRect(screen, float32(barX), float32(barY), float32(barWidth*health), float32(barHeight), fill, true)
+
+	for i := 1; i < segments; i++ {
+		segmentX := float32(barX + barWidth*float64(i)/float64(segments))
+		vector.StrokeLine(screen, segmentX, float32(barY), segmentX, float32(barY+barHeight), 1, color.RGBA{0, 0, 0, 255}, false)
+		r.drawCallCount++
+	}
+	if name != "" {
+		nameColor := color.RGBA{255, 160, 60, 255} // Bosses in orange, elites in their aura's color
+		if rank == healthBarElite {
+			nameColor = GetEliteModifierConfig(entity.Elite).AuraColor
+		}
+		r.drawText(screen, name, sx-r.measureText(name)/2, barY-18, nameColor)
+		return barY - 18
+	}
+	return barY
+}
+
+// drawStatusIcons draws a row of icons for the entity's active status effects, centered above top
+// Ships out of health bar range get none
+func (r *Renderer) drawStatusIcons(screen *ebiten.Image, entity *Entity, sx, top float64) {
+	r.statusIcons = appendStatusIcons(r.statusIcons[:0], entity)
+	if len(r.statusIcons) == 0 {
+		return
+	}
+	rank, _ := healthBarStyle(entity)
+	if !r.inHealthBarRange(entity, rank) {
+		return
+	}
+
+	rowWidth := float64(len(r.statusIcons)*(StatusIconSize+statusIconSpacing) - statusIconSpacing)
+	x := sx - rowWidth/2
+	y := top - StatusIconSize - 2
+	for _, icon := range r.statusIcons {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, y)
+		screen.DrawImage(r.statusIconImage(icon), op)
+		r.drawCallCount++
+		x += StatusIconSize + statusIconSpacing
+	}
+}
+
+// statusIconImage returns the icon's image, drawn the first time it's needed and reused after that
+func (r *Renderer) statusIconImage(icon StatusIcon) *ebiten.Image {
+	if image := r.statusIconImages[icon]; image != nil {
+		return image
+	}
+	const size = float32(StatusIconSize)
+	const center = size / 2
+	image := ebiten.NewImage(StatusIconSize, StatusIconSize)
+	clr := statusIconColors[icon]
+	vector.DrawFilledCircle(image, center, center, center, color.RGBA{0, 0, 0, 180}, true)
+	vector.StrokeCircle(image, center, center, center-1, 1.5, clr, true)
+
+	switch icon {
+	case StatusIconBurning:
+		// Three flame tongues
+		for i, height := range []float32{4, 6, 4} {
+			x := center + float32(i-1)*2.5
+			vector.StrokeLine(image, x, center+3, x, center+3-height, 1.5, clr, true)
+		}
+	case StatusIconSlowed:
+		// Clock hands
+		vector.StrokeLine(image, center, center, center, center-4, 1.5, clr, true)
+		vector.StrokeLine(image, center, center, center+3, center, 1.5, clr, true)
+	case StatusIconDisabled:
+		// Lightning bolt
+		vector.StrokeLine(image, center+2, center-4, center-1, center, 1.5, clr, true)
+		vector.StrokeLine(image, center-1, center, center+1, center, 1.5, clr, true)
+		vector.StrokeLine(image, center+1, center, center-2, center+4, 1.5, clr, true)
+	case StatusIconShielded:
+		// Inner ring
+		vector.StrokeCircle(image, center, center, center-4, 1.5, clr, true)
+	}
+	r.statusIconImages[icon] = image
+	return image
+}
+
+// drawEmbers draws a few embers rising off a burning ship, flickering ten times a second
+func (r *Renderer) drawEmbers(screen *ebiten.Image, entity *Entity, sx, sy, radius float64) {
+	const embers = 5
+	frame := uint64(entity.Age * 10)
+	rise := entity.Age*2 - math.Floor(entity.Age*2) // Embers drift up and start over twice a second
+	for i := uint64(0); i < embers; i++ {
+		// Cheap hash of the ship, frame and ember picks its offset and brightness
+		hash := (entity.ID*0x9E3779B1 + frame*0x85EBCA6B + i*0xC2B2AE35) % 1000
+		offsetX := (float64(hash%100)/100 - 0.5) * radius * 1.6
+		height := (float64(i) + rise) / embers * radius * 1.5
+		brightness := uint8(150 + hash%100)
+		r.circleCount++
+		r.drawCallCount++
+		vector.DrawFilledCircle(screen, float32(sx+offsetX), float32(sy-height), 1.5, color.RGBA{255, brightness, 40, 220}, true)
+	}
+}
+
+// drawTractorBeam draws a tow beam as a translucent cone fanning out from the turret
+func (r *Renderer) drawTractorBeam(screen *ebiten.Image, sx, sy, rotation float64, tractor *TractorConfig) {
+	const rays = 9
+	length := tractor.Range * r.camera.Zoom
+	edgeColor := color.RGBA{120, 200, 255, 140}
+	fillColor := color.RGBA{120, 200, 255, 35}
+
+	// Fill the cone with faint rays, then outline its edges and far arc
+	var prevX, prevY float64
+	for i := 0; i < rays; i++ {
+		angle := rotation - tractor.HalfAngle + 2*tractor.HalfAngle*float64(i)/(rays-1)
+		endX := sx + math.Cos(angle)*length
+		endY := sy + math.Sin(angle)*length
+		clr := fillColor
+		if i == 0 || i == rays-1 {
+			clr = edgeColor
+		}
+		r.drawTransparentLineWithWidth(screen, sx, sy, endX, endY, clr, 6)
+		if i > 0 {
+			r.drawTransparentLine(screen, prevX, prevY, endX, endY, edgeColor)
+		}
+		prevX, prevY = endX, endY
+	}
+	r.lineCount += 2*rays - 1
+	r.drawCallCount += 2*rays - 1
+}
+
+// renderHeatmap tints each HeatmapCellSize square on screen by its entity count or recent damage,
+// from faint yellow to solid red at the busiest visible square
+func (r *Renderer) renderHeatmap(screen *ebiten.Image, mode HeatmapMode, visibleCells []*Cell) {
+	var heat map[HeatCell]float64
+	if r.Heatmap != nil {
+		heat = r.Heatmap.Damage
+	}
+	if mode == HeatmapDensity {
+		heat = make(map[HeatCell]float64, 64)
+		for _, cell := range visibleCells {
+			for i := 0; i < cell.Count; i++ {
+				if entity := cell.Entities[i]; entity.Active {
+					heat[HeatCellAt(entity.X, entity.Y)]++
+				}
+			}
+		}
+	}
+
+	minX, minY := r.camera.ScreenToWorld(0, 0)
+	maxX, maxY := r.camera.ScreenToWorld(r.camera.Width, r.camera.Height)
+	minCell, maxCell := HeatCellAt(minX, minY), HeatCellAt(maxX, maxY)
+	peak := 0.0
+	for x := minCell.X; x <= maxCell.X; x++ {
+		for y := minCell.Y; y <= maxCell.Y; y++ {
+			peak = math.Max(peak, heat[HeatCell{x, y}])
+		}
+	}
+	legend := fmt.Sprintf("Heatmap: %s (peak %.0f per square)", mode, peak)
+	r.drawText(screen, legend, 10, r.camera.Height-55, color.RGBA{255, 160, 80, 255})
+	if peak == 0 {
+		return
+	}
+
+	size := float32(HeatmapCellSize * r.camera.Zoom)
+	for x := minCell.X; x <= maxCell.X; x++ {
+		for y := minCell.Y; y <= maxCell.Y; y++ {
+			value := heat[HeatCell{x, y}]
+			if value <= 0 {
+				continue
+			}
+			intensity := value / peak
+			sx, sy := r.camera.WorldToScreen(float64(x)*HeatmapCellSize, float64(y)*HeatmapCellSize)
+			clr := color.RGBA{255, uint8(220 * (1 - intensity)), 0, uint8(30 + 120*intensity)}
+			vector.DrawFilledRect(screen, float32(sx), float32(sy), size, size, clr, false)
+		}
+	}
+}
+
+// renderTrails renders breadcrumb trails and predicted future paths for entities in visible cells
+func (r *Renderer) renderTrails(screen *ebiten.Image, visibleCells []*Cell) {
+	for _, cell := range visibleCells {
+		for i := 0; i < cell.Count; i++ {
+			entity := cell.Entities[i]
+			if !entity.Active || entity.Health <= 0 || entity.Trail == nil {
+				continue
+			}
+
+			factionColor := GetFactionConfig(entity.Faction).Color
+
+			// Breadcrumbs: small dots fading from oldest (dim) to newest (bright)
+			trail := entity.Trail
+			for j := 0; j < trail.Count; j++ {
+				px, py := trail.Point(j)
+				sx, sy := r.camera.WorldToScreen(px, py)
+				if sx < 0 || sx > r.camera.Width || sy < 0 || sy > r.camera.Height {
+					continue
+				}
+				alpha := uint8(20 + 100*float64(j+1)/float64(trail.Count))
+				dotColor := color.RGBA{factionColor.R, factionColor.G, factionColor.B, alpha}
+				r.circleCount++
+				r.drawCallCount++
+				vector.DrawFilledCircle(screen, float32(sx), float32(sy), 1.5, dotColor, true)
+			}
+
+			// Predicted path: dashed line segments from current position into the future
+			r.predictedPathBuffer = PredictFuturePath(entity, PredictedTrailDuration, PredictedTrailSteps, r.predictedPathBuffer)
+			prevX, prevY := r.camera.WorldToScreen(entity.X, entity.Y)
+			predictColor := color.RGBA{255, 255, 255, 60}
+			for j, point := range r.predictedPathBuffer {
+				sx, sy := r.camera.WorldToScreen(point[0], point[1])
+				// Skip every other segment to get a dashed look
+				if j%2 == 0 {
+					r.lineCount++
+					r.drawCallCount++
+					vector.StrokeLine(screen, float32(prevX), float32(prevY), float32(sx), float32(sy), 1, predictColor, true)
+				}
+				prevX, prevY = sx, sy
+			}
+		}
+	}
+}
+
+// renderPlayerPath renders the player's predicted trajectory as a dotted line
+// Uses the currently held thrust and rotation inputs
+func (r *Renderer) renderPlayerPath(screen *ebiten.Image, player *Entity) {
+	if player == nil || !player.Active || player.Input == nil {
+		return
+	}
+
+	thrust := player.Input.GetThrust()
+	rotation := player.Input.GetRotation()
+	r.playerPathBuffer = PredictShipPath(player, thrust, rotation, PlayerPathDuration, PlayerPathPoints, r.playerPathBuffer)
+
+	pointCount := len(r.playerPathBuffer)
+	for i, point := range r.playerPathBuffer {
+		sx, sy := r.camera.WorldToScreen(point[0], point[1])
+		if sx < 0 || sx > r.camera.Width || sy < 0 || sy > r.camera.Height {
+			continue
+		}
+		// Fade dots further in the future
+		alpha := uint8(200 - 160*float64(i)/float64(pointCount))
+		r.circleCount++
+		r.drawCallCount++
+		vector.DrawFilledCircle(screen, float32(sx), float32(sy), 2, color.RGBA{0, 255, 255, alpha}, true)
+	}
+}
+
+// renderImpactFX draws hit sparks, scorch decals and chain lightning, newest first, up to maxImpactFXDrawn
+func (r *Renderer) renderImpactFX(screen *ebiten.Image, world *World) {
+	drawn := 0
+	for i := len(world.FX) - 1; i >= 0 && drawn < maxImpactFXDrawn; i-- {
+		entity := world.FX[i]
+		if !entity.Active || !isImpactKind(entity.Indicator.Kind) {
+			continue
+		}
+		if entity.Indicator.Kind == IndicatorKindArc {
+			// Bolts span several targets, so they aren't culled by their origin
+			r.drawArc(screen, entity)
+			drawn++
+			continue
+		}
+		x, y := entity.X, entity.Y
+		if entity.Indicator.Kind == IndicatorKindScorch {
+			var onHost bool
+			if x, y, onHost = scorchPosition(world, entity); !onHost {
+				continue
+			}
+		}
+		sx, sy := r.camera.WorldToScreen(x, y)
+		if sx < -50 || sx > r.camera.Width+50 || sy < -50 || sy > r.camera.Height+50 {
+			continue
+		}
+		if entity.Indicator.Kind == IndicatorKindScorch {
+			r.drawScorch(screen, entity, sx, sy)
+		} else {
+			r.drawImpactSparks(screen, entity, sx, sy)
+		}
+		drawn++
+	}
+}
+
+// drawImpactSparks draws a hit's spark burst: short streaks flying out in a cone along the projectile's heading,
+// stretching and fading over their lifetime
+func (r *Renderer) drawImpactSparks(screen *ebiten.Image, entity *Entity, sx, sy float64) {
+	sparks := int(math.Round(ImpactSparkCount * GetSettings().ParticleDensity))
+	progress := math.Min(entity.Age/entity.Lifetime, 1)
+	alpha := uint8(255 * (1 - progress))
+	for i := 0; i < sparks; i++ {
+		// Cheap hash of the burst and spark picks the spark's angle and speed
+		hash := (entity.ID*0x9E3779B1 + uint64(i)*0xC2B2AE35) % 1000
+		angle := entity.Rotation + (float64(hash%100)/50-1)*ImpactSparkSpread
+		reach := (10 + float64(hash/100)*2) * progress * r.camera.Zoom
+		startX := sx + math.Cos(angle)*reach*0.5
+		startY := sy + math.Sin(angle)*reach*0.5
+		endX := sx + math.Cos(angle)*reach
+		endY := sy + math.Sin(angle)*reach
+		r.lineCount++
+		r.drawCallCount++
+		vector.StrokeLine(screen, float32(startX), float32(startY), float32(endX), float32(endY), 1.5, color.RGBA{255, 220, 140, alpha}, true)
+	}
+}
+
+// drawScorch draws a scorch decal, a dark blotch that fades over the last third of its lifetime
+func (r *Renderer) drawScorch(screen *ebiten.Image, entity *Entity, sx, sy float64) {
+	alpha := 160.0
+	if remaining := 1 - entity.Age/entity.Lifetime; remaining < 1.0/3 {
+		alpha *= math.Max(remaining*3, 0)
+	}
+	radius := entity.Radius * r.camera.Zoom
+	r.circleCount += 2
+	r.drawCallCount += 2
+	vector.DrawFilledCircle(screen, float32(sx), float32(sy), float32(radius), color.RGBA{20, 15, 10, uint8(alpha)}, true)
+	vector.DrawFilledCircle(screen, float32(sx), float32(sy), float32(radius*0.5), color.RGBA{60, 30, 10, uint8(alpha)}, true)
+}
+
 // renderDestroyedIndicator renders a visual indicator showing a missile was destroyed
-func (r *Renderer) renderDestroyedIndicator(screen *ebiten.Image, entity *Entity) {
+// Returns false if the indicator was culled (off screen or faded)
+func (r *Renderer) renderDestroyedIndicator(screen *ebiten.Image, entity *Entity) bool {
 	// Convert world coordinates to screen coordinates
 	sx, sy := r.camera.WorldToScreen(entity.X, entity.Y)
 
@@ -481,20 +989,29 @@ func (r *Renderer) renderDestroyedIndicator(screen *ebiten.Image, entity *Entity
 	margin := 100.0
 	if sx < -margin || sx > r.camera.Width+margin ||
 		sy < -margin || sy > r.camera.Height+margin {
-		return
+		return false
+	}
+
+	// EMP blasts draw an expanding ring instead of a cross
+	if entity.Indicator.Kind == IndicatorKindEMP {
+		r.drawEMPRing(screen, entity, sx, sy)
+		return true
 	}
 
+	// Accessibility: static indicators keep a constant alpha for their whole lifetime
+	staticIndicator := GetSettings().StaticIndicators
+
 	// Skip rendering if indicator is too old/faded (performance optimization)
 	// Skip earlier (at 50% lifetime) to save more draw calls
-	if entity.Lifetime > 0 && entity.Age > entity.Lifetime*0.5 {
+	if !staticIndicator && entity.Lifetime > 0 && entity.Age > entity.Lifetime*0.5 {
 		// Indicator is fading out, skip rendering to save draw calls
-		return
+		return false
 	}
 
 	// Determine color - yellow for bullet kills, faction color for missile timeouts
 	var baseColor color.RGBA
-	if entity.Radius < 0 {
-		// Negative radius marks this as a yellow indicator (bullet kill)
+	if entity.Indicator.Kind == IndicatorKindKill {
+		// Bullet kill
 		baseColor = color.RGBA{255, 255, 0, 255} // Yellow
 	} else {
 		// Use faction color (missile timeout)
@@ -504,7 +1021,7 @@ func (r *Renderer) renderDestroyedIndicator(screen *ebiten.Image, entity *Entity
 
 	// Calculate fade based on lifetime (fade from 0.3 alpha to transparent)
 	var alpha uint8 = 77 // Start at ~0.3 alpha (77/255 ≈ 0.3)
-	if entity.Lifetime > 0 {
+	if entity.Lifetime > 0 && !staticIndicator {
 		lifePercent := 1.0 - (entity.Age / entity.Lifetime)
 		if lifePercent < 0 {
 			lifePercent = 0
@@ -522,12 +1039,23 @@ func (r *Renderer) renderDestroyedIndicator(screen *ebiten.Image, entity *Entity
 	}
 
 	// Draw indicator as an X shape (cross)
-	// Use absolute value of radius (negative radius is just a marker for yellow)
-	radius := math.Abs(entity.Radius) * r.camera.Zoom
+	radius := entity.Radius * r.camera.Zoom
 	if radius < 3 {
 		radius = 3
 	}
 
+	// Static icon: a steady icon (or ring without art) instead of a fading cross
+	if staticIndicator {
+		if icon := r.sprites.Image("icons/destroyed"); icon != nil {
+			r.drawSprite(screen, icon, sx, sy, radius, 0, clr)
+			return true
+		}
+		r.circleCount++
+		r.drawCallCount++
+		vector.StrokeCircle(screen, float32(sx), float32(sy), float32(radius), 2, clr, true)
+		return true
+	}
+
 	// Draw X shape (two diagonal lines) with thicker lines
 	r.lineCount += 2
 	r.drawCallCount += 2
@@ -545,6 +1073,62 @@ func (r *Renderer) renderDestroyedIndicator(screen *ebiten.Image, entity *Entity
 	x4 := sx - radius
 	y4 := sy + radius
 	r.drawTransparentLineWithWidth(screen, x3, y3, x4, y4, clr, lineWidth)
+	return true
+}
+
+// shipSpriteFrame returns the current animation frame for a ship sprite (nil if the ship has no art)
+// States without their own sheet fall back to the idle sprite
+func (r *Renderer) shipSpriteFrame(sprite string, animation *AnimationController) *ebiten.Image {
+	if sprite == "" {
+		return nil
+	}
+	names, ok := r.sheetNames[sprite]
+	if !ok {
+		names = newAnimationSheetNames(sprite)
+		r.sheetNames[sprite] = names
+	}
+	frames := r.sprites.Frames(names[animation.State])
+	if len(frames) == 0 {
+		frames = r.sprites.Frames(sprite)
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+	return frames[animation.FrameIndex(len(frames))]
+}
+
+// drawSprite draws a sprite centered at (sx, sy), scaled to the entity radius, rotated and tinted
+// Sprites are drawn white in the atlas so the tint supplies the faction color
+func (r *Renderer) drawSprite(screen *ebiten.Image, sprite *ebiten.Image, sx, sy, radius, rotation float64, clr color.RGBA) {
+	bounds := sprite.Bounds()
+	width := float64(bounds.Dx())
+	height := float64(bounds.Dy())
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-width/2, -height/2)
+	scale := radius * spriteRadiusScale / width
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Rotate(rotation)
+	op.GeoM.Translate(sx, sy)
+	op.ColorScale.ScaleWithColor(clr)
+	op.Filter = ebiten.FilterLinear
+
+	r.drawCallCount++
+	screen.DrawImage(sprite, op)
+}
+
+// clipAimLine shortens an aim line to end at the first entity blocking the shooter's line of sight
+func (r *Renderer) clipAimLine(shooter *Entity, fromX, fromY, toX, toY float64) (float64, float64) {
+	if r.world == nil {
+		return toX, toY
+	}
+	hit, blocked := r.world.Raycast(fromX, fromY, toX, toY, func(entity *Entity) bool {
+		return BlocksLineOfSight(entity, shooter)
+	})
+	if !blocked {
+		return toX, toY
+	}
+	return hit.X, hit.Y
 }
 
 // renderXP renders an XP entity
@@ -573,6 +1157,14 @@ func (r *Renderer) renderXP(screen *ebiten.Image, entity *Entity) {
 		radius = 2
 	}
 
+	// Merged orbs (see xp_merge.go) glow brighter, toward white, with a faint fill
+	if entity.Pickup.Orbs > 1 {
+		brightness := math.Min(math.Log2(float64(entity.Pickup.Orbs))/5, 1)
+		clr.B = uint8(brightness * 200)
+		r.drawCallCount++
+		vector.FillCircle(screen, float32(sx), float32(sy), float32(radius), color.RGBA{clr.R, clr.G, clr.B, 80}, true)
+	}
+
 	// Draw outline only (non-filled orb)
 	r.lineCount++
 	r.drawCallCount++
@@ -585,30 +1177,25 @@ func (r *Renderer) drawAimTarget(screen *ebiten.Image, entity *Entity, player *E
 	var hasTarget bool
 	var aimPointX, aimPointY float64
 
-	shipConfig := GetShipTypeConfig(entity.ShipType)
-
 	// Determine target based on entity type
 	if entity.Type == EntityTypePlayer {
 		// Player targets enemies - draw aim lines for each turret
-		if playerInput, ok := entity.Input.(*PlayerInput); ok {
+		if _, ok := entity.Input.(*PlayerInput); ok {
 			// Draw aim line for each turret that has a target
-			for turretIndex, mount := range shipConfig.TurretMounts {
-				if !mount.Active {
+			for i := range entity.Turrets {
+				turret := &entity.Turrets[i]
+				if !turret.Mount.Active {
 					continue
 				}
-				turretTarget := playerInput.GetTurretTarget(turretIndex)
+				turretTarget := turret.Target
 				if turretTarget.HasTarget {
 					targetX = turretTarget.TargetX
 					targetY = turretTarget.TargetY
 					hasTarget = true
 
 					// Calculate turret position for aim point
-					cosRot := math.Cos(entity.Rotation)
-					sinRot := math.Sin(entity.Rotation)
-					mountX := mount.OffsetX*cosRot - mount.OffsetY*sinRot
-					mountY := mount.OffsetX*sinRot + mount.OffsetY*cosRot
-					aimPointX = entity.X + mountX
-					aimPointY = entity.Y + mountY
+					aimPointX, aimPointY = turret.WorldPosition(entity)
+					targetX, targetY = r.clipAimLine(entity, aimPointX, aimPointY, targetX, targetY)
 
 					// Draw aim line for this turret with transparency
 					aimSx, aimSy := r.camera.WorldToScreen(aimPointX, aimPointY)
@@ -653,6 +1240,8 @@ func (r *Renderer) drawAimTarget(screen *ebiten.Image, entity *Entity, player *E
 
 	// Draw aim line if there's a target
 	if hasTarget {
+		targetX, targetY = r.clipAimLine(entity, aimPointX, aimPointY, targetX, targetY)
+
 		// Convert to screen coordinates
 		aimSx, aimSy := r.camera.WorldToScreen(aimPointX, aimPointY)
 		targetSx, targetSy := r.camera.WorldToScreen(targetX, targetY)
@@ -762,6 +1351,180 @@ func (r *Renderer) drawSquare(screen *ebiten.Image, x, y, radius, rotation float
 	}
 }
 
+// drawAsteroid draws a lumpy rock outline; the lumps are seeded by the entity ID so each asteroid keeps its shape
+func (r *Renderer) drawAsteroid(screen *ebiten.Image, x, y, radius, rotation float64, id uint64, clr color.Color) {
+	const vertices = 9
+	var points [vertices][2]float64
+	seed := id*2654435761 + 1
+	for i := range points {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		lump := 0.75 + float64(seed>>40)/float64(1<<24)*0.25 // 0.75-1.0 of the radius
+		angle := rotation + float64(i)/vertices*2*math.Pi
+		points[i] = [2]float64{x + math.Cos(angle)*radius*lump, y + math.Sin(angle)*radius*lump}
+	}
+
+	r.lineCount += vertices
+	r.drawCallCount += vertices
+	for i := range points {
+		next := points[(i+1)%vertices]
+		vector.StrokeLine(screen, float32(points[i][0]), float32(points[i][1]),
+			float32(next[0]), float32(next[1]), 2, clr, true)
+	}
+}
+
+// drawStation draws a derelict station: an outer ring joined to a square hub by four spokes
+func (r *Renderer) drawStation(screen *ebiten.Image, x, y, radius, rotation float64, clr color.Color) {
+	r.circleCount++
+	r.drawCallCount++
+	vector.StrokeCircle(screen, float32(x), float32(y), float32(radius), 3, clr, true)
+
+	hub := radius * 0.3
+	r.lineCount += 8 // 4 spokes + 4 hub edges
+	r.drawCallCount += 8
+	for i := 0; i < 4; i++ {
+		angle := rotation + float64(i)*math.Pi/2
+		cos, sin := math.Cos(angle), math.Sin(angle)
+		vector.StrokeLine(screen, float32(x+cos*hub), float32(y+sin*hub),
+			float32(x+cos*radius), float32(y+sin*radius), 2, clr, true)
+
+		nextCos, nextSin := math.Cos(angle+math.Pi/2), math.Sin(angle+math.Pi/2)
+		vector.StrokeLine(screen, float32(x+cos*hub), float32(y+sin*hub),
+			float32(x+nextCos*hub), float32(y+nextSin*hub), 2, clr, true)
+	}
+}
+
+// drawEMPRing draws an EMP blast as a ring expanding to the blast radius and fading out
+func (r *Renderer) drawEMPRing(screen *ebiten.Image, entity *Entity, sx, sy float64) {
+	progress := math.Min(entity.Age/entity.Lifetime, 1)
+	radius := entity.Radius * r.camera.Zoom * (0.3 + 0.7*progress)
+	alpha := uint8(200 * (1 - progress))
+	r.circleCount++
+	r.drawCallCount++
+	vector.StrokeCircle(screen, float32(sx), float32(sy), float32(radius), 3, color.RGBA{alpha / 2, alpha, alpha, alpha}, true)
+}
+
+// queueShockwaves queues a screen distortion for each EMP blast on screen, following its expanding ring
+func (r *Renderer) queueShockwaves(world *World) {
+	for _, entity := range world.FX {
+		if !entity.Active || entity.Indicator.Kind != IndicatorKindEMP {
+			continue
+		}
+		progress := math.Min(entity.Age/entity.Lifetime, 1)
+		radius := entity.Radius * r.camera.Zoom * (0.3 + 0.7*progress)
+		sx, sy := r.camera.WorldToScreen(entity.X, entity.Y)
+		if sx < -radius || sx > r.camera.Width+radius || sy < -radius || sy > r.camera.Height+radius {
+			continue
+		}
+		r.shaders.AddShockwave(sx, sy, radius, ShockwaveStrength*(1-progress))
+	}
+}
+
+// renderPings draws the team pings of the player's faction (every ping without a player)
+// Pings off screen are pinned to the screen edge so teammates can find them
+func (r *Renderer) renderPings(screen *ebiten.Image, world *World, player *Entity) {
+	const edgeMargin = 20.0
+	for _, entity := range world.FX {
+		if !entity.Active || !isPingKind(entity.Indicator.Kind) {
+			continue
+		}
+		if player != nil && entity.Faction != player.Faction {
+			continue
+		}
+
+		clr, label := pingStyle(entity.Indicator.Kind)
+		// Fade out over the last quarter of the ping's life
+		if remaining := 1 - entity.Age/entity.Lifetime; remaining < 0.25 {
+			clr.A = uint8(255 * math.Max(remaining/0.25, 0))
+		}
+
+		sx, sy, offScreen := r.pinToScreenEdge(entity.X, entity.Y, edgeMargin)
+		if offScreen {
+			r.circleCount++
+			r.drawCallCount++
+			vector.DrawFilledCircle(screen, float32(sx), float32(sy), 6, clr, true)
+			continue
+		}
+
+		// A diamond with a ring pulsing out of it, once a second
+		size := PingRadius * 0.4
+		pulse := entity.Age - math.Floor(entity.Age)
+		r.lineCount += 4
+		r.drawCallCount += 4
+		vector.StrokeLine(screen, float32(sx), float32(sy-size), float32(sx+size), float32(sy), 2, clr, true)
+		vector.StrokeLine(screen, float32(sx+size), float32(sy), float32(sx), float32(sy+size), 2, clr, true)
+		vector.StrokeLine(screen, float32(sx), float32(sy+size), float32(sx-size), float32(sy), 2, clr, true)
+		vector.StrokeLine(screen, float32(sx-size), float32(sy), float32(sx), float32(sy-size), 2, clr, true)
+		ring := clr
+		ring.A = uint8(float64(clr.A) * (1 - pulse))
+		r.circleCount++
+		r.drawCallCount++
+		vector.StrokeCircle(screen, float32(sx), float32(sy), float32(PingRadius*pulse), 2, ring, true)
+		r.drawText(screen, label, sx-r.measureText(label)/2, sy+size+4, clr)
+	}
+}
+
+// pingStyle returns the color and label of a team ping kind
+func pingStyle(kind IndicatorKind) (color.RGBA, string) {
+	switch kind {
+	case IndicatorKindPingAttack:
+		return color.RGBA{255, 80, 60, 255}, "ATTACK"
+	case IndicatorKindPingHelp:
+		return color.RGBA{255, 210, 60, 255}, "HELP"
+	default:
+		return color.RGBA{80, 255, 160, 255}, "LOOT"
+	}
+}
+
+// pinToScreenEdge returns the screen position of a world point, clamped to margin inside the screen edge
+// offScreen reports whether the point had to be clamped
+func (r *Renderer) pinToScreenEdge(x, y, margin float64) (sx, sy float64, offScreen bool) {
+	sx, sy = r.camera.WorldToScreen(x, y)
+	if sx >= 0 && sx <= r.camera.Width && sy >= 0 && sy <= r.camera.Height {
+		return sx, sy, false
+	}
+	sx = math.Max(margin, math.Min(r.camera.Width-margin, sx))
+	sy = math.Max(margin, math.Min(r.camera.Height-margin, sy))
+	return sx, sy, true
+}
+
+// drawSparks draws a few short electric arcs around a disabled ship
+// The arcs jump to new angles twenty times a second
+func (r *Renderer) drawSparks(screen *ebiten.Image, entity *Entity, sx, sy, radius float64) {
+	const sparks = 4
+	frame := uint64(entity.Age * 20)
+	for i := uint64(0); i < sparks; i++ {
+		// Cheap hash of the ship, frame and spark picks the arc's angle
+		hash := (entity.ID*0x9E3779B1 + frame*0x85EBCA6B + i*0xC2B2AE35) % 3600
+		angle := float64(hash) / 3600 * 2 * math.Pi
+		x1 := sx + math.Cos(angle)*radius*0.6
+		y1 := sy + math.Sin(angle)*radius*0.6
+		kink := angle + 0.5
+		x2 := sx + math.Cos(kink)*radius*1.2
+		y2 := sy + math.Sin(kink)*radius*1.2
+		x3 := sx + math.Cos(angle)*radius*1.6
+		y3 := sy + math.Sin(angle)*radius*1.6
+		r.lineCount += 2
+		r.drawCallCount += 2
+		vector.StrokeLine(screen, float32(x1), float32(y1), float32(x2), float32(y2), 1.5, color.RGBA{160, 230, 255, 255}, true)
+		vector.StrokeLine(screen, float32(x2), float32(y2), float32(x3), float32(y3), 1.5, color.RGBA{160, 230, 255, 255}, true)
+	}
+}
+
+// drawCloakShimmer draws the heat-haze ripple around a cloaked ship fading in or out of view
+// The ripple is strongest when the ship is barely visible
+func (r *Renderer) drawCloakShimmer(screen *ebiten.Image, entity *Entity, sx, sy, radius float64) {
+	strength := 1 - entity.Cloak.Visibility
+	for i := 0; i < 3; i++ {
+		phase := entity.Age*12 + float64(i)*2.1
+		ripple := radius + 3 + math.Sin(phase)*2*r.camera.Zoom + float64(i)*3*r.camera.Zoom
+		offsetX := math.Cos(phase*1.7) * 1.5 * r.camera.Zoom
+		alpha := uint8(160 * strength / float64(i+1))
+		r.circleCount++
+		r.drawCallCount++
+		vector.StrokeCircle(screen, float32(sx+offsetX), float32(sy), float32(ripple), 1, color.RGBA{alpha / 2, alpha, alpha, alpha}, true)
+	}
+}
+
 // drawDiamond draws a diamond shape rotated by the entity's rotation
 func (r *Renderer) drawDiamond(screen *ebiten.Image, x, y, radius, rotation float64, clr color.Color) {
 	// Diamond (square rotated 45 degrees) pointing forward
@@ -790,11 +1553,76 @@ func (r *Renderer) drawDiamond(screen *ebiten.Image, x, y, radius, rotation floa
 	}
 }
 
+// RenderCollisionWarnings outlines threats on a collision course with the player in red
+// Threats outside the viewport get a warning marker on the screen edge pointing towards them
+func (r *Renderer) RenderCollisionWarnings(screen *ebiten.Image, player *Entity, threats []*Entity) {
+	if player == nil || !player.Active {
+		return
+	}
+
+	warningColor := color.RGBA{255, 40, 40, 255}
+	edgeMargin := 20.0
+	centerX := r.camera.Width / 2
+	centerY := r.camera.Height / 2
+
+	for _, threat := range threats {
+		if !threat.Active || threat.Health <= 0 {
+			continue
+		}
+
+		sx, sy := r.camera.WorldToScreen(threat.X, threat.Y)
+		onScreen := sx >= 0 && sx <= r.camera.Width && sy >= 0 && sy <= r.camera.Height
+
+		if onScreen {
+			// Red outline around the threat
+			outlineRadius := math.Max(threat.Radius*r.camera.Zoom+4, 6)
+			r.circleCount++
+			r.drawCallCount++
+			vector.StrokeCircle(screen, float32(sx), float32(sy), float32(outlineRadius), 2, warningColor, true)
+			continue
+		}
+
+		// Off-screen: project the direction onto the screen edge
+		dx := sx - centerX
+		dy := sy - centerY
+		scaleX := math.Inf(1)
+		scaleY := math.Inf(1)
+		if dx != 0 {
+			scaleX = (centerX - edgeMargin) / math.Abs(dx)
+		}
+		if dy != 0 {
+			scaleY = (centerY - edgeMargin) / math.Abs(dy)
+		}
+		scale := math.Min(scaleX, scaleY)
+		markerX := centerX + dx*scale
+		markerY := centerY + dy*scale
+
+		// Draw a triangle pointing towards the threat
+		angle := math.Atan2(dy, dx)
+		size := 10.0
+		tipX := markerX + math.Cos(angle)*size
+		tipY := markerY + math.Sin(angle)*size
+		leftX := markerX + math.Cos(angle+2.5)*size
+		leftY := markerY + math.Sin(angle+2.5)*size
+		rightX := markerX + math.Cos(angle-2.5)*size
+		rightY := markerY + math.Sin(angle-2.5)*size
+
+		r.lineCount += 3
+		r.drawCallCount += 3
+		vector.StrokeLine(screen, float32(tipX), float32(tipY), float32(leftX), float32(leftY), 2, warningColor, true)
+		vector.StrokeLine(screen, float32(leftX), float32(leftY), float32(rightX), float32(rightY), 2, warningColor, true)
+		vector.StrokeLine(screen, float32(rightX), float32(rightY), float32(tipX), float32(tipY), 2, warningColor, true)
+	}
+}
+
 // RenderUI renders the user interface (score, FPS, restart message, etc.)
 func (r *Renderer) RenderUI(screen *ebiten.Image, player *Entity, score int, fps float64) {
 	// Always show score
 	scoreText := fmt.Sprintf("Score: %d", score)
 	r.drawText(screen, scoreText, 10, 30, color.RGBA{255, 255, 255, 255})
+	if r.Lives != nil {
+		r.drawText(screen, fmt.Sprintf("Lives: %d", r.Lives.Left), 30+r.measureText(scoreText), 30, color.RGBA{120, 255, 200, 255})
+	}
 
 	// Show FPS less frequently to reduce text rendering overhead (update every 0.2s worth of frames)
 	// Only update FPS text every ~12 frames at 60fps (0.2 seconds)
@@ -805,15 +1633,96 @@ func (r *Renderer) RenderUI(screen *ebiten.Image, player *Entity, score int, fps
 	}
 	r.drawText(screen, r.cachedFPSText, 10, 50, color.RGBA{200, 200, 200, 255})
 
+	// Show reduced game speed so it isn't mistaken for lag
+	if speed := GetSettings().GameSpeed; speed < 1.0 {
+		speedText := fmt.Sprintf("Speed: %.0f%%", speed*100)
+		r.drawText(screen, speedText, r.camera.Width-r.measureText(speedText)-10, 30, color.RGBA{200, 200, 200, 255})
+	}
+
+	// Show tractor beam energy while it's draining or recharging
+	if player != nil && player.Active {
+		for i := range player.Turrets {
+			turret := &player.Turrets[i]
+			tractor := GetWeaponConfig(turret.Mount.WeaponType).Tractor
+			if tractor == nil || turret.Destroyed || turret.Energy >= tractor.Energy {
+				continue
+			}
+			energyText := fmt.Sprintf("Tractor: %.0f%%", turret.Energy/tractor.Energy*100)
+			r.drawText(screen, energyText, r.camera.Width-r.measureText(energyText)-10, 50, color.RGBA{120, 200, 255, 255})
+			break
+		}
+	}
+
 	// Show player coordinates
 	if player != nil && player.Active {
 		coordText := fmt.Sprintf("Position: (%.0f, %.0f)", player.X, player.Y)
 		r.drawText(screen, coordText, 10, 70, color.RGBA{200, 200, 200, 255})
 	}
 
-	// Show restart message if player is dead
+	// Status lines stack on the left below the coordinates (the kill feed takes the right side)
+	statusY := 90.0
+
+	// Show the safe zone timer, and a warning while the player is outside it
+	if r.world != nil && r.world.SafeZone != nil {
+		zone := r.world.SafeZone
+		zoneText := fmt.Sprintf("Zone %d: shrinking in %.0fs", zone.Phase, zone.TimeUntilShrink())
+		if zone.FinalZone() {
+			zoneText = fmt.Sprintf("Final zone: %.0f dmg/s outside", zone.DamagePerSecond())
+		} else if zone.Shrinking() {
+			zoneText = fmt.Sprintf("Zone %d: shrinking", zone.Phase)
+		}
+		r.drawText(screen, zoneText, 10, statusY, color.RGBA{80, 160, 255, 255})
+		statusY += 20
+		if player != nil && player.Active && !zone.Contains(player.X, player.Y) {
+			warning := fmt.Sprintf("OUTSIDE THE ZONE - %.0f damage/s", zone.DamagePerSecond())
+			r.drawText(screen, warning, (r.camera.Width-r.measureText(warning))/2, 110, color.RGBA{255, 80, 80, 255})
+		}
+	}
+
+	// Show the sentry turrets left to deploy
+	if player != nil && player.Active && player.Sentries > 0 {
+		r.drawText(screen, fmt.Sprintf("Sentries [B]: %d", player.Sentries), 10, statusY, color.RGBA{0, 255, 0, 255})
+		statusY += 20
+	}
+
+	// Show the player's standing with the factions that take sides, once it has changed
+	if r.Reputation != nil {
+		for faction := Faction(0); faction < factionCount; faction++ {
+			if !HasReputation(faction) || r.Reputation[faction] == 0 {
+				continue
+			}
+			reputationText, reputationColor := r.Reputation.reputationText(faction)
+			r.drawText(screen, reputationText, 10, statusY, reputationColor)
+			statusY += 20
+		}
+	}
+
+	// Warn while an EMP has the player's systems down
+	if player != nil && player.Active && player.Status.IsDisabled() {
+		warning := fmt.Sprintf("SYSTEMS DISABLED - %.1fs", player.Status.Disabled)
+		r.drawText(screen, warning, (r.camera.Width-r.measureText(warning))/2, 130, color.RGBA{120, 220, 255, 255})
+	}
+
+	// Count down the respawn invulnerability, with a ring around the ship
+	if player != nil && player.Active && player.Status.IsInvulnerable() {
+		r.drawWorldCircle(screen, player.X, player.Y, player.Radius*2, 2, color.RGBA{120, 255, 200, 160})
+		notice := fmt.Sprintf("INVULNERABLE - %.1fs", player.Status.Invulnerable)
+		r.drawText(screen, notice, (r.camera.Width-r.measureText(notice))/2, 150, color.RGBA{120, 255, 200, 255})
+	}
+
+	// Show restart message if player is dead (the death cam and the continue screen replace it while lives are on)
+	if r.Lives != nil && player != nil && (!player.Active || player.Health <= 0) {
+		r.renderLivesScreen(screen)
+		return
+	}
 	if player == nil || !player.Active || player.Health <= 0 {
-		restartText := "[R] to Restart"
+		restartText := "[R] to Restart - [Y] to Retry the same seed"
+		if r.world != nil && r.world.Config.Respawn.Mode == RespawnInPlace {
+			restartText = fmt.Sprintf("[R] to Respawn (-%.0f%% score) - [Y] to Retry the same seed", r.world.Config.Respawn.ScorePenalty*100)
+		}
+		if r.Checkpoint != nil {
+			restartText += fmt.Sprintf(" - [K] Checkpoint (wave %d)", r.Checkpoint.Wave)
+		}
 		textWidth := r.measureText(restartText)
 		textX := (r.camera.Width - textWidth) / 2
 		textY := r.camera.Height / 2
@@ -821,6 +1730,653 @@ func (r *Renderer) RenderUI(screen *ebiten.Image, player *Entity, score int, fps
 	}
 }
 
+// renderLivesScreen shows the respawn countdown during the death cam, or the continue screen when out of lives
+func (r *Renderer) renderLivesScreen(screen *ebiten.Image) {
+	lines := []string{fmt.Sprintf("Ship lost - respawning in %.1fs (%d left)", r.Lives.DeathCam, r.Lives.Left)}
+	if r.Lives.OutOfLives {
+		lines = []string{"OUT OF LIVES", "[Enter] Continue (score resets)"}
+		if r.Checkpoint != nil {
+			lines = append(lines, fmt.Sprintf("[K] Restart from the wave %d checkpoint", r.Checkpoint.Wave))
+		}
+		lines = append(lines, "[R] New run - [Y] Retry the same seed")
+	}
+	y := r.camera.Height / 2
+	for i, line := range lines {
+		clr := color.RGBA{255, 255, 0, 255}
+		if i == 0 && r.Lives.OutOfLives {
+			clr = color.RGBA{255, 90, 90, 255}
+		}
+		r.drawText(screen, line, (r.camera.Width-r.measureText(line))/2, y, clr)
+		y += 22
+	}
+}
+
+// renderSafeZone draws the current safe zone edge and, until the final zone, a preview of the next one
+func (r *Renderer) renderSafeZone(screen *ebiten.Image, zone *SafeZone) {
+	if !zone.FinalZone() {
+		r.drawWorldCircle(screen, zone.NextX, zone.NextY, zone.NextRadius, 1, color.RGBA{255, 255, 255, 90})
+	}
+	r.drawWorldCircle(screen, zone.X, zone.Y, zone.Radius, 3, color.RGBA{80, 160, 255, 220})
+}
+
+// drawWorldCircle strokes a circle given in world coordinates, skipping off-screen segments
+// Zone circles are far larger than a texture, so they are drawn as segments straight onto the screen
+func (r *Renderer) drawWorldCircle(screen *ebiten.Image, x, y, radius, width float64, clr color.RGBA) {
+	const segments = 256
+	margin := width + 2
+	prevX, prevY := r.camera.WorldToScreen(x+radius, y)
+	for i := 1; i <= segments; i++ {
+		angle := float64(i) / segments * 2 * math.Pi
+		sx, sy := r.camera.WorldToScreen(x+math.Cos(angle)*radius, y+math.Sin(angle)*radius)
+		offScreen := (prevX < -margin && sx < -margin) || (prevX > r.camera.Width+margin && sx > r.camera.Width+margin) ||
+			(prevY < -margin && sy < -margin) || (prevY > r.camera.Height+margin && sy > r.camera.Height+margin)
+		if !offScreen {
+			vector.StrokeLine(screen, float32(prevX), float32(prevY), float32(sx), float32(sy), float32(width), clr, true)
+			r.lineCount++
+			r.drawCallCount++
+		}
+		prevX, prevY = sx, sy
+	}
+}
+
+// RenderWeaponStats shows the per-weapon breakdown of the run below the restart message
+func (r *Renderer) RenderWeaponStats(screen *ebiten.Image, stats []WeaponStat) {
+	if len(stats) == 0 {
+		return
+	}
+
+	// The UI font is proportional, so each column is drawn at its own offset
+	columns := []float64{0, 160, 230, 290, 380, 460}
+	tableWidth := 510.0
+	x := (r.camera.Width - tableWidth) / 2
+	y := r.camera.Height/2 + 40
+
+	drawRow := func(cells []string, clr color.Color) {
+		for i, cell := range cells {
+			r.drawText(screen, cell, x+columns[i], y, clr)
+		}
+		y += 20
+	}
+
+	drawRow([]string{"Weapon", "Shots", "Hits", "Accuracy", "Damage", "Kills"}, color.RGBA{255, 255, 255, 255})
+	for _, stat := range stats {
+		drawRow([]string{
+			GetWeaponConfig(stat.Weapon).Name,
+			fmt.Sprintf("%d", stat.ShotsFired),
+			fmt.Sprintf("%d", stat.Hits),
+			fmt.Sprintf("%.0f%%", stat.Accuracy()*100),
+			fmt.Sprintf("%.0f", stat.Damage),
+			fmt.Sprintf("%d", stat.Kills),
+		}, color.RGBA{200, 200, 200, 255})
+	}
+}
+
+// RenderDeathRecap shows above the restart message what killed the player, the wave and score breakdown,
+// the hull and hits over the last RecapWindow seconds, the station purchases and the run's seed
+func (r *Renderer) RenderDeathRecap(screen *ebiten.Image, recap *DeathRecap, waveNumber, score int, seed int64) {
+	const panelWidth, graphWidth, graphHeight = 620.0, 300.0, 80.0
+	x := (r.camera.Width - panelWidth) / 2
+	y := r.camera.Height/2 - 250
+
+	cause := "Killed by a collision or the zone"
+	if recap.Killer != "" {
+		cause = fmt.Sprintf("Killed by %s [%s]", recap.Killer, GetWeaponConfig(recap.KillerWeapon).Name)
+	}
+	r.drawText(screen, cause, x, y, color.RGBA{255, 90, 90, 255})
+	seedText := fmt.Sprintf("Seed %d", seed)
+	r.drawText(screen, seedText, x+panelWidth-r.measureText(seedText), y, color.RGBA{200, 200, 200, 255})
+	y += 25
+
+	breakdown := ""
+	for source := ScoreSource(0); source < scoreSourceCount; source++ {
+		if recap.Score[source] > 0 {
+			breakdown += fmt.Sprintf(", %s %d", source, recap.Score[source])
+		}
+	}
+	if breakdown != "" {
+		breakdown = " (" + breakdown[2:] + ")"
+	}
+	r.drawText(screen, fmt.Sprintf("Wave %d - Score %d%s", waveNumber, score, breakdown), x, y, color.RGBA{255, 220, 100, 255})
+	y += 30
+
+	// Hull over the last seconds, with a bar per hit sized by its damage
+	r.drawText(screen, fmt.Sprintf("Last %.0fs", RecapWindow), x, y, color.RGBA{200, 200, 200, 255})
+	graphY := y + 22
+	vector.StrokeRect(screen, float32(x), float32(graphY), graphWidth, graphHeight, 1, color.RGBA{120, 120, 120, 255}, false)
+	start := recap.Time - RecapWindow
+	for _, hit := range recap.Hits {
+		if hit.Time < start {
+			continue
+		}
+		hitX := x + (hit.Time-start)/RecapWindow*graphWidth
+		barHeight := math.Min(hit.Amount/100, 1) * graphHeight
+		vector.StrokeLine(screen, float32(hitX), float32(graphY+graphHeight), float32(hitX), float32(graphY+graphHeight-barHeight), 2, color.RGBA{255, 80, 80, 200}, false)
+	}
+	samples := recap.HealthTimeline()
+	for i := 1; i < len(samples); i++ {
+		// The newest sample sits at the right edge
+		x1 := x + graphWidth - float64(len(samples)-i)*RecapSampleInterval/RecapWindow*graphWidth
+		x2 := x1 + RecapSampleInterval/RecapWindow*graphWidth
+		y1 := graphY + graphHeight*(1-samples[i-1])
+		y2 := graphY + graphHeight*(1-samples[i])
+		vector.StrokeLine(screen, float32(x1), float32(y1), float32(x2), float32(y2), 2, color.RGBA{100, 255, 100, 255}, true)
+	}
+
+	// Upgrades bought this run, newest last
+	listX := x + graphWidth + 30
+	r.drawText(screen, "Upgrades", listX, y, color.RGBA{200, 200, 200, 255})
+	if len(recap.Upgrades) == 0 {
+		r.drawText(screen, "none", listX, y+20, color.RGBA{150, 150, 150, 255})
+	}
+	const maxUpgrades = 5
+	upgrades := recap.Upgrades
+	if len(upgrades) > maxUpgrades {
+		upgrades = upgrades[len(upgrades)-maxUpgrades:]
+	}
+	for i, upgrade := range upgrades {
+		line := fmt.Sprintf("Wave %d: %s (%d)", upgrade.Wave, upgrade.Name, upgrade.Price)
+		r.drawText(screen, line, listX, y+20*float64(i+1), color.RGBA{150, 200, 255, 255})
+	}
+}
+
+// RenderRunGraphs draws line graphs of the run's samples along the bottom of the death screen:
+// score over time, and intensity (player kills per minute and live entities) over time
+func (r *Renderer) RenderRunGraphs(screen *ebiten.Image, samples []RunStatsSample) {
+	if len(samples) < 2 || samples[len(samples)-1].Time <= 0 {
+		return
+	}
+	const panelWidth, graphWidth, graphHeight = 620.0, 300.0, 80.0
+	x := (r.camera.Width - panelWidth) / 2
+	y := r.camera.Height - 130
+	duration := samples[len(samples)-1].Time
+
+	times := make([]float64, len(samples))
+	scores := make([]float64, len(samples))
+	entities := make([]float64, len(samples))
+	for i, sample := range samples {
+		times[i] = sample.Time
+		scores[i] = float64(sample.Score)
+		entities[i] = float64(sample.Entities)
+	}
+
+	// Kills per minute hold over each interval, so they are drawn as steps
+	killTimes := make([]float64, 0, 2*len(samples))
+	killRates := make([]float64, 0, 2*len(samples))
+	for i := 1; i < len(samples); i++ {
+		interval := samples[i].Time - samples[i-1].Time
+		if interval <= 0 {
+			continue
+		}
+		rate := float64(samples[i].Kills-samples[i-1].Kills) / interval * 60
+		killTimes = append(killTimes, samples[i-1].Time, samples[i].Time)
+		killRates = append(killRates, rate, rate)
+	}
+
+	minutes := int(duration) / 60
+	scoreColor := color.RGBA{255, 220, 100, 255}
+	r.drawText(screen, fmt.Sprintf("Score over %d:%02d (%d)", minutes, int(duration)-minutes*60, samples[len(samples)-1].Score), x, y, scoreColor)
+	r.drawGraphLine(screen, x, y+22, graphWidth, graphHeight, times, scores, duration, scoreColor)
+
+	intensityX := x + panelWidth - graphWidth
+	killColor := color.RGBA{255, 90, 90, 255}
+	entityColor := color.RGBA{120, 180, 255, 255}
+	killText := fmt.Sprintf("Kills/min (%.0f)", maxValue(killRates))
+	r.drawText(screen, killText, intensityX, y, killColor)
+	r.drawText(screen, fmt.Sprintf("Entities (%.0f)", maxValue(entities)), intensityX+r.measureText(killText)+15, y, entityColor)
+	r.drawGraphLine(screen, intensityX, y+22, graphWidth, graphHeight, times, entities, duration, entityColor)
+	r.drawGraphLine(screen, intensityX, y+22, graphWidth, graphHeight, killTimes, killRates, duration, killColor)
+}
+
+// drawGraphLine frames a graph and draws values over time inside it, scaled so the largest value
+// reaches the top and the duration spans the width
+func (r *Renderer) drawGraphLine(screen *ebiten.Image, x, y, width, height float64, times, values []float64, duration float64, clr color.RGBA) {
+	vector.StrokeRect(screen, float32(x), float32(y), float32(width), float32(height), 1, color.RGBA{120, 120, 120, 255}, false)
+	top := maxValue(values)
+	if top <= 0 {
+		top = 1
+	}
+	for i := 1; i < len(values); i++ {
+		x1 := x + times[i-1]/duration*width
+		x2 := x + times[i]/duration*width
+		y1 := y + height*(1-values[i-1]/top)
+		y2 := y + height*(1-values[i]/top)
+		vector.StrokeLine(screen, float32(x1), float32(y1), float32(x2), float32(y2), 2, clr, true)
+	}
+}
+
+// maxValue returns the largest of the values (0 if there are none)
+func maxValue(values []float64) float64 {
+	largest := 0.0
+	for _, value := range values {
+		largest = math.Max(largest, value)
+	}
+	return largest
+}
+
+// RenderStatsPanel draws the entity statistics debug panel on the left: live counts with the run's peaks
+// per entity type, ship type and faction, the projectile pool and the cell occupancy histogram
+func (r *Renderer) RenderStatsPanel(screen *ebiten.Image, panel *StatsPanel) {
+	const x, y, columnWidth, lineHeight = 10.0, 160.0, 200.0, 18.0
+	current, peak := &panel.Current, &panel.Peak
+	vector.DrawFilledRect(screen, float32(x-5), float32(y-5), float32(2*columnWidth+10), 20*lineHeight+10, color.RGBA{0, 0, 0, 160}, false)
+
+	header := color.RGBA{255, 220, 100, 255}
+	row := color.RGBA{200, 200, 200, 255}
+	lineX, lineY := x, y
+	line := func(text string, clr color.Color) {
+		r.drawText(screen, text, lineX, lineY, clr)
+		lineY += lineHeight
+	}
+	count := func(name string, value, peakValue int) {
+		line(fmt.Sprintf("  %s %d (peak %d)", name, value, peakValue), row)
+	}
+
+	// Left column: totals, entity types and factions
+	line(fmt.Sprintf("Entities %d (peak %d)", current.Total, peak.Total), header)
+	for entityType := EntityType(0); entityType < entityTypeCount; entityType++ {
+		count(entityTypeNames[entityType], current.Types[entityType], peak.Types[entityType])
+	}
+	line("Factions", header)
+	for faction := Faction(0); faction < factionCount; faction++ {
+		count(factionNames[faction], current.Factions[faction], peak.Factions[faction])
+	}
+	line(fmt.Sprintf("Projectile pool %d/%d (peak %d)", current.Projectiles, current.ProjectileCapacity, peak.Projectiles), header)
+
+	// Right column: ship types and cell occupancy
+	lineX, lineY = x+columnWidth, y
+	line("Ships", header)
+	for shipType := ShipType(0); shipType < ShipTypeCount; shipType++ {
+		count(GetShipTypeConfig(shipType).Name, current.Ships[shipType], peak.Ships[shipType])
+	}
+	line(fmt.Sprintf("Cells (busiest %d, peak %d)", current.BusiestCell, peak.BusiestCell), header)
+	for i, low := range cellOccupancyBuckets {
+		var name string
+		switch {
+		case i+1 == len(cellOccupancyBuckets):
+			name = fmt.Sprintf("%d+", low)
+		case cellOccupancyBuckets[i+1]-1 == low:
+			name = fmt.Sprintf("%d", low)
+		default:
+			name = fmt.Sprintf("%d-%d", low, cellOccupancyBuckets[i+1]-1)
+		}
+		count(name, current.Cells[i], peak.Cells[i])
+	}
+}
+
+// RenderKillFeed lists recent kills at the top right, fading out as they expire
+func (r *Renderer) RenderKillFeed(screen *ebiten.Image, entries []KillFeedEntry) {
+	y := 80.0
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		line := fmt.Sprintf("%s [%s] %s", entry.Killer, GetWeaponConfig(entry.Weapon).Name, entry.Victim)
+		if entry.Assists > 0 {
+			line += fmt.Sprintf(" +%d", entry.Assists)
+		}
+
+		// Fade over the last second
+		alpha := math.Min(KillFeedDuration-entry.Age, 1)
+		clr := color.RGBA{255, 220, 120, uint8(255 * alpha)}
+		r.drawText(screen, line, r.camera.Width-r.measureText(line)-10, y, clr)
+		y += 20
+	}
+}
+
+// RenderRadio lists recent radio messages at the bottom left, newest at the bottom, fading out as they expire
+// Each message shows its speaker over the line, next to the speaker's portrait when it has one
+func (r *Renderer) RenderRadio(screen *ebiten.Image, messages []RadioMessage) {
+	const rowHeight = 42.0
+	const portraitSize = 36.0
+	y := r.camera.Height - 100
+	for i := len(messages) - 1; i >= 0; i-- {
+		message := messages[i]
+		alpha := math.Min(RadioMessageDuration-message.Age, 1) // Fade over the last second
+
+		x := 10.0
+		if portrait := r.sprites.Image(message.Portrait); portrait != nil {
+			r.drawCallCount++
+			vector.DrawFilledRect(screen, float32(x), float32(y), portraitSize, portraitSize, color.RGBA{10, 10, 25, uint8(200 * alpha)}, false)
+			vector.StrokeRect(screen, float32(x), float32(y), portraitSize, portraitSize, 1, color.RGBA{message.Color.R, message.Color.G, message.Color.B, uint8(255 * alpha)}, false)
+			tint := color.RGBA{message.Color.R, message.Color.G, message.Color.B, uint8(255 * alpha)}
+			r.drawSprite(screen, portrait, x+portraitSize/2, y+portraitSize/2, portraitSize*0.35, -math.Pi/2, tint)
+			x += portraitSize + 8
+		}
+
+		speakerColor := message.Color
+		speakerColor.A = uint8(255 * alpha)
+		r.drawText(screen, message.Speaker, x, y, speakerColor)
+		r.drawText(screen, message.Text, x, y+18, color.RGBA{230, 230, 230, uint8(255 * alpha)})
+		y -= rowHeight
+	}
+}
+
+// RenderWaveClear draws the wave-clear bonus popup in the upper middle of the screen, fading over its last second
+func (r *Renderer) RenderWaveClear(screen *ebiten.Image, bonus *WaveClearBonus) {
+	alpha := uint8(255 * math.Min(WaveClearPopupDuration-bonus.Age, 1))
+	title := fmt.Sprintf("Wave %d cleared! +%d", bonus.Wave, bonus.Total())
+	y := r.camera.Height / 4
+	r.drawText(screen, title, (r.camera.Width-r.measureText(title))/2, y, color.RGBA{255, 215, 0, alpha})
+
+	minutes, seconds := int(bonus.Time)/60, int(bonus.Time)%60
+	detail := fmt.Sprintf("Time %d:%02d +%d    Damage taken %.0f +%d", minutes, seconds, bonus.TimeBonus, bonus.DamageTaken, bonus.DamageBonus)
+	r.drawText(screen, detail, (r.camera.Width-r.measureText(detail))/2, y+22, color.RGBA{220, 220, 220, alpha})
+}
+
+// RenderObjective draws the mission objective's health bar and destination in the world,
+// and the objective tracker at the top of the screen
+func (r *Renderer) RenderObjective(screen *ebiten.Image, mission *Mission, waveNumber int) {
+	objective := mission.Objective
+	health := math.Max(objective.Health, 0) / objective.MaxHealth
+	if mission.State == MissionActive {
+		// Objective health bar, always shown (unlike the damaged-entity bars)
+		sx, sy := r.camera.WorldToScreen(objective.X, objective.Y)
+		radius := objective.Radius * r.camera.Zoom
+		barWidth := math.Max(radius*2, 40)
+		barHeight := 5.0
+		barX := sx - barWidth/2
+		barY := sy - radius - barHeight - 6
+		vector.DrawFilledRect(screen, float32(barX), float32(barY), float32(barWidth), float32(barHeight), color.RGBA{100, 0, 0, 255}, true)
+		vector.DrawFilledRect(screen, float32(barX), float32(barY), float32(barWidth*health), float32(barHeight), color.RGBA{80, 200, 255, 255}, true)
+
+		if mission.Type == MissionEscort {
+			r.drawWorldCircle(screen, mission.DestX, mission.DestY, ConvoyArrivalDistance, 2, color.RGBA{80, 200, 255, 120})
+		}
+	}
+
+	var tracker string
+	clr := color.RGBA{80, 200, 255, 255}
+	switch {
+	case mission.State == MissionComplete:
+		tracker = "MISSION COMPLETE"
+		clr = color.RGBA{100, 255, 100, 255}
+	case mission.State == MissionFailed:
+		tracker = "MISSION FAILED - objective destroyed"
+		clr = color.RGBA{255, 80, 80, 255}
+	case mission.Type == MissionEscort:
+		distance := math.Hypot(mission.DestX-objective.X, mission.DestY-objective.Y)
+		tracker = fmt.Sprintf("ESCORT convoy %.0f%% - %.0f to destination", health*100, distance)
+	default:
+		wave := min(waveNumber-mission.StartWave+1, DefendWaves)
+		tracker = fmt.Sprintf("DEFEND station %.0f%% - wave %d/%d", health*100, wave, DefendWaves)
+	}
+	r.drawText(screen, tracker, (r.camera.Width-r.measureText(tracker))/2, 30, clr)
+}
+
+// RenderWaypoint marks the navigation waypoint, pinned to the screen edge with its distance when off screen,
+// and shows whether the autopilot is flying to it
+func (r *Renderer) RenderWaypoint(screen *ebiten.Image, waypoint *Waypoint, player *Entity, autopilot bool) {
+	clr := color.RGBA{255, 100, 255, 230}
+	sx, sy, offScreen := r.pinToScreenEdge(waypoint.X, waypoint.Y, 30)
+	size := 8.0
+	r.lineCount += 2
+	r.drawCallCount += 2
+	vector.StrokeLine(screen, float32(sx-size), float32(sy-size), float32(sx+size), float32(sy+size), 2, clr, true)
+	vector.StrokeLine(screen, float32(sx+size), float32(sy-size), float32(sx-size), float32(sy+size), 2, clr, true)
+	if offScreen && player != nil && player.Active {
+		label := fmt.Sprintf("%.0f", math.Hypot(waypoint.X-player.X, waypoint.Y-player.Y))
+		r.drawText(screen, label, sx-r.measureText(label)/2, sy+size+4, clr)
+	}
+
+	status := "[N] Autopilot"
+	if autopilot {
+		status = "AUTOPILOT ENGAGED - steer to take over"
+	}
+	r.drawText(screen, status, (r.camera.Width-r.measureText(status))/2, r.camera.Height-85, clr)
+}
+
+// RenderCompass draws the heading compass with the bearings to the waypoint and the nearest threat
+// Headings are world-cardinal: north is up the screen
+func (r *Renderer) RenderCompass(screen *ebiten.Image, style CompassStyle, player *Entity, waypoint *Waypoint, threat *Entity) {
+	waypointColor := color.RGBA{255, 100, 255, 230}
+	threatColor := color.RGBA{255, 70, 70, 230}
+	labelColor := color.RGBA{200, 220, 255, 200}
+
+	// bearing converts a screen-space angle (0 = east, clockwise) to a compass bearing (0 = north, clockwise)
+	bearing := func(angle float64) float64 {
+		return math.Mod(angle*180/math.Pi+90+360, 360)
+	}
+
+	if style == CompassTape {
+		const tapeWidth, tapeY = 400.0, 60.0
+		centerX := r.camera.Width / 2
+		heading := bearing(player.Rotation)
+		pixelsPerDegree := tapeWidth / CompassTapeSpan
+		// offset returns a bearing's x offset from the center of the tape (clamped to its ends)
+		offset := func(b float64) float64 {
+			delta := math.Remainder(b-heading, 360)
+			return math.Max(-tapeWidth/2, math.Min(tapeWidth/2, delta*pixelsPerDegree))
+		}
+
+		vector.StrokeLine(screen, float32(centerX-tapeWidth/2), float32(tapeY), float32(centerX+tapeWidth/2), float32(tapeY), 1, labelColor, true)
+		labels := [8]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+		for degrees := 0.0; degrees < 360; degrees += 15 {
+			delta := math.Remainder(degrees-heading, 360)
+			if math.Abs(delta) > CompassTapeSpan/2 {
+				continue
+			}
+			x := centerX + delta*pixelsPerDegree
+			tick := 4.0
+			if math.Mod(degrees, 45) == 0 {
+				tick = 8
+				label := labels[int(degrees)/45]
+				r.drawText(screen, label, x-r.measureText(label)/2, tapeY+10, labelColor)
+			}
+			vector.StrokeLine(screen, float32(x), float32(tapeY-tick), float32(x), float32(tapeY), 1, labelColor, true)
+		}
+		if waypoint != nil {
+			x := centerX + offset(bearing(math.Atan2(waypoint.Y-player.Y, waypoint.X-player.X)))
+			vector.DrawFilledCircle(screen, float32(x), float32(tapeY-12), 4, waypointColor, true)
+		}
+		if threat != nil {
+			x := centerX + offset(bearing(math.Atan2(threat.Y-player.Y, threat.X-player.X)))
+			vector.DrawFilledCircle(screen, float32(x), float32(tapeY-12), 4, threatColor, true)
+		}
+		// Heading caret and readout
+		vector.StrokeLine(screen, float32(centerX), float32(tapeY-14), float32(centerX), float32(tapeY+4), 2, color.RGBA{255, 255, 255, 255}, true)
+		readout := fmt.Sprintf("%03.0f", heading)
+		r.drawText(screen, readout, centerX-r.measureText(readout)/2, tapeY-34, color.RGBA{255, 255, 255, 255})
+		return
+	}
+
+	// Ring around the ship, with cardinal labels and markers on the rim
+	sx, sy := r.camera.WorldToScreen(player.X, player.Y)
+	radius := math.Max(CompassRingRadius, player.Radius*r.camera.Zoom+20)
+	vector.StrokeCircle(screen, float32(sx), float32(sy), float32(radius), 1, color.RGBA{200, 220, 255, 60}, true)
+	for i, label := range [4]string{"N", "E", "S", "W"} {
+		angle := float64(i)*math.Pi/2 - math.Pi/2
+		x := sx + math.Cos(angle)*(radius+12)
+		y := sy + math.Sin(angle)*(radius+12)
+		r.drawText(screen, label, x-r.measureText(label)/2, y-8, labelColor)
+	}
+	marker := func(angle float64, clr color.RGBA) {
+		x := sx + math.Cos(angle)*radius
+		y := sy + math.Sin(angle)*radius
+		vector.DrawFilledCircle(screen, float32(x), float32(y), 4, clr, true)
+	}
+	if waypoint != nil {
+		marker(math.Atan2(waypoint.Y-player.Y, waypoint.X-player.X), waypointColor)
+	}
+	if threat != nil {
+		marker(math.Atan2(threat.Y-player.Y, threat.X-player.X), threatColor)
+	}
+}
+
+// RenderWorldMap draws the full-screen map: explored cells, known stations, where enemies were last seen,
+// the mission objective, team pings, the player and the waypoint
+func (r *Renderer) RenderWorldMap(screen *ebiten.Image, m *WorldMap, world *World, player *Entity, mission *Mission, waypoint *Waypoint) {
+	width, height := r.camera.Width, r.camera.Height
+	toScreen := func(x, y float64) (float32, float32) {
+		sx, sy := m.WorldToScreen(x, y, width, height)
+		return float32(sx), float32(sy)
+	}
+	screen.Fill(color.RGBA{5, 5, 15, 255})
+
+	// Explored cells (only the ones on screen)
+	cellSize := MapCellSize * m.Scale
+	for cell := range m.Explored {
+		sx, sy := m.WorldToScreen(float64(cell.X)*MapCellSize, float64(cell.Y)*MapCellSize, width, height)
+		if sx > width || sy > height || sx+cellSize < 0 || sy+cellSize < 0 {
+			continue
+		}
+		vector.DrawFilledRect(screen, float32(sx), float32(sy), float32(cellSize), float32(cellSize), color.RGBA{25, 40, 65, 255}, false)
+	}
+
+	// Enemy concentrations, growing with the number of ships seen
+	for cell, count := range m.Enemies {
+		sx, sy := toScreen((float64(cell.X)+0.5)*MapCellSize, (float64(cell.Y)+0.5)*MapCellSize)
+		radius := math.Min(math.Max(cellSize*0.1, 3)*math.Sqrt(float64(count)), cellSize/2)
+		vector.DrawFilledCircle(screen, sx, sy, float32(radius), color.RGBA{255, 60, 60, 110}, true)
+	}
+
+	// Known stations: green with a shop, grey when derelict
+	for _, station := range m.Stations {
+		sx, sy := toScreen(station.X, station.Y)
+		clr := color.RGBA{150, 150, 150, 255}
+		if station.Shop {
+			clr = color.RGBA{100, 255, 100, 255}
+		}
+		vector.DrawFilledRect(screen, sx-4, sy-4, 8, 8, clr, false)
+	}
+
+	// Mission objective (and the convoy's destination)
+	if mission != nil && mission.State == MissionActive {
+		objectiveColor := color.RGBA{80, 200, 255, 255}
+		if mission.Type == MissionEscort {
+			sx, sy := toScreen(mission.DestX, mission.DestY)
+			vector.StrokeCircle(screen, sx, sy, float32(math.Max(ConvoyArrivalDistance*m.Scale, 6)), 2, objectiveColor, true)
+		}
+		sx, sy := toScreen(mission.Objective.X, mission.Objective.Y)
+		vector.DrawFilledCircle(screen, sx, sy, 5, objectiveColor, true)
+	}
+
+	// Team pings
+	for _, entity := range world.FX {
+		if !entity.Active || !isPingKind(entity.Indicator.Kind) || (player != nil && entity.Faction != player.Faction) {
+			continue
+		}
+		clr, _ := pingStyle(entity.Indicator.Kind)
+		sx, sy := toScreen(entity.X, entity.Y)
+		vector.StrokeCircle(screen, sx, sy, 6, 2, clr, true)
+	}
+
+	// Waypoint, with a line from the player
+	waypointColor := color.RGBA{255, 100, 255, 255}
+	if waypoint != nil {
+		sx, sy := toScreen(waypoint.X, waypoint.Y)
+		if player != nil && player.Active {
+			px, py := toScreen(player.X, player.Y)
+			vector.StrokeLine(screen, px, py, sx, sy, 1, color.RGBA{255, 100, 255, 120}, true)
+		}
+		vector.StrokeLine(screen, sx-6, sy-6, sx+6, sy+6, 2, waypointColor, true)
+		vector.StrokeLine(screen, sx+6, sy-6, sx-6, sy+6, 2, waypointColor, true)
+	}
+
+	// Player, with a heading tick
+	if player != nil && player.Active {
+		sx, sy := toScreen(player.X, player.Y)
+		vector.DrawFilledCircle(screen, sx, sy, 4, color.RGBA{255, 255, 255, 255}, true)
+		hx := sx + float32(math.Cos(player.Rotation)*10)
+		hy := sy + float32(math.Sin(player.Rotation)*10)
+		vector.StrokeLine(screen, sx, sy, hx, hy, 2, color.RGBA{255, 255, 255, 255}, true)
+	}
+
+	r.drawText(screen, fmt.Sprintf("WORLD MAP - %.0f across", width/m.Scale), 20, 20, color.RGBA{255, 255, 255, 255})
+
+	// Standing with the factions that take sides
+	reputationY := 45.0
+	for faction := Faction(0); faction < factionCount; faction++ {
+		if HasReputation(faction) {
+			reputationText, reputationColor := world.Reputation.reputationText(faction)
+			r.drawText(screen, reputationText, 20, reputationY, reputationColor)
+			reputationY += 20
+		}
+	}
+	hint := "WASD pan, Q/E or wheel zoom, click sets waypoint, right click clears, M closes"
+	r.drawText(screen, hint, (width-r.measureText(hint))/2, height-30, color.RGBA{255, 255, 255, 200})
+}
+
+// RenderPhotoModeHint shows photo mode controls at the bottom of the screen
+func (r *Renderer) RenderPhotoModeHint(screen *ebiten.Image) {
+	hint := "PHOTO MODE - WASD pan, Q/E zoom, H hide UI, G glow, F12 screenshot, P exit"
+	textWidth := r.measureText(hint)
+	r.drawText(screen, hint, (r.camera.Width-textWidth)/2, r.camera.Height-30, color.RGBA{255, 255, 255, 200})
+}
+
+// RenderModMenu lists installed mods with their enabled state and load errors
+func (r *Renderer) RenderModMenu(screen *ebiten.Image, mods []*Mod) {
+	x, y := 40.0, 120.0
+	r.drawText(screen, "MODS - number keys toggle, F6 closes (changes apply on restart)", x, y, color.RGBA{255, 255, 255, 255})
+	if len(mods) == 0 {
+		r.drawText(screen, "No mods installed", x, y+25, color.RGBA{200, 200, 200, 255})
+		return
+	}
+
+	for i, mod := range mods {
+		y += 25
+		mark := " "
+		if mod.Enabled {
+			mark = "x"
+		}
+		line := fmt.Sprintf("%d [%s] %s %s", i+1, mark, mod.Manifest.Name, mod.Manifest.Version)
+		clr := color.RGBA{200, 200, 200, 255}
+		if mod.Err != nil {
+			line += fmt.Sprintf(" - failed: %v", mod.Err)
+			clr = color.RGBA{255, 100, 100, 255}
+		}
+		r.drawText(screen, line, x, y, clr)
+	}
+}
+
+// RenderLoadoutMenu lists the player's turrets with their targeting policies
+func (r *Renderer) RenderLoadoutMenu(screen *ebiten.Image, player *Entity, loadout *Loadout) {
+	x, y := 40.0, 120.0
+	r.drawText(screen, "LOADOUT - number keys cycle turret targeting, F7 closes", x, y, color.RGBA{255, 255, 255, 255})
+	if player == nil || len(player.Turrets) == 0 {
+		r.drawText(screen, "No turrets", x, y+25, color.RGBA{200, 200, 200, 255})
+		return
+	}
+
+	for i := range player.Turrets {
+		y += 25
+		turret := &player.Turrets[i]
+		line := fmt.Sprintf("%d %s: %s", i+1, GetWeaponConfig(turret.Mount.WeaponType).Name, loadout.TurretPriority(i))
+		clr := color.RGBA{200, 200, 200, 255}
+		if !turret.IsOperational() {
+			line += " (disabled)"
+			clr = color.RGBA{255, 100, 100, 255}
+		}
+		r.drawText(screen, line, x, y, clr)
+	}
+}
+
+// RenderDockHint tells the player a station is in docking range
+func (r *Renderer) RenderDockHint(screen *ebiten.Image) {
+	hint := "[E] Dock"
+	textWidth := r.measureText(hint)
+	r.drawText(screen, hint, (r.camera.Width-textWidth)/2, r.camera.Height-60, color.RGBA{255, 255, 255, 220})
+}
+
+// RenderShop lists the docked station's inventory with current prices
+func (r *Renderer) RenderShop(screen *ebiten.Image, station *Entity, player *Entity, credits int) {
+	x, y := 40.0, 120.0
+	r.drawText(screen, "STATION - number keys buy, E undocks", x, y, color.RGBA{255, 255, 255, 255})
+	y += 25
+	r.drawText(screen, fmt.Sprintf("Credits: %d", credits), x, y, color.RGBA{255, 220, 100, 255})
+
+	for i, item := range station.Station.Inventory {
+		y += 25
+		price, ok := ShopItemPrice(item, player)
+		line := fmt.Sprintf("%d %s: %d", i+1, ShopItemName(item), price)
+		clr := color.RGBA{200, 200, 200, 255}
+		if !ok {
+			line = fmt.Sprintf("%d %s: -", i+1, ShopItemName(item))
+			clr = color.RGBA{120, 120, 120, 255}
+		} else if price > credits {
+			clr = color.RGBA{255, 100, 100, 255}
+		}
+		r.drawText(screen, line, x, y, clr)
+	}
+}
+
 // drawText draws text on the screen
 func (r *Renderer) drawText(screen *ebiten.Image, str string, x, y float64, clr color.Color) {
 	op := &text.DrawOptions{}
@@ -0,0 +1,173 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+const (
+	// ReputationMin and ReputationMax bound the player's standing with a faction
+	ReputationMin = -100.0
+	ReputationMax = 100.0
+
+	// ReputationHostileThreshold is the standing below which a faction's patrols attack the player
+	ReputationHostileThreshold = -20.0
+
+	// ReputationFriendlyThreshold is the standing from which a faction's patrols fight the player's enemies
+	ReputationFriendlyThreshold = 40.0
+
+	// ReputationDamagePenalty is the standing lost for shooting a faction's ship, per ship's worth of damage
+	ReputationDamagePenalty = 30.0
+
+	// ReputationKillPenalty is the standing lost for destroying a faction's ship
+	ReputationKillPenalty = 15.0
+
+	// ReputationHelpReward is the standing gained for destroying an enemy near one of the faction's ships
+	ReputationHelpReward = 4.0
+
+	// ReputationWitnessRadius is how close to an enemy's wreck a faction's ship must be for the kill to count as help (pixels)
+	ReputationWitnessRadius = 1000.0
+)
+
+// Stance is how a faction's ships treat the player, following the player's reputation with it
+type Stance int
+
+const (
+	StanceNeutral  Stance = iota // Mind their own business
+	StanceHostile                // Attack the player and its allies
+	StanceFriendly               // Fight the player's enemies
+)
+
+// Reputation is the player's standing with each faction, from ReputationMin to ReputationMax
+// Only factions with a reputation (see HasReputation) are tracked; the others are always allies or always enemies
+type Reputation [factionCount]float64
+
+// HasReputation returns true if the player's standing with faction changes how its ships behave
+func HasReputation(faction Faction) bool {
+	return faction == FactionIndependent
+}
+
+// Adjust changes the standing with faction by amount (factions without a reputation are left alone)
+func (r *Reputation) Adjust(faction Faction, amount float64) {
+	if !HasReputation(faction) {
+		return
+	}
+	r[faction] = math.Max(ReputationMin, math.Min(r[faction]+amount, ReputationMax))
+}
+
+// Sanitize clamps every standing into range and clears factions without a reputation (e.g. after loading a checkpoint file)
+func (r *Reputation) Sanitize() {
+	for faction := range r {
+		if !HasReputation(Faction(faction)) || math.IsNaN(r[faction]) {
+			r[faction] = 0
+			continue
+		}
+		r[faction] = math.Max(ReputationMin, math.Min(r[faction], ReputationMax))
+	}
+}
+
+// Stance returns how faction's ships treat the player at the current standing
+func (r *Reputation) Stance(faction Faction) Stance {
+	switch {
+	case !HasReputation(faction):
+		return StanceNeutral
+	case r[faction] < ReputationHostileThreshold:
+		return StanceHostile
+	case r[faction] >= ReputationFriendlyThreshold:
+		return StanceFriendly
+	default:
+		return StanceNeutral
+	}
+}
+
+// Peaceful returns true if faction's ships have a reputation and aren't hostile: the player's side leaves them alone
+func (r *Reputation) Peaceful(faction Faction) bool {
+	return HasReputation(faction) && r.Stance(faction) != StanceHostile
+}
+
+// TargetFaction returns the faction the AI ships of faction attack, and false if they attack no one
+// Factions with a reputation take sides following the player's standing with them
+func (r *Reputation) TargetFaction(faction Faction) (Faction, bool) {
+	if !HasReputation(faction) {
+		return GetOppositeFaction(faction), true
+	}
+	switch r.Stance(faction) {
+	case StanceHostile:
+		return FactionPlayer, true
+	case StanceFriendly:
+		return FactionEnemy, true
+	default:
+		return faction, false
+	}
+}
+
+// stanceNames and stanceColors label a stance on the HUD and the world map
+var (
+	stanceNames  = [...]string{StanceNeutral: "Neutral", StanceHostile: "Hostile", StanceFriendly: "Friendly"}
+	stanceColors = [...]color.RGBA{
+		StanceNeutral:  {200, 200, 200, 255},
+		StanceHostile:  {255, 80, 80, 255},
+		StanceFriendly: {100, 255, 100, 255},
+	}
+)
+
+// reputationText returns the player's standing with faction as shown on the HUD and the map, with its color
+func (r *Reputation) reputationText(faction Faction) (string, color.RGBA) {
+	stance := r.Stance(faction)
+	return fmt.Sprintf("%s: %s (%+.0f)", factionNames[faction], stanceNames[stance], r[faction]), stanceColors[stance]
+}
+
+// adjustReputation changes the player's standing with faction, which hails the player when its stance changes
+func (g *Game) adjustReputation(faction Faction, amount float64) {
+	reputation := &g.world.Reputation
+	before := reputation.Stance(faction)
+	reputation.Adjust(faction, amount)
+	stance := reputation.Stance(faction)
+	if stance == before {
+		return
+	}
+	cue := RadioCueFactionNeutral
+	switch stance {
+	case StanceHostile:
+		cue = RadioCueFactionHostile
+	case StanceFriendly:
+		cue = RadioCueFactionFriendly
+	}
+	g.radio.Say(cue, factionNames[faction], "", GetFactionConfig(faction).Color)
+}
+
+// onReputationDamage lowers the player's standing with a faction whose ship the player's side shot
+func (g *Game) onReputationDamage(event Event) {
+	target := event.Target
+	if event.SourceFaction != FactionPlayer || target == nil || !HasReputation(target.Faction) || target.MaxHealth <= 0 {
+		return
+	}
+	g.adjustReputation(target.Faction, -event.Amount/target.MaxHealth*ReputationDamagePenalty)
+}
+
+// onReputationKill lowers the player's standing with a faction whose ship the player's side destroyed,
+// and raises it with every faction that had a ship near an enemy the player's side destroyed
+func (g *Game) onReputationKill(event Event) {
+	target := event.Target
+	if event.SourceFaction != FactionPlayer || target == nil {
+		return
+	}
+	if HasReputation(target.Faction) {
+		g.adjustReputation(target.Faction, -ReputationKillPenalty)
+		return
+	}
+	if target.Faction != FactionEnemy {
+		return
+	}
+	var helped [factionCount]bool
+	for _, witness := range g.world.QueryTargetCandidates(target.X, target.Y, ReputationWitnessRadius) {
+		if !witness.Active || witness.Health <= 0 || witness.Type != EntityTypeEnemy || !HasReputation(witness.Faction) || helped[witness.Faction] {
+			continue
+		}
+		if dx, dy := witness.X-target.X, witness.Y-target.Y; dx*dx+dy*dy <= ReputationWitnessRadius*ReputationWitnessRadius {
+			helped[witness.Faction] = true
+			g.adjustReputation(witness.Faction, ReputationHelpReward)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// RespawnDistance is how far from where the player died the safe spots are looked for (pixels)
+	RespawnDistance = 1500.0
+
+	// RespawnSafeRadius is the radius around a candidate spot in which enemies count against it (pixels)
+	RespawnSafeRadius = 1200.0
+
+	// respawnCandidates is the number of spots tried around the death position
+	respawnCandidates = 8
+)
+
+// RespawnMode selects what happens when the player respawns after dying
+type RespawnMode int
+
+const (
+	RespawnRestart RespawnMode = iota // Start a new run from wave 1
+	RespawnInPlace                    // Come back in the same run, with a penalty
+	respawnModeCount
+)
+
+// respawnModeNames are the names used by the -respawn flag
+var respawnModeNames = [respawnModeCount]string{"restart", "in-place"}
+
+// String returns the mode's flag name
+func (m RespawnMode) String() string {
+	if m < 0 || m >= respawnModeCount {
+		return respawnModeNames[RespawnRestart]
+	}
+	return respawnModeNames[m]
+}
+
+// ParseRespawnMode parses a respawn mode name ("restart" or "in-place")
+func ParseRespawnMode(name string) (RespawnMode, error) {
+	for mode, modeName := range respawnModeNames {
+		if name == modeName {
+			return RespawnMode(mode), nil
+		}
+	}
+	return RespawnRestart, fmt.Errorf("unknown respawn mode %q", name)
+}
+
+// RespawnConfig configures respawning after death
+type RespawnConfig struct {
+	Mode RespawnMode
+
+	// Invulnerability is how long a ship respawned in place can't be damaged (seconds)
+	Invulnerability float64
+
+	// ScorePenalty is the share of the score lost on an in-place respawn (0-1)
+	ScorePenalty float64
+
+	// KeepUpgrades carries purchased weapon modules, sensor upgrades, drones and sentries over to the new ship
+	// (otherwise it comes back stock)
+	KeepUpgrades bool
+}
+
+// DefaultRespawnConfig returns the classic behavior: dying ends the run
+// The in-place values apply when the mode is switched to RespawnInPlace
+func DefaultRespawnConfig() RespawnConfig {
+	return RespawnConfig{
+		Mode:            RespawnRestart,
+		Invulnerability: 3.0,
+		ScorePenalty:    0.25,
+		KeepUpgrades:    false,
+	}
+}
+
+// respawnInPlace brings the dead player back in the current run: a new ship at a safe spot, briefly invulnerable,
+// minus scorePenalty (a share of the score). The world, wave progress and the run's statistics carry on
+func (g *Game) respawnInPlace(scorePenalty float64) {
+	old := g.player
+	respawn := g.config.Respawn
+
+	penalty := int(float64(g.score) * scorePenalty)
+	g.score -= penalty
+
+	g.spawnPlayerShip(g.safeRespawnSpot(old.X, old.Y))
+	g.player.Status.Protect(respawn.Invulnerability)
+	if respawn.KeepUpgrades {
+		carryOverUpgrades(old, g.player)
+	}
+
+	// Orbs still flying to the wreck go to the new ship
+	for _, entity := range g.world.AllEntities {
+		if entity.Type == EntityTypeXP && entity.Pickup.Target == old {
+			entity.Pickup.Target = g.player
+		}
+	}
+
+	g.recap.Revive()
+	g.runStats.Revive()
+	fmt.Printf("Respawned in place on wave %d, lost %d score\n", g.waveNumber, penalty)
+}
+
+// safeRespawnSpot returns the spot around (x, y), RespawnDistance away, with the fewest enemy ships nearby
+// (ties go to the first direction tried, so the choice doesn't depend on the random numbers)
+func (g *Game) safeRespawnSpot(x, y float64) (float64, float64) {
+	bestX, bestY, bestThreats := x, y, math.MaxInt
+	for i := 0; i < respawnCandidates; i++ {
+		angle := 2 * math.Pi * float64(i) / respawnCandidates
+		spotX := math.Max(g.config.WorldMinX, math.Min(x+math.Cos(angle)*RespawnDistance, g.config.WorldMinX+g.config.WorldWidth))
+		spotY := math.Max(g.config.WorldMinY, math.Min(y+math.Sin(angle)*RespawnDistance, g.config.WorldMinY+g.config.WorldHeight))
+		threats := 0
+		for _, entity := range g.world.GetEntitiesInRadius(spotX, spotY, RespawnSafeRadius) {
+			if entity.Type == EntityTypeEnemy && entity.Active && entity.Health > 0 {
+				threats++
+			}
+		}
+		if threats < bestThreats {
+			bestX, bestY, bestThreats = spotX, spotY, threats
+		}
+	}
+	return bestX, bestY
+}
+
+// carryOverUpgrades gives the new ship the old one's weapon modules, sensor and salvage beam upgrades, drones
+// and sentry charges
+func carryOverUpgrades(old, ship *Entity) {
+	ship.Sensors = old.Sensors
+	ship.SalvageBeam = old.SalvageBeam
+	ship.Drones = old.Drones
+	ship.Sentries = old.Sentries
+	equipWeapons(ship, shipWeapons(old))
+}
+
+// shipWeapons returns the weapon of each of the ship's turrets
+func shipWeapons(ship *Entity) []WeaponType {
+	weapons := make([]WeaponType, len(ship.Turrets))
+	for i := range ship.Turrets {
+		weapons[i] = ship.Turrets[i].Mount.WeaponType
+	}
+	return weapons
+}
+
+// equipWeapons mounts the weapons on the ship's turrets in order, with full tractor energy
+func equipWeapons(ship *Entity, weapons []WeaponType) {
+	for i := range ship.Turrets {
+		if i >= len(weapons) {
+			break
+		}
+		ship.Turrets[i].Mount.WeaponType = weapons[i]
+		if tractor := GetWeaponConfig(weapons[i]).Tractor; tractor != nil {
+			ship.Turrets[i].Energy = tractor.Energy
+		}
+	}
+}
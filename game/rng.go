@@ -0,0 +1,23 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// runRNG drives every random choice of the simulation (spawns, loot, elites, AI quirks), so a run replays
+// from its seed given the same inputs and timestep
+// It is only used from the game loop; sector generation rolls everything it places from its own per-sector
+// sources, and plans are applied on fixed ticks (see sectors.go)
+// Config lookups (GetShipTypeConfig and the like) never draw from it: drawing code calls them every frame
+var runRNG = rand.New(rand.NewSource(1))
+
+// seedRun reseeds the simulation's random numbers and returns the seed used (0 picks a fresh one)
+func seedRun(seed int64) int64 {
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	runRNG.Seed(seed)
+	fmt.Printf("Run seed: %d\n", seed)
+	return seed
+}
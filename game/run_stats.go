@@ -0,0 +1,78 @@
+package game
+
+import "fmt"
+
+// RunStatsInterval is the time between two run statistics samples (seconds)
+const RunStatsInterval = 60.0
+
+// RunStatsSample is a snapshot of the run taken every RunStatsInterval
+type RunStatsSample struct {
+	Time     float64 // Run time in seconds
+	Score    int
+	Kills    int // Player kills so far
+	Entities int
+	FPS      float64
+}
+
+// RunStats samples the run once a minute for the graphs on the death screen, logging every sample
+// The first sample is taken when the run starts and the last one when the player dies
+type RunStats struct {
+	Samples []RunStatsSample
+
+	// Player kills so far
+	Kills int
+
+	time  float64
+	timer float64
+	dead  bool
+}
+
+// NewRunStats creates empty run statistics
+func NewRunStats() *RunStats {
+	return &RunStats{Samples: make([]RunStatsSample, 0, 32)}
+}
+
+// Reset clears the statistics (a new run)
+func (s *RunStats) Reset() {
+	*s = RunStats{Samples: s.Samples[:0]}
+}
+
+// Revive resumes sampling after the player respawned in the same run
+func (s *RunStats) Revive() {
+	s.dead = false
+}
+
+// Update advances the run time and takes a sample when one is due, until the player dies
+func (s *RunStats) Update(deltaTime float64, player *Entity, score, entities int, fps float64) {
+	if s.dead || player == nil {
+		return
+	}
+	if !player.Active || player.Health <= 0 {
+		s.dead = true
+		s.record(score, entities, fps)
+		return
+	}
+
+	if s.timer <= 0 {
+		s.timer += RunStatsInterval
+		s.record(score, entities, fps)
+	}
+	s.time += deltaTime
+	s.timer -= deltaTime
+}
+
+// record appends a sample at the current run time and logs it
+func (s *RunStats) record(score, entities int, fps float64) {
+	sample := RunStatsSample{Time: s.time, Score: score, Kills: s.Kills, Entities: entities, FPS: fps}
+	s.Samples = append(s.Samples, sample)
+	minutes := int(s.time) / 60
+	fmt.Printf("Run stats at %d:%02d: score %d, kills %d, entities %d, %.0f FPS\n",
+		minutes, int(s.time)-minutes*60, sample.Score, sample.Kills, sample.Entities, sample.FPS)
+}
+
+// onRunStatsKill counts the player's kills
+func (g *Game) onRunStatsKill(event Event) {
+	if event.IsFrom(g.player) && event.Target.Type == EntityTypeEnemy && event.Target.Faction != FactionPlayer {
+		g.runStats.Kills++
+	}
+}
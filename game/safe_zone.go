@@ -0,0 +1,147 @@
+package game
+
+import "math"
+
+const (
+	// SafeZoneInitialRadius is the radius of the first safe zone around the world center
+	SafeZoneInitialRadius = 3000.0
+
+	// SafeZoneMinRadius is the smallest the zone shrinks to
+	SafeZoneMinRadius = 300.0
+
+	// SafeZoneShrinkFactor is the next zone's radius relative to the current one
+	SafeZoneShrinkFactor = 0.6
+
+	// SafeZoneWaitTime is how long the zone holds still (with the next zone previewed) before shrinking (seconds)
+	SafeZoneWaitTime = 30.0
+
+	// SafeZoneShrinkTime is how long the zone takes to shrink to the next zone (seconds)
+	SafeZoneShrinkTime = 20.0
+
+	// SafeZoneBaseDamage is the damage per second outside the first zone; each phase adds the same again
+	SafeZoneBaseDamage = 5.0
+
+	// SafeZoneAIMargin is how far inside the edge AI ships start turning back toward the center
+	SafeZoneAIMargin = 150.0
+)
+
+// SafeZone is a battle-royale style circle that shrinks in phases
+// Ships outside the current circle take damage every second; the next circle is known in advance
+type SafeZone struct {
+	// Current circle
+	X, Y, Radius float64
+
+	// Circle the zone shrinks to in the current phase
+	NextX, NextY, NextRadius float64
+
+	// Phase number (starting at 1) and time spent in the phase
+	Phase     int
+	PhaseTime float64
+
+	// Circle at the start of the phase (the shrink interpolates from it to the next circle)
+	startX, startY, startRadius float64
+}
+
+// NewSafeZone creates a safe zone centered at x, y and picks the first next zone
+func NewSafeZone(x, y, radius float64) *SafeZone {
+	zone := &SafeZone{X: x, Y: y, Radius: radius}
+	zone.beginPhase(1)
+	return zone
+}
+
+// beginPhase starts a new phase from the current circle, choosing a random next circle inside it
+func (z *SafeZone) beginPhase(phase int) {
+	z.Phase = phase
+	z.PhaseTime = 0
+	z.startX, z.startY, z.startRadius = z.X, z.Y, z.Radius
+
+	z.NextRadius = math.Max(z.Radius*SafeZoneShrinkFactor, SafeZoneMinRadius)
+	if z.NextRadius >= z.Radius {
+		// Fully shrunk: stay put
+		z.NextX, z.NextY, z.NextRadius = z.X, z.Y, z.Radius
+		return
+	}
+
+	// Keep the next circle entirely inside the current one
+	angle := runRNG.Float64() * 2 * math.Pi
+	offset := runRNG.Float64() * (z.Radius - z.NextRadius)
+	z.NextX = z.X + math.Cos(angle)*offset
+	z.NextY = z.Y + math.Sin(angle)*offset
+}
+
+// Update advances the wait/shrink cycle
+func (z *SafeZone) Update(deltaTime float64) {
+	z.PhaseTime += deltaTime
+	if z.PhaseTime <= SafeZoneWaitTime {
+		return
+	}
+
+	// Shrink linearly toward the next circle
+	t := math.Min((z.PhaseTime-SafeZoneWaitTime)/SafeZoneShrinkTime, 1)
+	z.X = z.startX + (z.NextX-z.startX)*t
+	z.Y = z.startY + (z.NextY-z.startY)*t
+	z.Radius = z.startRadius + (z.NextRadius-z.startRadius)*t
+
+	// Once fully shrunk, phases keep cycling in place so the damage keeps rising
+	if t >= 1 {
+		z.beginPhase(z.Phase + 1)
+	}
+}
+
+// FinalZone returns true once the zone has shrunk as far as it will go
+func (z *SafeZone) FinalZone() bool {
+	return z.NextRadius >= z.startRadius
+}
+
+// Shrinking returns true while the circle is moving toward the next zone
+func (z *SafeZone) Shrinking() bool {
+	return z.PhaseTime > SafeZoneWaitTime && !z.FinalZone()
+}
+
+// TimeUntilShrink returns the seconds left before the current phase starts shrinking (0 while shrinking)
+func (z *SafeZone) TimeUntilShrink() float64 {
+	return math.Max(SafeZoneWaitTime-z.PhaseTime, 0)
+}
+
+// DamagePerSecond returns the damage dealt per second to ships outside the zone
+func (z *SafeZone) DamagePerSecond() float64 {
+	return SafeZoneBaseDamage * float64(z.Phase)
+}
+
+// Contains returns true if a point is inside the current circle
+func (z *SafeZone) Contains(x, y float64) bool {
+	dx := x - z.X
+	dy := y - z.Y
+	return dx*dx+dy*dy <= z.Radius*z.Radius
+}
+
+// ShouldRetreat returns true if a ship at x, y is outside the zone or within margin of its edge
+func (z *SafeZone) ShouldRetreat(x, y, margin float64) bool {
+	inner := math.Max(z.Radius-margin, 0)
+	dx := x - z.X
+	dy := y - z.Y
+	return dx*dx+dy*dy > inner*inner
+}
+
+// applySafeZoneDamage damages ships outside the safe zone
+func (g *Game) applySafeZoneDamage(deltaTime float64) {
+	zone := g.world.SafeZone
+	damage := zone.DamagePerSecond() * deltaTime
+	for _, entity := range g.world.AllEntities {
+		if !entity.Active || entity.Health <= 0 {
+			continue
+		}
+		if entity.Type != EntityTypePlayer && entity.Type != EntityTypeEnemy {
+			continue
+		}
+		if zone.Contains(entity.X, entity.Y) || entity.Status.IsInvulnerable() {
+			continue
+		}
+
+		entity.Health -= damage
+		if entity.Health <= 0 {
+			// Killed by the zone: no XP, just mark the spot
+			g.createDestroyedIndicator(entity.X, entity.Y, entity.Faction)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// SalvagePerShip is the number of salvage pieces a destroyed enemy leaves (elites and mini-bosses leave more)
+	SalvagePerShip     = 1
+	SalvagePerElite    = 3
+	SalvagePerMiniBoss = 6
+
+	// SalvageValue is the credits one piece of salvage is worth
+	SalvageValue = 5
+
+	// SalvageLifetime is how long salvage drifts before it's gone (seconds); longer than plain debris
+	SalvageLifetime = 40.0
+
+	// SalvagePickupRange is how close to the player's hull salvage must drift to be collected (pixels)
+	SalvagePickupRange = 20.0
+
+	// SalvageBeamBaseRange and SalvageBeamRangePerLevel set the radius the salvage beam gathers in (pixels)
+	SalvageBeamBaseRange     = 150.0
+	SalvageBeamRangePerLevel = 100.0
+
+	// SalvageBeamPullSpeed is how fast the beam reels salvage in (pixels per second)
+	SalvageBeamPullSpeed = 250.0
+
+	// SalvageBeamMaxLevel is the number of salvage beam upgrades the player can buy
+	SalvageBeamMaxLevel = 3
+
+	// SalvageBeamUpgradePrice is the price of one salvage beam upgrade at a station shop
+	SalvageBeamUpgradePrice = 120
+)
+
+// salvageColor is the glint that sets salvage apart from plain debris
+var salvageColor = color.RGBA{255, 210, 90, 255}
+
+// SalvageBeamRange returns the radius the salvage beam gathers in for an upgrade level (0 without the beam)
+func SalvageBeamRange(level int) float64 {
+	if level <= 0 {
+		return 0
+	}
+	return SalvageBeamBaseRange + float64(level-1)*SalvageBeamRangePerLevel
+}
+
+// isSalvage returns true for debris pieces the player can collect
+func isSalvage(entity *Entity) bool {
+	return entity.Type == EntityTypeDebris && entity.Pickup.Value > 0
+}
+
+// salvagePieces returns the number of salvage pieces a destroyed ship leaves
+// Only hostile ships leave salvage; unlike plain debris it doesn't depend on the particle density
+func salvagePieces(ship *Entity) int {
+	switch {
+	case ship.Type != EntityTypeEnemy || ship.Faction == FactionPlayer:
+		return 0
+	case isMiniBoss(ship):
+		return SalvagePerMiniBoss
+	case ship.Elite != EliteNone:
+		return SalvagePerElite
+	default:
+		return SalvagePerShip
+	}
+}
+
+// updateSalvage collects salvage the player touches and reels in the salvage within the beam's range
+func (g *Game) updateSalvage() {
+	player := g.player
+	if player == nil || !player.Active || player.Health <= 0 {
+		return
+	}
+	beamRange := SalvageBeamRange(player.SalvageBeam)
+	for _, piece := range g.world.Debris {
+		if !piece.Active || !isSalvage(piece) {
+			continue
+		}
+		dx, dy := player.X-piece.X, player.Y-piece.Y
+		distance := math.Hypot(dx, dy)
+		if distance <= player.Radius+SalvagePickupRange {
+			g.credits += piece.Pickup.Value
+			piece.Active = false // Removed by UpdateDebris
+			continue
+		}
+		if distance <= beamRange {
+			piece.VX = player.VX + dx/distance*SalvageBeamPullSpeed
+			piece.VY = player.VY + dy/distance*SalvageBeamPullSpeed
+		}
+	}
+}
+
+// renderSalvageBeam draws the player's salvage beam to each piece it's reeling in
+func (r *Renderer) renderSalvageBeam(screen *ebiten.Image, world *World, player *Entity) {
+	if player == nil || !player.Active || player.Health <= 0 || player.SalvageBeam <= 0 {
+		return
+	}
+	beamRange := SalvageBeamRange(player.SalvageBeam)
+	px, py := r.camera.WorldToScreen(player.X, player.Y)
+	clr := salvageColor
+	clr.A = 90
+	for _, piece := range world.Debris {
+		if !piece.Active || !isSalvage(piece) || piece.DistanceTo(player) > beamRange {
+			continue
+		}
+		sx, sy := r.camera.WorldToScreen(piece.X, piece.Y)
+		r.lineCount++
+		r.drawCallCount++
+		vector.StrokeLine(screen, float32(px), float32(py), float32(sx), float32(sy), 1, clr, true)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import "math"
+
+const (
+	// MaxScreenShake is the furthest a full-strength shake throws the camera (world pixels)
+	MaxScreenShake = 10.0
+
+	// ScreenShakeDecay is how fast the shake strength wears off (full strength per second)
+	ScreenShakeDecay = 2.0
+
+	// ScreenShakeFrequency is how fast the camera jitters (radians per second of the base wave)
+	ScreenShakeFrequency = 40.0
+
+	// ScreenShakeKill is the shake strength when a mini-boss or capital ship goes down
+	ScreenShakeKill = 0.6
+)
+
+// ScreenShake jolts the player's camera after hits and big explosions (off with the ScreenShake setting)
+// It only moves the camera while drawing, so aiming and sector streaming never see the offset
+type ScreenShake struct {
+	strength float64 // 0-1; the offset grows with its square, so small hits stay subtle
+	time     float64 // Drives the jitter (no runRNG: the run must replay the same with shake off)
+}
+
+// NewScreenShake creates a still screen shake
+func NewScreenShake() *ScreenShake {
+	return &ScreenShake{}
+}
+
+// Add shakes the screen by strength (0-1), on top of any shake still going
+func (s *ScreenShake) Add(strength float64) {
+	if !GetSettings().ScreenShake {
+		return
+	}
+	s.strength = math.Min(s.strength+strength, 1)
+}
+
+// Update wears the shake off
+func (s *ScreenShake) Update(deltaTime float64) {
+	s.time += deltaTime
+	s.strength = math.Max(s.strength-ScreenShakeDecay*deltaTime, 0)
+}
+
+// Reset stops the shake
+func (s *ScreenShake) Reset() {
+	s.strength = 0
+}
+
+// Offset returns how far to move the camera this frame
+func (s *ScreenShake) Offset() (float64, float64) {
+	if s.strength <= 0 || !GetSettings().ScreenShake {
+		return 0, 0
+	}
+	// Two detuned waves per axis look like noise without drawing random numbers
+	t := s.time * ScreenShakeFrequency
+	amplitude := MaxScreenShake * s.strength * s.strength
+	return amplitude * (math.Sin(t)*0.6 + math.Sin(t*2.3+1.1)*0.4), amplitude * (math.Sin(t*1.3+2.0)*0.6 + math.Sin(t*2.9+0.4)*0.4)
+}
+
+// onScreenShakeDamage shakes the screen when the player is hit, harder for bigger hits
+func (g *Game) onScreenShakeDamage(event Event) {
+	if event.Target == nil || event.Target != g.player || g.player.MaxHealth <= 0 {
+		return
+	}
+	g.screenShake.Add(math.Min(event.Amount/g.player.MaxHealth*3, 1))
+}
+
+// onScreenShakeKill shakes the screen when the player dies or a mini-boss or capital ship goes down
+func (g *Game) onScreenShakeKill(event Event) {
+	switch target := event.Target; {
+	case target == nil:
+	case target == g.player:
+		g.screenShake.Add(1)
+	case isMiniBoss(target) || target.ShipType == ShipTypeCapitalCore:
+		// Only wrecks near the camera; the far side of the sector doesn't rattle the screen
+		dx, dy := target.X-g.camera.X, target.Y-g.camera.Y
+		if reach := float64(g.config.ScreenWidth); dx*dx+dy*dy <= reach*reach {
+			g.screenShake.Add(ScreenShakeKill)
+		}
+	}
+}
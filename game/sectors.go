@@ -0,0 +1,419 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const (
+	// PatrolAggroRadius is how far from its post a patrol ship engages targets (pixels)
+	PatrolAggroRadius = 1400.0
+
+	// PatrolOrbitRadius and PatrolOrbitSpeed shape the loop patrol ships fly around their post
+	PatrolOrbitRadius = 350.0
+	PatrolOrbitSpeed  = 0.4 // Radians per second
+
+	// sectorRequestQueue is the number of sector requests the generator worker can have queued
+	sectorRequestQueue = 32
+
+	// sectorApplyTicks is the number of ticks between requesting a sector and spawning its content
+	// Plans are applied on that tick whenever the worker finished them, so a seed replays the same run
+	sectorApplyTicks = 30
+)
+
+// SectorConfig controls procedural sector generation
+// The world is divided into square sectors that are populated the first time the player comes near;
+// the same seed always produces the same sectors
+type SectorConfig struct {
+	// Enabled turns sector generation on
+	Enabled bool
+
+	// Seed selects the generated universe (0 = pick a random seed when the game starts)
+	Seed int64
+
+	// Size is the side length of a sector (pixels)
+	Size float64
+
+	// LoadRadius is how many sectors around the player's sector are generated
+	LoadRadius int
+
+	// Lookahead also generates around where the player will be in this many seconds at their current velocity
+	Lookahead float64
+
+	// SpawnClearance keeps generated content at least this far from the player when it appears (pixels)
+	SpawnClearance float64
+
+	// Content density per sector
+	AsteroidClustersMax    int     // Asteroid clusters (0 to this many)
+	StationChance          float64 // Chance of a derelict station
+	PatrolsMax             int     // Faction patrols (0 to this many)
+	FriendlyPatrolShare    float64 // Share of patrols flying for the player's faction
+	IndependentPatrolShare float64 // Share of patrols flying for the independents
+}
+
+// DefaultSectorConfig returns the default sector generation settings (disabled)
+func DefaultSectorConfig() SectorConfig {
+	return SectorConfig{
+		Enabled:                false,
+		Size:                   8192.0,
+		LoadRadius:             1,
+		Lookahead:              4.0,
+		SpawnClearance:         1500.0,
+		AsteroidClustersMax:    3,
+		StationChance:          0.3,
+		PatrolsMax:             2,
+		FriendlyPatrolShare:    0.25,
+		IndependentPatrolShare: 0.25,
+	}
+}
+
+// SectorKey identifies a sector by its column and row, counted from the world's minimum corner
+type SectorKey struct {
+	X, Y int
+}
+
+// SectorFeature identifies what a SectorSpawn places
+type SectorFeature int
+
+const (
+	SectorFeatureAsteroid SectorFeature = iota
+	SectorFeatureStation
+	SectorFeaturePatrolShip
+)
+
+// SectorSpawn is one entity a generated sector contains
+// Everything random about it is rolled by GenerateSector, so applying it never draws from runRNG
+type SectorSpawn struct {
+	Feature SectorFeature
+	X, Y    float64
+
+	// Asteroids and stations: starting angle and tumble speed
+	Rotation, Spin float64
+
+	Asteroid  AsteroidSize // SectorFeatureAsteroid
+	Loot      int          // SectorFeatureStation
+	Inventory []ShopItem   // SectorFeatureStation
+
+	// SectorFeaturePatrolShip: the ship's enemy type, faction and shoot cooldown, and the post its patrol guards
+	EnemyType     EnemyType
+	Faction       Faction
+	ShootCooldown float64
+	PostX, PostY  float64
+}
+
+// SectorPlan is the generated content of one sector
+// Plans are plain data so they can be built off the main goroutine and applied to the world later
+type SectorPlan struct {
+	Key    SectorKey
+	Spawns []SectorSpawn
+}
+
+// sectorSeed mixes the run seed with a sector's key (splitmix64), so every sector has its own stream
+func sectorSeed(seed int64, key SectorKey) int64 {
+	z := uint64(seed) + uint64(int64(key.X))*0x9E3779B97F4A7C15 + uint64(int64(key.Y))*0xC2B2AE3D27D4EB4F
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
+
+// GenerateSector builds the content of a sector from the seed alone
+// It touches no shared state and only its own random source, so it is safe to call from any goroutine
+func GenerateSector(config SectorConfig, seed int64, key SectorKey, worldMinX, worldMinY float64) SectorPlan {
+	rng := rand.New(rand.NewSource(sectorSeed(seed, key)))
+	plan := SectorPlan{Key: key, Spawns: make([]SectorSpawn, 0, 32)}
+
+	// Content stays a margin away from the sector's edges so neighbouring sectors don't overlap
+	margin := config.Size * 0.1
+	originX := worldMinX + float64(key.X)*config.Size
+	originY := worldMinY + float64(key.Y)*config.Size
+	randomPoint := func() (float64, float64) {
+		return originX + margin + rng.Float64()*(config.Size-2*margin),
+			originY + margin + rng.Float64()*(config.Size-2*margin)
+	}
+
+	// Asteroid clusters: a few large rocks with smaller ones around them
+	for i := rng.Intn(config.AsteroidClustersMax + 1); i > 0; i-- {
+		centerX, centerY := randomPoint()
+		spread := 300.0 + rng.Float64()*400.0
+		for j := 4 + rng.Intn(6); j > 0; j-- {
+			angle := rng.Float64() * 2 * math.Pi
+			distance := math.Sqrt(rng.Float64()) * spread
+			size := AsteroidSizeLarge
+			if roll := rng.Float64(); roll < 0.3 {
+				size = AsteroidSizeSmall
+			} else if roll < 0.7 {
+				size = AsteroidSizeMedium
+			}
+			plan.Spawns = append(plan.Spawns, SectorSpawn{
+				Feature:  SectorFeatureAsteroid,
+				X:        centerX + math.Cos(angle)*distance,
+				Y:        centerY + math.Sin(angle)*distance,
+				Rotation: rng.Float64() * 2 * math.Pi,
+				Spin:     (rng.Float64()*2 - 1) * AsteroidSpinSpeed,
+				Asteroid: size,
+			})
+		}
+	}
+
+	// Derelict station with loot and a shop
+	if rng.Float64() < config.StationChance {
+		x, y := randomPoint()
+		plan.Spawns = append(plan.Spawns, SectorSpawn{
+			Feature:   SectorFeatureStation,
+			X:         x,
+			Y:         y,
+			Rotation:  rng.Float64() * 2 * math.Pi,
+			Spin:      (rng.Float64()*2 - 1) * StationSpinSpeed,
+			Loot:      100 + rng.Intn(5)*50,
+			Inventory: rollStationInventory(rng),
+		})
+	}
+
+	// Faction patrols: a few ships guarding a post
+	for i := rng.Intn(config.PatrolsMax + 1); i > 0; i-- {
+		postX, postY := randomPoint()
+		faction := FactionEnemy
+		if roll := rng.Float64(); roll < config.FriendlyPatrolShare {
+			faction = FactionPlayer
+		} else if roll < config.FriendlyPatrolShare+config.IndependentPatrolShare {
+			faction = FactionIndependent
+		}
+		for j := 2 + rng.Intn(3); j > 0; j-- {
+			enemyType := EnemyTypeShooter
+			if faction == FactionEnemy && rng.Float64() < 0.4 {
+				enemyType = EnemyTypeRocket
+			}
+			angle := rng.Float64() * 2 * math.Pi
+			plan.Spawns = append(plan.Spawns, SectorSpawn{
+				Feature:       SectorFeaturePatrolShip,
+				X:             postX + math.Cos(angle)*PatrolOrbitRadius,
+				Y:             postY + math.Sin(angle)*PatrolOrbitRadius,
+				EnemyType:     enemyType,
+				Faction:       faction,
+				ShootCooldown: GetEnemyTypeConfig(enemyType).RollShootCooldown(rng),
+				PostX:         postX,
+				PostY:         postY,
+			})
+		}
+	}
+
+	return plan
+}
+
+// SectorGenerator generates sectors on a worker goroutine ahead of the player
+// Request and Poll are called from the game loop only; the worker never touches the world
+type SectorGenerator struct {
+	config               SectorConfig
+	seed                 int64
+	worldMinX, worldMinY float64
+
+	requests chan SectorKey
+	results  chan SectorPlan
+	done     chan struct{}
+
+	// Sectors already requested, requests not yet handed to the worker, the sectors waiting for their
+	// tick in request order, and plans the worker finished ahead of it (game loop only)
+	requested map[SectorKey]bool
+	queued    []SectorKey
+	pending   []pendingSector
+	ready     map[SectorKey]SectorPlan
+}
+
+// pendingSector is a requested sector and the tick its plan is applied on
+type pendingSector struct {
+	key  SectorKey
+	tick uint64
+}
+
+// NewSectorGenerator starts a generator worker for the world described by config
+func NewSectorGenerator(config Config) *SectorGenerator {
+	seed := config.Sectors.Seed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	fmt.Printf("Sector seed: %d\n", seed)
+
+	s := &SectorGenerator{
+		config:    config.Sectors,
+		seed:      seed,
+		worldMinX: config.WorldMinX,
+		worldMinY: config.WorldMinY,
+		requests:  make(chan SectorKey, sectorRequestQueue),
+		results:   make(chan SectorPlan, sectorRequestQueue),
+		done:      make(chan struct{}),
+		requested: make(map[SectorKey]bool, 64),
+		ready:     make(map[SectorKey]SectorPlan, sectorRequestQueue),
+	}
+	go s.run()
+	return s
+}
+
+// Seed returns the seed the sectors are generated from
+func (s *SectorGenerator) Seed() int64 {
+	return s.seed
+}
+
+// run generates requested sectors until Close
+func (s *SectorGenerator) run() {
+	for {
+		select {
+		case key := <-s.requests:
+			plan := GenerateSector(s.config, s.seed, key, s.worldMinX, s.worldMinY)
+			select {
+			case s.results <- plan:
+			case <-s.done:
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Request queues a sector for generation unless it was requested before; its plan is applied
+// sectorApplyTicks after tick (see Poll)
+func (s *SectorGenerator) Request(key SectorKey, tick uint64) {
+	if s.requested[key] {
+		return
+	}
+	s.requested[key] = true
+	s.queued = append(s.queued, key)
+	s.pending = append(s.pending, pendingSector{key: key, tick: tick + sectorApplyTicks})
+	s.send()
+}
+
+// send hands queued requests to the worker until its queue is full
+func (s *SectorGenerator) send() {
+	for len(s.queued) > 0 {
+		select {
+		case s.requests <- s.queued[0]:
+			s.queued = s.queued[1:]
+		default:
+			return
+		}
+	}
+}
+
+// Poll calls apply, in request order, for every sector whose tick has come
+// It only blocks if the worker hasn't finished a due sector yet, so plans land on the same tick in every replay
+func (s *SectorGenerator) Poll(tick uint64, apply func(SectorPlan)) {
+	s.send()
+	for len(s.pending) > 0 && s.pending[0].tick <= tick {
+		key := s.pending[0].key
+		plan, ok := s.ready[key]
+		for !ok {
+			s.receive()
+			plan, ok = s.ready[key]
+		}
+		delete(s.ready, key)
+		s.pending = s.pending[1:]
+		apply(plan)
+	}
+}
+
+// receive waits for the worker's next plan, handing it queued requests meanwhile
+func (s *SectorGenerator) receive() {
+	if len(s.queued) == 0 {
+		plan := <-s.results
+		s.ready[plan.Key] = plan
+		return
+	}
+	select {
+	case s.requests <- s.queued[0]:
+		s.queued = s.queued[1:]
+	case plan := <-s.results:
+		s.ready[plan.Key] = plan
+	}
+}
+
+// Close stops the worker; plans still in flight are dropped
+func (s *SectorGenerator) Close() {
+	close(s.done)
+}
+
+// SectorAt returns the key of the sector containing a world position
+func (s *SectorGenerator) SectorAt(x, y float64) SectorKey {
+	return SectorKey{
+		X: int(math.Floor((x - s.worldMinX) / s.config.Size)),
+		Y: int(math.Floor((y - s.worldMinY) / s.config.Size)),
+	}
+}
+
+// updateSectors requests the sectors around the player and where the player is heading,
+// and populates the world with the sectors the worker has finished
+func (g *Game) updateSectors() {
+	if g.sectors == nil {
+		return
+	}
+
+	// Follow the player, or the camera when there is none (arena)
+	x, y := g.camera.X, g.camera.Y
+	vx, vy := 0.0, 0.0
+	if g.player != nil && g.player.Active {
+		x, y = g.player.X, g.player.Y
+		vx, vy = g.player.VX, g.player.VY
+	}
+	lookahead := g.config.Sectors.Lookahead
+	g.requestSectorsAround(g.sectors.SectorAt(x, y))
+	g.requestSectorsAround(g.sectors.SectorAt(x+vx*lookahead, y+vy*lookahead))
+
+	g.sectors.Poll(g.tickCount, g.applySectorPlan)
+}
+
+// requestSectorsAround requests every sector within LoadRadius of center that lies inside the world
+func (g *Game) requestSectorsAround(center SectorKey) {
+	radius := g.config.Sectors.LoadRadius
+	size := g.config.Sectors.Size
+	maxX := int(math.Ceil(g.config.WorldWidth/size)) - 1
+	maxY := int(math.Ceil(g.config.WorldHeight/size)) - 1
+	for x := max(center.X-radius, 0); x <= min(center.X+radius, maxX); x++ {
+		for y := max(center.Y-radius, 0); y <= min(center.Y+radius, maxY); y++ {
+			g.sectors.Request(SectorKey{x, y}, g.tickCount)
+		}
+	}
+}
+
+// applySectorPlan spawns a generated sector's content, skipping anything that would appear next to the player
+func (g *Game) applySectorPlan(plan SectorPlan) {
+	clearanceSq := g.config.Sectors.SpawnClearance * g.config.Sectors.SpawnClearance
+	for _, spawn := range plan.Spawns {
+		if g.player != nil && g.player.Active {
+			dx := spawn.X - g.player.X
+			dy := spawn.Y - g.player.Y
+			if dx*dx+dy*dy < clearanceSq {
+				continue
+			}
+		}
+
+		switch spawn.Feature {
+		case SectorFeatureAsteroid:
+			asteroid := newAsteroid(spawn.X, spawn.Y, spawn.Asteroid)
+			asteroid.Rotation = spawn.Rotation
+			asteroid.AngularVelocity = spawn.Spin
+			g.world.RegisterEntity(asteroid)
+		case SectorFeatureStation:
+			station := newStation(spawn.X, spawn.Y, spawn.Loot)
+			station.Rotation = spawn.Rotation
+			station.AngularVelocity = spawn.Spin
+			station.Station.Inventory = spawn.Inventory
+			g.world.RegisterEntity(station)
+		case SectorFeaturePatrolShip:
+			g.spawnPatrolShip(spawn)
+		}
+	}
+}
+
+// spawnPatrolShip spawns one ship of a sector patrol guarding its post
+// Player-faction patrols fly the player ship with shooter behavior, like arena bots
+func (g *Game) spawnPatrolShip(spawn SectorSpawn) {
+	aiInput := newAIInputWithCooldown(spawn.EnemyType, spawn.ShootCooldown)
+	aiInput.SetPatrol(spawn.PostX, spawn.PostY)
+	shipType := GetEnemyTypeConfig(spawn.EnemyType).ShipType
+	if spawn.Faction == FactionPlayer {
+		shipType = ShipTypePlayer
+	}
+	ship := NewEntityWithShipType(spawn.X, spawn.Y, EntityTypeEnemy, shipType, aiInput)
+	ship.Faction = spawn.Faction
+	g.world.RegisterEntity(ship)
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// SentryStartingCharges is the number of sentry turrets the player starts a run with
+	SentryStartingCharges = 2
+
+	// MaxSentryCharges is the most sentry turrets the player can carry
+	MaxSentryCharges = 5
+
+	// MaxDeployedSentries is the number of sentries that can stand at once; deploying another dismisses the oldest
+	MaxDeployedSentries = 2
+
+	// SentryLifetime is how long a deployed sentry lasts before it shuts down (seconds)
+	SentryLifetime = 45.0
+
+	// SentryRange is how far a sentry looks for targets (pixels)
+	SentryRange = 500.0
+
+	// SentryPrice is the price of one sentry turret at a station shop
+	SentryPrice = 80
+)
+
+// SentryInput is the input of a deployed sentry: it never moves, and fires when its turrets have a clear shot
+type SentryInput struct {
+	shoot bool
+}
+
+// GetThrust returns 0: sentries are stationary
+func (s *SentryInput) GetThrust() float64 {
+	return 0
+}
+
+// GetRotation returns 0: only the turrets turn
+func (s *SentryInput) GetRotation() float64 {
+	return 0
+}
+
+// ShouldShoot returns true when a turret has a clear shot at a target
+func (s *SentryInput) ShouldShoot() bool {
+	return s.shoot
+}
+
+// HasTarget returns true when a turret has a target
+func (s *SentryInput) HasTarget() bool {
+	return s.shoot
+}
+
+// Update does nothing: updateSentries aims the turrets
+func (s *SentryInput) Update(deltaTime float64) {
+}
+
+// deploySentry places a sentry turret at the player's position when B is pressed and a charge is left
+func (g *Game) deploySentry() {
+	player := g.player
+	if !inpututil.IsKeyJustPressed(ebiten.KeyB) || player == nil || !player.Active || player.Health <= 0 || player.Sentries <= 0 {
+		return
+	}
+	player.Sentries--
+
+	if len(g.sentries) >= MaxDeployedSentries {
+		g.dismissSentry(g.sentries[0])
+		g.sentries = g.sentries[1:]
+	}
+	sentry := NewEntityWithShipType(player.X, player.Y, EntityTypeEnemy, ShipTypeSentry, &SentryInput{})
+	sentry.Faction = player.Faction
+	sentry.Rotation = player.Rotation
+	sentry.Lifetime = SentryLifetime
+	sentry.NoCollision = true // The player can fly over it; enemies still ram it
+	g.world.RegisterEntity(sentry)
+	g.sentries = append(g.sentries, sentry)
+}
+
+// updateSentries aims deployed sentries at the nearest hostile ship in range and shuts down the expired ones
+func (g *Game) updateSentries(deltaTime float64) {
+	live := g.sentries[:0]
+	for _, sentry := range g.sentries {
+		if !sentry.Active || sentry.Health <= 0 {
+			continue // Destroyed: removed by the update loop
+		}
+		if sentry.Age >= sentry.Lifetime {
+			g.dismissSentry(sentry)
+			continue
+		}
+		live = append(live, sentry)
+
+		input := sentry.Input.(*SentryInput)
+		target := findAITargetNear(sentry, sentry.X, sentry.Y, SentryRange, g.world, GetOppositeFaction(sentry.Faction))
+		updateAITurrets(sentry, target, g.world, deltaTime)
+		input.shoot = false
+		for i := range sentry.Turrets {
+			if sentry.Turrets[i].Target.HasTarget {
+				input.shoot = true
+			}
+		}
+	}
+	clear(g.sentries[len(live):])
+	g.sentries = live
+}
+
+// dismissSentry takes a sentry out of the world without it counting as destroyed
+func (g *Game) dismissSentry(sentry *Entity) {
+	g.createDestroyedIndicator(sentry.X, sentry.Y, sentry.Faction)
+	sentry.Active = false
+	g.world.UnregisterEntity(sentry)
+}
@@ -0,0 +1,231 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// settingsDirName is the directory under the user config dir holding the settings file
+	settingsDirName = "billionslike3"
+
+	// settingsFileName is the name of the settings file
+	settingsFileName = "settings.json"
+
+	// MinGameSpeed is the slowest allowed global game speed (25% slower than normal)
+	MinGameSpeed = 0.75
+)
+
+// Settings holds user preferences persisted to the settings file
+type Settings struct {
+	// Accessibility
+	ScreenShake      bool    // Allow camera shake effects (V toggles)
+	ParticleDensity  float64 // Multiplier for particle/FX spawn counts (0-1, U cycles)
+	StaticIndicators bool    // Draw destroyed indicators as static icons instead of fading crosses (I toggles)
+	GameSpeed        float64 // Global simulation speed multiplier (MinGameSpeed-1)
+
+	// HUD
+	Compass    CompassStyle  // Heading compass around the player, along the top, or off (F8 cycles)
+	HealthBars HealthBarMode // When health bars are drawn over ships (see health_bars.go)
+
+	// Graphics
+	Bloom    BloomQuality // Glow on bright parts of the world (see bloom.go)
+	Lighting bool         // Darken space and light it around projectiles, engines and explosions (see lighting.go)
+	LowEnd   bool         // Low-end mode: bypass post-processing whatever its quality setting
+
+	// Camera follow mode and stiffness (see camera_follow.go)
+	Camera CameraSettings
+
+	// Display mode, monitor and window geometry, applied at launch (see display.go)
+	Display DisplaySettings
+
+	// Loadout
+	Loadout Loadout
+
+	// Mods
+	DisabledMods []string // IDs of mods that are installed but not loaded
+}
+
+// Loadout holds the player's ship setup
+type Loadout struct {
+	TurretPriorities []TargetPriority // Targeting policy per player turret mount (missing entries = nearest)
+}
+
+// TurretPriority returns the targeting policy of the player turret at mount index i
+func (l *Loadout) TurretPriority(i int) TargetPriority {
+	if i < 0 || i >= len(l.TurretPriorities) {
+		return TargetPriorityNearest
+	}
+	return l.TurretPriorities[i]
+}
+
+// CycleTurretPriority switches the player turret at mount index i to the next targeting policy
+func (l *Loadout) CycleTurretPriority(i int) {
+	for len(l.TurretPriorities) <= i {
+		l.TurretPriorities = append(l.TurretPriorities, TargetPriorityNearest)
+	}
+	l.TurretPriorities[i] = l.TurretPriorities[i].Next()
+}
+
+// DefaultSettings returns the default user settings
+func DefaultSettings() Settings {
+	return Settings{
+		ScreenShake:      true,
+		ParticleDensity:  1.0,
+		StaticIndicators: false,
+		GameSpeed:        1.0,
+		Compass:          CompassRing,
+		Bloom:            BloomLow,
+		Camera:           DefaultCameraSettings(),
+	}
+}
+
+// Global settings instance (persists across game resets)
+var globalSettings = DefaultSettings()
+
+// GetSettings returns the global settings
+func GetSettings() *Settings {
+	return &globalSettings
+}
+
+// Sanitize clamps settings to valid ranges (the file is user editable)
+func (s *Settings) Sanitize() {
+	if s.GameSpeed <= 0 || s.GameSpeed > 1.0 {
+		s.GameSpeed = 1.0
+	} else if s.GameSpeed < MinGameSpeed {
+		s.GameSpeed = MinGameSpeed
+	}
+	if s.Compass < 0 || s.Compass >= compassStyleCount {
+		s.Compass = CompassRing
+	}
+	if s.HealthBars < 0 || s.HealthBars >= healthBarModeCount {
+		s.HealthBars = HealthBarsOnDamage
+	}
+	if s.Bloom < 0 || s.Bloom >= bloomQualityCount {
+		s.Bloom = BloomLow
+	}
+	s.Camera.Sanitize()
+	s.Display.Sanitize()
+	for i, priority := range s.Loadout.TurretPriorities {
+		if priority < 0 || priority >= targetPriorityCount {
+			s.Loadout.TurretPriorities[i] = TargetPriorityNearest
+		}
+	}
+	if s.ParticleDensity < 0 {
+		s.ParticleDensity = 0
+	} else if s.ParticleDensity > 1 {
+		s.ParticleDensity = 1
+	}
+}
+
+// CycleGameSpeed steps the game speed through 100%, 90%, 80% and 75%
+func (s *Settings) CycleGameSpeed() {
+	switch {
+	case s.GameSpeed > 0.95:
+		s.GameSpeed = 0.9
+	case s.GameSpeed > 0.85:
+		s.GameSpeed = 0.8
+	case s.GameSpeed > 0.77:
+		s.GameSpeed = MinGameSpeed
+	default:
+		s.GameSpeed = 1.0
+	}
+}
+
+// CycleParticleDensity steps the particle density through 100%, 50%, 25% and none
+func (s *Settings) CycleParticleDensity() {
+	switch {
+	case s.ParticleDensity > 0.75:
+		s.ParticleDensity = 0.5
+	case s.ParticleDensity > 0.375:
+		s.ParticleDensity = 0.25
+	case s.ParticleDensity > 0:
+		s.ParticleDensity = 0
+	default:
+		s.ParticleDensity = 1.0
+	}
+}
+
+// IsModDisabled returns true if the mod with the given ID is disabled
+func (s *Settings) IsModDisabled(id string) bool {
+	for _, disabled := range s.DisabledMods {
+		if disabled == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetModEnabled enables or disables the mod with the given ID
+func (s *Settings) SetModEnabled(id string, enabled bool) {
+	for i, disabled := range s.DisabledMods {
+		if disabled == id {
+			if enabled {
+				s.DisabledMods = append(s.DisabledMods[:i], s.DisabledMods[i+1:]...)
+			}
+			return
+		}
+	}
+	if !enabled {
+		s.DisabledMods = append(s.DisabledMods, id)
+	}
+}
+
+// settingsPath returns the full path of the settings file
+func settingsPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+	return filepath.Join(configDir, settingsDirName, settingsFileName), nil
+}
+
+// LoadSettings reads the settings file into the global settings
+// A missing file is not an error; defaults are kept
+func LoadSettings() error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	// Start from defaults so fields missing from older files keep sane values
+	settings := DefaultSettings()
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	settings.Sanitize()
+	globalSettings = settings
+	return nil
+}
+
+// SaveSettings writes the global settings to the settings file
+func SaveSettings() error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create settings directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(globalSettings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
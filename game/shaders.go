@@ -0,0 +1,189 @@
+package game
+
+import (
+	"embed"
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Kage sources of the effect shaders
+//
+//go:embed shaders/*.kage
+var shaderSources embed.FS
+
+const (
+	// MaxShockwaves is the number of shockwaves distorting the screen at once (the shader's array size)
+	MaxShockwaves = 8
+
+	// ShockwaveStrength is how far a fresh shockwave pushes the image outward (pixels)
+	ShockwaveStrength = 12.0
+
+	// MaxDamageAberration is the strongest chromatic aberration a hit can cause (pixels at the screen edge)
+	MaxDamageAberration = 6.0
+
+	// DamageAberrationDecay is how fast the aberration wears off (pixels per second)
+	DamageAberrationDecay = 15.0
+
+	// ShieldRippleScale is the shield bubble's radius relative to the ship's
+	ShieldRippleScale = 1.4
+)
+
+// ShaderID identifies an effect shader
+type ShaderID int
+
+const (
+	ShaderShockwave           ShaderID = iota // Full screen: rings pushing the image outward
+	ShaderShieldRipple                        // Per entity: rippling shield bubble
+	ShaderChromaticAberration                 // Full screen: color channels pulled apart after a hit
+	shaderCount
+)
+
+// shaderFiles are the Kage sources of each shader, in the shaders directory
+var shaderFiles = [shaderCount]string{"shockwave.kage", "shield_ripple.kage", "chromatic_aberration.kage"}
+
+// ShaderManager compiles the effect shaders and applies them per entity or to the whole screen
+// Full-screen effects are queued while the world is drawn and applied together by ApplyScreenEffects
+// A nil manager draws no effects, so rendering works the same when shaders fail to compile
+type ShaderManager struct {
+	shaders [shaderCount]*ebiten.Shader
+
+	// Shockwaves queued this frame: center x, center y, ring radius and strength, in screen pixels
+	shockwaves     [MaxShockwaves * 4]float32
+	shockwaveCount int
+
+	// Current chromatic aberration strength, raised by hits and decaying over time (pixels)
+	aberration float64
+
+	// Copy of the screen the full-screen shaders read from (a shader can't read the image it draws to)
+	scratch *ebiten.Image
+}
+
+// NewShaderManager compiles the embedded effect shaders
+func NewShaderManager() (*ShaderManager, error) {
+	m := &ShaderManager{}
+	for id, file := range shaderFiles {
+		source, err := shaderSources.ReadFile("shaders/" + file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shader %s: %w", file, err)
+		}
+		shader, err := ebiten.NewShader(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile shader %s: %w", file, err)
+		}
+		m.shaders[id] = shader
+	}
+	return m, nil
+}
+
+// enabled returns true if shader effects should be drawn (low-end mode bypasses them)
+func (m *ShaderManager) enabled() bool {
+	return m != nil && !GetSettings().LowEnd
+}
+
+// Update wears off the damage aberration
+func (m *ShaderManager) Update(deltaTime float64) {
+	if m == nil {
+		return
+	}
+	m.aberration = math.Max(m.aberration-DamageAberrationDecay*deltaTime, 0)
+}
+
+// FlashDamage starts a chromatic aberration for a hit; severity is the share of max health lost (0-1)
+func (m *ShaderManager) FlashDamage(severity float64) {
+	if m == nil {
+		return
+	}
+	m.aberration = math.Max(m.aberration, MaxDamageAberration*math.Min(severity*4, 1))
+}
+
+// AddShockwave queues a shockwave at a screen position for this frame's full-screen pass
+// radius is the ring's current radius and strength how far it pushes (both in pixels)
+func (m *ShaderManager) AddShockwave(sx, sy, radius, strength float64) {
+	if !m.enabled() || m.shockwaveCount >= MaxShockwaves {
+		return
+	}
+	wave := m.shockwaves[m.shockwaveCount*4:]
+	wave[0], wave[1], wave[2], wave[3] = float32(sx), float32(sy), float32(radius), float32(strength)
+	m.shockwaveCount++
+}
+
+// DrawShieldRipple draws a rippling shield bubble of the given screen radius centered on (sx, sy),
+// deformed by dents (see ShieldBubble.Uniform)
+// It returns false if nothing was drawn
+func (m *ShaderManager) DrawShieldRipple(screen *ebiten.Image, sx, sy, radius, time float64, clr color.RGBA, dents []float32) bool {
+	if !m.enabled() {
+		return false
+	}
+	// Leave room for dents springing back outward
+	extent := radius * (1 + ShieldDentDepth)
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Translate(sx-extent, sy-extent)
+	op.Uniforms = map[string]any{
+		"Center": []float32{float32(sx), float32(sy)},
+		"Radius": float32(radius),
+		"Time":   float32(time),
+		"Color":  []float32{float32(clr.R) / 255, float32(clr.G) / 255, float32(clr.B) / 255, float32(clr.A) / 255},
+		"Dents":  dents,
+	}
+	op.Blend = ebiten.BlendLighter
+	size := int(extent*2) + 1
+	screen.DrawRectShader(size, size, m.shaders[ShaderShieldRipple], op)
+	return true
+}
+
+// ApplyScreenEffects runs the queued shockwaves and the damage aberration over the screen, then clears the queue
+// It returns the number of draw calls made
+func (m *ShaderManager) ApplyScreenEffects(screen *ebiten.Image) int {
+	if !m.enabled() {
+		if m != nil {
+			m.shockwaveCount = 0
+		}
+		return 0
+	}
+	drawCalls := 0
+	if m.shockwaveCount > 0 {
+		drawCalls += m.applyFullScreen(screen, ShaderShockwave, map[string]any{
+			"Waves":     m.shockwaves[:],
+			"WaveCount": m.shockwaveCount,
+		})
+		m.shockwaveCount = 0
+	}
+	if m.aberration > 0.5 {
+		drawCalls += m.applyFullScreen(screen, ShaderChromaticAberration, map[string]any{
+			"Strength": float32(m.aberration),
+		})
+	}
+	return drawCalls
+}
+
+// applyFullScreen redraws the screen through a shader that reads the current frame as its source image
+// It returns the number of draw calls made
+func (m *ShaderManager) applyFullScreen(screen *ebiten.Image, id ShaderID, uniforms map[string]any) int {
+	bounds := screen.Bounds()
+	if m.scratch == nil || m.scratch.Bounds().Size() != bounds.Size() {
+		if m.scratch != nil {
+			m.scratch.Deallocate()
+		}
+		m.scratch = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	m.scratch.Clear()
+	m.scratch.DrawImage(screen, nil)
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = m.scratch
+	op.Uniforms = uniforms
+	op.Blend = ebiten.BlendCopy
+	screen.DrawRectShader(bounds.Dx(), bounds.Dy(), m.shaders[id], op)
+	return 2
+}
+
+// onShaderDamage flashes a chromatic aberration when the player is hit, stronger for bigger hits
+func (g *Game) onShaderDamage(event Event) {
+	if event.Target == nil || event.Target != g.player || g.player.MaxHealth <= 0 {
+		return
+	}
+	g.shaders.FlashDamage(event.Amount / g.player.MaxHealth)
+}
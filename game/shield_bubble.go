@@ -0,0 +1,61 @@
+package game
+
+import "math"
+
+const (
+	// MaxShieldDents is the number of hits a shield bubble shows at once (the shader's array size)
+	MaxShieldDents = 4
+
+	// ShieldDentDuration is how long a dent takes to spring back (seconds)
+	ShieldDentDuration = 0.3
+
+	// ShieldDentDepth is how far the hardest hit pushes the bubble in, as a share of its radius
+	ShieldDentDepth = 0.3
+)
+
+// ShieldDent is one hit on a shield bubble
+type ShieldDent struct {
+	Angle    float64 // Direction of the hit from the ship's center (radians)
+	Strength float64 // 0-1, scales the depth and the flash
+	Time     float64 // Age of the ship when it was hit
+}
+
+// ShieldBubble holds the recent hits denting a ship's shield bubble
+// A new hit replaces the oldest one; dents wear off with time, so nothing needs updating per tick
+type ShieldBubble struct {
+	dents [MaxShieldDents]ShieldDent
+	next  int
+}
+
+// Dent records a hit coming from angle at time now (the ship's age)
+func (b *ShieldBubble) Dent(angle, strength, now float64) {
+	b.dents[b.next] = ShieldDent{Angle: angle, Strength: strength, Time: now}
+	b.next = (b.next + 1) % MaxShieldDents
+}
+
+// Uniform returns the dents at time now for the shield shader: angle, depth (share of the radius) and flash per dent
+// A dent springs back with a decaying wobble, overshooting outward before it settles
+func (b *ShieldBubble) Uniform(now float64) []float32 {
+	uniform := make([]float32, MaxShieldDents*4)
+	for i, dent := range b.dents {
+		t := (now - dent.Time) / ShieldDentDuration
+		if dent.Strength <= 0 || t < 0 || t >= 1 {
+			continue
+		}
+		decay := (1 - t) * (1 - t)
+		uniform[i*4] = float32(dent.Angle)
+		uniform[i*4+1] = float32(dent.Strength * ShieldDentDepth * decay * math.Cos(t*3*math.Pi))
+		uniform[i*4+2] = float32(dent.Strength * decay)
+	}
+	return uniform
+}
+
+// onShieldBubbleDamage dents the shield bubble of a shielded ship where it was hit, deeper for bigger hits
+func (g *Game) onShieldBubbleDamage(event Event) {
+	target := event.Target
+	if target == nil || event.Burn || target.Shield <= 0 || target.MaxHealth <= 0 {
+		return // Burns have no impact point to dent
+	}
+	strength := math.Min(0.3+event.Amount/target.MaxHealth*4, 1)
+	target.ShieldBubble.Dent(math.Atan2(event.HitY-target.Y, event.HitX-target.X), strength, target.Age)
+}
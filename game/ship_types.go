@@ -1,8 +1,6 @@
 package game
 
-import (
-	"math/rand"
-)
+import "math"
 
 // ShipType defines different types of ships
 type ShipType int
@@ -11,6 +9,14 @@ const (
 	ShipTypePlayer ShipType = iota
 	ShipTypeHomingSuicide
 	ShipTypeShooter
+	ShipTypeSwarmer
+	ShipTypeFreighter
+	ShipTypeGunship
+	ShipTypeStalker
+	ShipTypeDrone
+	ShipTypeSentry
+	ShipTypeCapitalCore
+	ShipTypeCapitalSection
 	ShipTypeCount // Total number of ship types
 )
 
@@ -22,6 +28,11 @@ type TurretMountPoint struct {
 	Active       bool       // Whether this mount point has an active turret
 	BarrelLength float64    // Length of the barrel (where bullets spawn)
 	WeaponType   WeaponType // Type of weapon mounted on this turret
+
+	// Firing arc: the barrel only traverses from ArcMin to ArcMax (radians, relative to the ship forward
+	// direction, counterclockwise from ArcMin to ArcMax), and only takes targets inside it. Both 0 = full circle
+	ArcMin float64
+	ArcMax float64
 }
 
 // ShipTypeConfig holds configuration for each ship type
@@ -34,6 +45,7 @@ type ShipTypeConfig struct {
 	Radius        float64
 	ShootCooldown float64 // Only used for ships that can shoot
 	Shape         ShipShape
+	Sprite        string             // Sprite name in the asset atlas (empty = draw Shape)
 	TurretMounts  []TurretMountPoint // Turret mount points on this ship
 	// Physics properties
 	AngularAcceleration float64 // Angular acceleration (radians per second squared)
@@ -44,6 +56,15 @@ type ShipTypeConfig struct {
 
 	// Score value when destroyed
 	Score int
+
+	// Hits near a turret mount can destroy that turret (large ships and bosses)
+	DestructibleTurrets bool
+
+	// Turrets sharing a weapon take turns firing instead of firing in synchronized volleys
+	StaggeredTurrets bool
+
+	// Carries a cloaking device: spawns cloaked and decloaks to attack (see cloak.go)
+	Cloaking bool
 	
 	// Targeting configuration (for AI ships)
 	TargetEntityTypes []EntityType // Whitelist of entity types this ship can target (empty = all)
@@ -62,8 +83,35 @@ const (
 	ShipShapeDiamond
 )
 
-// GetShipTypeConfig returns configuration for a ship type
+// Ship config patches registered by mods, applied in registration order
+var shipConfigPatches = make(map[ShipType][]func(*ShipTypeConfig))
+
+// PatchShipType registers a function that adjusts a ship type's config on every lookup
+func PatchShipType(shipType ShipType, patch func(*ShipTypeConfig)) {
+	shipConfigPatches[shipType] = append(shipConfigPatches[shipType], patch)
+}
+
+// GetShipTypeByName returns the ship type whose config has the given name
+func GetShipTypeByName(name string) (ShipType, bool) {
+	for shipType := ShipType(0); shipType < ShipTypeCount; shipType++ {
+		if baseShipTypeConfig(shipType).Name == name {
+			return shipType, true
+		}
+	}
+	return 0, false
+}
+
+// GetShipTypeConfig returns configuration for a ship type (with mod patches applied)
 func GetShipTypeConfig(shipType ShipType) ShipTypeConfig {
+	config := baseShipTypeConfig(shipType)
+	for _, patch := range shipConfigPatches[config.Type] {
+		patch(&config)
+	}
+	return config
+}
+
+// baseShipTypeConfig returns the built-in configuration for a ship type
+func baseShipTypeConfig(shipType ShipType) ShipTypeConfig {
 	switch shipType {
 	case ShipTypePlayer:
 		return ShipTypeConfig{
@@ -75,6 +123,7 @@ func GetShipTypeConfig(shipType ShipType) ShipTypeConfig {
 			Radius:              10.0, // Smaller collision radius
 			ShootCooldown:       0.1,  // Very fast shooting
 			Shape:               ShipShapeTriangle,
+			Sprite:              "sprites/player",
 			AngularAcceleration: 5.0,              // Radians per second squared
 			MaxAngularSpeed:     3.0,              // Radians per second
 			Friction:            0.9999,           // Very very small friction
@@ -84,6 +133,7 @@ func GetShipTypeConfig(shipType ShipType) ShipTypeConfig {
 				{OffsetX: 0.0, OffsetY: -8.0, Angle: 0.0, Active: true, BarrelLength: 12.0, WeaponType: WeaponTypeBullet},        // Right mount (active) - bullets
 				{OffsetX: 16.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 10.0, WeaponType: WeaponTypeHomingMissile}, // Front mount (active) - rockets
 				{OffsetX: 0.0, OffsetY: 8.0, Angle: 0.0, Active: true, BarrelLength: 12.0, WeaponType: WeaponTypeBullet},         // Left mount (active) - bullets
+				{OffsetX: -10.0, OffsetY: 0.0, Angle: math.Pi, Active: true, BarrelLength: 8.0, WeaponType: WeaponTypeTractor},   // Rear mount (active) - tractor beam (hold T)
 
 			},
 		}
@@ -97,14 +147,15 @@ func GetShipTypeConfig(shipType ShipType) ShipTypeConfig {
 			Radius:              6.0,
 			ShootCooldown:       0.0, // Doesn't shoot
 			Shape:               ShipShapeTriangle,
+			Sprite:              "sprites/homing_suicide",
 			AngularAcceleration: 4.0,                  // Radians per second squared
 			MaxAngularSpeed:     2.5,                  // Radians per second
 			Friction:            0.9999,               // Very very small friction
 			DefaultWeaponType:   WeaponTypeNone,       // Not used (doesn't shoot)
 			Score:               10,                   // Small score for easy enemies
 			TurretMounts:        []TurretMountPoint{}, // No turrets
-			TargetEntityTypes:  []EntityType{EntityTypePlayer, EntityTypeEnemy}, // Target players and enemies
-			TargetShipTypes:    []ShipType{ShipTypePlayer, ShipTypeShooter}, // Only target real ships (not rockets)
+			TargetEntityTypes:  []EntityType{EntityTypePlayer, EntityTypeEnemy, EntityTypeStation}, // Target players, enemies and defended stations
+			TargetShipTypes:    []ShipType{ShipTypePlayer, ShipTypeShooter, ShipTypeFreighter}, // Only target real ships (not rockets)
 			BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator}, // Don't target projectiles, XP, or indicators
 			BlacklistShipTypes:   []ShipType{ShipTypeHomingSuicide}, // Don't target rockets
 		}
@@ -116,8 +167,9 @@ func GetShipTypeConfig(shipType ShipType) ShipTypeConfig {
 			Acceleration:        250.0, // Thrust acceleration
 			Health:              50.0,
 			Radius:              12.0,
-			ShootCooldown:       1.0 + rand.Float64()*1.5, // 1-2.5 seconds
+			ShootCooldown:       1.0, // AI ships roll their own 1-2.5 seconds at spawn (see RollShootCooldown)
 			Shape:               ShipShapeTriangle,
+			Sprite:              "sprites/shooter",
 			AngularAcceleration: 3.0,                     // Radians per second squared
 			MaxAngularSpeed:     2.0,                     // Radians per second
 			Friction:            0.9999,                  // Very very small friction
@@ -127,7 +179,171 @@ func GetShipTypeConfig(shipType ShipType) ShipTypeConfig {
 				{OffsetX: 0.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 12.0, WeaponType: WeaponTypeHomingMissile},
 			}, // No turrets (shoots from center)
 		}
+	case ShipTypeSwarmer:
+		return ShipTypeConfig{
+			Type:                ShipTypeSwarmer,
+			Name:                "Swarmer",
+			Speed:               260.0, // Max speed
+			Acceleration:        500.0, // Thrust acceleration
+			Health:              6.0,   // Dies to a single bullet
+			Radius:              5.0,
+			ShootCooldown:       0.0, // Doesn't shoot (rams)
+			Shape:               ShipShapeDiamond,
+			AngularAcceleration: 8.0,                  // Radians per second squared
+			MaxAngularSpeed:     5.0,                  // Radians per second
+			Friction:            0.995,                // Some drag so members settle into their slots
+			DefaultWeaponType:   WeaponTypeNone,       // Not used (doesn't shoot)
+			Score:               3,                    // Tiny score, but they come in numbers
+			TurretMounts:        []TurretMountPoint{}, // No turrets
+			TargetEntityTypes:   []EntityType{EntityTypePlayer, EntityTypeEnemy, EntityTypeStation},                // Target players, enemies and defended stations
+			TargetShipTypes:     []ShipType{ShipTypePlayer, ShipTypeShooter, ShipTypeFreighter},                    // Only target real ships (not rockets)
+			BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator}, // Don't target projectiles, XP, or indicators
+		}
+	case ShipTypeFreighter:
+		return ShipTypeConfig{
+			Type:                ShipTypeFreighter,
+			Name:                "Freighter",
+			Speed:               70.0, // Slow enough to escort
+			Acceleration:        60.0, // Thrust acceleration
+			Health:              600.0,
+			Radius:              24.0,
+			ShootCooldown:       0.0, // Unarmed
+			Shape:               ShipShapeSquare,
+			AngularAcceleration: 1.5,                  // Radians per second squared
+			MaxAngularSpeed:     0.8,                  // Radians per second
+			Friction:            0.995,                // Some drag so it doesn't overshoot its destination
+			DefaultWeaponType:   WeaponTypeNone,       // Not used (doesn't shoot)
+			Score:               0,                    // Escort objective, not a kill
+			TurretMounts:        []TurretMountPoint{}, // No turrets
+		}
+	case ShipTypeGunship:
+		return ShipTypeConfig{
+			Type:                ShipTypeGunship,
+			Name:                "Gunship",
+			Speed:               150.0, // Max speed (outruns shooters when fleeing)
+			Acceleration:        300.0, // Thrust acceleration
+			Health:              400.0,
+			Radius:              22.0,
+			ShootCooldown:       0.8,
+			Shape:               ShipShapeTriangle,
+			Sprite:              "sprites/shooter",
+			AngularAcceleration: 3.0,                     // Radians per second squared
+			MaxAngularSpeed:     2.0,                     // Radians per second
+			Friction:            0.9999,                  // Very very small friction
+			DefaultWeaponType:   WeaponTypeHomingMissile, // Fallback weapon type
+			Score:               150,                     // Mini-boss
+			DestructibleTurrets: true,                    // Broadside and EMP mounts can be shot off
+			StaggeredTurrets:    true,                    // Broadside guns take turns
+			TurretMounts: []TurretMountPoint{
+				{OffsetX: 0.0, OffsetY: -14.0, Angle: -math.Pi / 2, Active: true, BarrelLength: 14.0, WeaponType: WeaponTypeBullet, ArcMin: -math.Pi * 11 / 12, ArcMax: -math.Pi / 12}, // Right mount - bullets, broadside
+				{OffsetX: 20.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 12.0, WeaponType: WeaponTypeEMP},                                                              // Front mount - EMP missiles
+				{OffsetX: 0.0, OffsetY: 14.0, Angle: math.Pi / 2, Active: true, BarrelLength: 14.0, WeaponType: WeaponTypeBullet, ArcMin: math.Pi / 12, ArcMax: math.Pi * 11 / 12},    // Left mount - bullets, broadside
+			},
+		}
+	case ShipTypeStalker:
+		return ShipTypeConfig{
+			Type:                ShipTypeStalker,
+			Name:                "Stalker",
+			Speed:               160.0, // Max speed
+			Acceleration:        300.0, // Thrust acceleration
+			Health:              40.0,
+			Radius:              11.0,
+			ShootCooldown:       0.5,
+			Shape:               ShipShapeTriangle,
+			Sprite:              "sprites/shooter",
+			AngularAcceleration: 4.0,              // Radians per second squared
+			MaxAngularSpeed:     2.5,              // Radians per second
+			Friction:            0.9999,           // Very very small friction
+			DefaultWeaponType:   WeaponTypeBullet, // Fallback weapon type
+			Score:               35,               // Ambusher
+			Cloaking:            true,
+			TurretMounts: []TurretMountPoint{
+				{OffsetX: 10.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 12.0, WeaponType: WeaponTypeBullet},
+			},
+			TargetEntityTypes: []EntityType{EntityTypePlayer, EntityTypeEnemy, EntityTypeStation}, // Stalks ships and stations, ignores missiles
+		}
+	case ShipTypeDrone:
+		return ShipTypeConfig{
+			Type:                ShipTypeDrone,
+			Name:                "Drone",
+			Speed:               280.0, // Outruns the player so it keeps up with its orbit
+			Acceleration:        700.0, // Thrust acceleration
+			Health:              30.0,
+			Radius:              5.0,
+			ShootCooldown:       0.0, // Uses the turret's weapon cooldown
+			Shape:               ShipShapeDiamond,
+			AngularAcceleration: 14.0,             // Radians per second squared
+			MaxAngularSpeed:     8.0,              // Radians per second
+			Friction:            0.99,             // Some drag so it settles into its slot
+			DefaultWeaponType:   WeaponTypeBullet, // Fallback weapon type
+			Score:               0,                // Escort, not a kill
+			TurretMounts: []TurretMountPoint{
+				{OffsetX: 0.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 6.0, WeaponType: WeaponTypeBullet},
+			},
+		}
+	case ShipTypeSentry:
+		return ShipTypeConfig{
+			Type:                ShipTypeSentry,
+			Name:                "Sentry",
+			Speed:               0.0, // Stationary
+			Acceleration:        0.0, // Stationary
+			Health:              150.0,
+			Radius:              10.0,
+			ShootCooldown:       0.0, // Uses the turret's weapon cooldown
+			Shape:               ShipShapeSquare,
+			AngularAcceleration: 0.0,              // Only the turrets turn
+			MaxAngularSpeed:     0.0,              // Only the turrets turn
+			Friction:            0.9,              // Heavy drag so rams barely move it
+			DefaultWeaponType:   WeaponTypeBullet, // Fallback weapon type
+			Score:               0,                // Deployed by the player, not a kill
+			TurretMounts: []TurretMountPoint{
+				{OffsetX: 0.0, OffsetY: -5.0, Angle: 0.0, Active: true, BarrelLength: 10.0, WeaponType: WeaponTypeBullet},
+				{OffsetX: 0.0, OffsetY: 5.0, Angle: 0.0, Active: true, BarrelLength: 10.0, WeaponType: WeaponTypeBullet},
+			},
+			TargetEntityTypes: []EntityType{EntityTypePlayer, EntityTypeEnemy}, // Ships only
+		}
+	case ShipTypeCapitalCore:
+		return ShipTypeConfig{
+			Type:                ShipTypeCapitalCore,
+			Name:                "Capital Core",
+			Speed:               50.0, // Lumbering
+			Acceleration:        40.0, // Thrust acceleration
+			Health:              1200.0,
+			Radius:              26.0,
+			ShootCooldown:       1.5,
+			Shape:               ShipShapeCircle,
+			AngularAcceleration: 0.6,                     // Radians per second squared
+			MaxAngularSpeed:     0.3,                     // Radians per second
+			Friction:            0.995,                   // Some drag so it doesn't overshoot
+			DefaultWeaponType:   WeaponTypeHomingMissile, // Fallback weapon type
+			Score:               400,                     // Boss
+			DestructibleTurrets: true,                    // The launcher can be shot off
+			TurretMounts: []TurretMountPoint{
+				{OffsetX: 0.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 14.0, WeaponType: WeaponTypeHomingMissile},
+			},
+			TargetEntityTypes: []EntityType{EntityTypePlayer, EntityTypeEnemy, EntityTypeStation}, // Ships and stations
+		}
+	case ShipTypeCapitalSection:
+		return ShipTypeConfig{
+			Type:                ShipTypeCapitalSection,
+			Name:                "Capital Section",
+			Speed:               50.0, // Welded to the core (see capital.go)
+			Acceleration:        0.0,  // Welded to the core
+			Health:              250.0,
+			Radius:              18.0,
+			ShootCooldown:       0.0, // Uses the turret's weapon cooldown
+			Shape:               ShipShapeSquare,
+			AngularAcceleration: 0.0,              // Welded to the core
+			MaxAngularSpeed:     0.0,              // Welded to the core
+			Friction:            1.0,              // Welded to the core
+			DefaultWeaponType:   WeaponTypeBullet, // Fallback weapon type
+			Score:               40,               // Each hull section
+			DestructibleTurrets: true,             // Each section's gun can be shot off
+			TurretMounts: []TurretMountPoint{
+				{OffsetX: 0.0, OffsetY: 0.0, Angle: 0.0, Active: true, BarrelLength: 12.0, WeaponType: WeaponTypeBullet}, // Replaced per section (see capitalSections)
+			},
+		}
 	default:
-		return GetShipTypeConfig(ShipTypePlayer)
+		return baseShipTypeConfig(ShipTypePlayer)
 	}
 }
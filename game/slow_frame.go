@@ -0,0 +1,238 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// SlowFrameThreshold is the frame time (Update through Draw) above which the frame is written to the profiles directory
+	SlowFrameThreshold = 30 * time.Millisecond
+
+	// SlowFrameCooldown is the minimum time between two slow frame reports, so a bad stretch doesn't flood the disk
+	SlowFrameCooldown = 2 * time.Second
+
+	// SlowFrameWarmup ignores slow frames right after startup (loading, first GC)
+	SlowFrameWarmup = 3 * time.Second
+
+	// allocSampleInterval is the time between two snapshots of the allocation profile
+	allocSampleInterval = time.Second
+
+	// slowFrameTopAllocs is the number of allocation sites listed in a report
+	slowFrameTopAllocs = 10
+)
+
+// FrameSection is the time one part of a frame took
+type FrameSection struct {
+	Name     string
+	Duration time.Duration
+}
+
+// frameMetrics are the runtime counters read at the start and end of every frame
+var frameMetrics = []string{"/gc/heap/allocs:bytes", "/gc/heap/allocs:objects", "/gc/cycles/total:gc-cycles"}
+
+// FrameTimer times the sections of each frame and writes a report of any frame slower than SlowFrameThreshold:
+// per-section timings, what the frame allocated, and the top allocation sites since the last sample
+// It catches one-off hitches that the average FPS drop detector misses
+// Allocation sites come from the runtime's sampling memory profiler, which is always on and cheap to read
+type FrameTimer struct {
+	profilesDir string
+	created     time.Time
+	lastReport  time.Time
+
+	// Frames drawn so far
+	frame uint64
+
+	// The last frame's time and GC cycles (read by the GC governor)
+	LastFrame FrameGC
+
+	// Current frame (active from the first Update after a Draw until the end of the next Draw)
+	active     bool
+	start      time.Time
+	lastMark   time.Time
+	sections   []FrameSection
+	startStats []metrics.Sample
+	endStats   []metrics.Sample
+
+	// Allocation profile snapshot, by call stack
+	allocRecords  []runtime.MemProfileRecord
+	allocBaseline map[[32]uintptr]int64
+	allocSampled  time.Time
+}
+
+// NewFrameTimer creates a frame timer writing its reports to profilesDir
+func NewFrameTimer(profilesDir string) *FrameTimer {
+	newSamples := func() []metrics.Sample {
+		samples := make([]metrics.Sample, len(frameMetrics))
+		for i, name := range frameMetrics {
+			samples[i].Name = name
+		}
+		return samples
+	}
+	return &FrameTimer{
+		profilesDir:   profilesDir,
+		created:       time.Now(),
+		sections:      make([]FrameSection, 0, 16),
+		startStats:    newSamples(),
+		endStats:      newSamples(),
+		allocBaseline: make(map[[32]uintptr]int64),
+	}
+}
+
+// BeginFrame starts timing a frame (Update calls this; repeated calls before the Draw are ignored)
+func (t *FrameTimer) BeginFrame() {
+	if t.active {
+		return
+	}
+	t.active = true
+	t.start = time.Now()
+	t.lastMark = t.start
+	t.sections = t.sections[:0]
+	metrics.Read(t.startStats)
+}
+
+// Mark ends the current section of the frame under the given name (ignored outside a frame, e.g. headless)
+func (t *FrameTimer) Mark(name string) {
+	if !t.active {
+		return
+	}
+	now := time.Now()
+	duration := now.Sub(t.lastMark)
+	t.lastMark = now
+	for i := range t.sections {
+		if t.sections[i].Name == name {
+			t.sections[i].Duration += duration // Several Updates in one frame
+			return
+		}
+	}
+	t.sections = append(t.sections, FrameSection{Name: name, Duration: duration})
+}
+
+// EndFrame finishes the frame (Draw calls this) and reports it if it was slow
+func (t *FrameTimer) EndFrame() {
+	if !t.active {
+		return
+	}
+	t.active = false
+	t.frame++
+	now := time.Now()
+	frameTime := now.Sub(t.start)
+	metrics.Read(t.endStats)
+	t.LastFrame = FrameGC{
+		Frame:    t.frame,
+		Duration: frameTime,
+		Cycles:   t.endStats[2].Value.Uint64() - t.startStats[2].Value.Uint64(),
+	}
+
+	if frameTime > SlowFrameThreshold && now.Sub(t.created) > SlowFrameWarmup && now.Sub(t.lastReport) > SlowFrameCooldown {
+		t.lastReport = now
+		t.report(frameTime)
+	}
+
+	// Refresh the allocation baseline, so reports list what was allocated recently
+	if now.Sub(t.allocSampled) > allocSampleInterval {
+		t.sampleAllocs()
+	}
+}
+
+// report writes the slow frame's report to the profiles directory
+func (t *FrameTimer) report(frameTime time.Duration) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Frame %d took %.1f ms (threshold %.0f ms)\n\n", t.frame, milliseconds(frameTime), milliseconds(SlowFrameThreshold))
+
+	fmt.Fprintf(&b, "Sections:\n")
+	for _, section := range t.sections {
+		fmt.Fprintf(&b, "  %-28s %7.2f ms\n", section.Name, milliseconds(section.Duration))
+	}
+
+	delta := func(i int) uint64 {
+		return t.endStats[i].Value.Uint64() - t.startStats[i].Value.Uint64()
+	}
+	fmt.Fprintf(&b, "\nAllocated this frame: %d KB in %d objects, %d GC cycles\n", delta(0)/1024, delta(1), delta(2))
+
+	since := "startup"
+	if !t.allocSampled.IsZero() {
+		since = t.allocSampled.Format("15:04:05.000")
+	}
+	fmt.Fprintf(&b, "\nTop allocation sites since %s (sampled, as of the last GC):\n", since)
+	for _, site := range t.topAllocSites() {
+		fmt.Fprintf(&b, "  %8d KB  %s\n", site.bytes/1024, site.location)
+	}
+
+	// Write on a goroutine so the report doesn't cause the next hitch
+	frame := t.frame
+	path := filepath.Join(t.profilesDir, fmt.Sprintf("slow-frame-%d-%s.txt", frame, time.Now().Format("20060102-150405")))
+	report := b.String()
+	go func() {
+		if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+			fmt.Printf("Failed to write slow frame report: %v\n", err)
+			return
+		}
+		fmt.Printf("Slow frame %d (%.1f ms), report saved to: %s\n", frame, milliseconds(frameTime), path)
+	}()
+}
+
+// allocSite is a call site and the bytes it allocated since the baseline
+type allocSite struct {
+	location string
+	bytes    int64
+}
+
+// readAllocs fetches the current allocation profile into allocRecords
+func (t *FrameTimer) readAllocs() []runtime.MemProfileRecord {
+	for {
+		n, ok := runtime.MemProfile(t.allocRecords, true)
+		if ok {
+			return t.allocRecords[:n]
+		}
+		t.allocRecords = make([]runtime.MemProfileRecord, n+n/4+16)
+	}
+}
+
+// sampleAllocs makes the current allocation profile the baseline
+func (t *FrameTimer) sampleAllocs() {
+	t.allocSampled = time.Now()
+	for _, record := range t.readAllocs() {
+		t.allocBaseline[record.Stack0] = record.AllocBytes
+	}
+}
+
+// topAllocSites returns the call sites that allocated the most since the baseline, largest first
+func (t *FrameTimer) topAllocSites() []allocSite {
+	bytesByLocation := make(map[string]int64)
+	for _, record := range t.readAllocs() {
+		if bytes := record.AllocBytes - t.allocBaseline[record.Stack0]; bytes > 0 {
+			bytesByLocation[allocLocation(record.Stack())] += bytes
+		}
+	}
+	sites := make([]allocSite, 0, len(bytesByLocation))
+	for location, bytes := range bytesByLocation {
+		sites = append(sites, allocSite{location: location, bytes: bytes})
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].bytes > sites[j].bytes
+	})
+	return sites[:min(len(sites), slowFrameTopAllocs)]
+}
+
+// allocLocation names the first caller outside the runtime in an allocation stack
+func allocLocation(stack []uintptr) string {
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") || !more {
+			return fmt.Sprintf("%s %s:%d", frame.Function, filepath.Base(frame.File), frame.Line)
+		}
+	}
+}
+
+// milliseconds converts a duration to milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const (
+	smokeTicks       = 10000
+	smokeMaxEntities = 5000 // Far above anything a normal game reaches
+)
+
+// smokeBotRecording scripts a bot that flies and weaves around the arena, holding fire
+// and pulsing the tractor beam; it changes course at random intervals from a fixed seed
+func smokeBotRecording(ticks int) []InputFrame {
+	rng := rand.New(rand.NewSource(1))
+	frames := make([]InputFrame, ticks)
+	frame := InputFrame{Thrust: 1, Shoot: true}
+	for tick := range frames {
+		if tick%30 == 0 && rng.Intn(3) == 0 {
+			frame.Thrust = float64(rng.Intn(3) - 1)
+			frame.Rotation = float64(rng.Intn(3) - 1)
+		}
+		frame.Tractor = tick%240 < 60
+		frames[tick] = frame
+	}
+	return frames
+}
+
+// TestSmokeScriptedRun replays a scripted bot as the player through the whole update pipeline
+// and checks the world stays sane: no panics, no NaN positions, bounded entities, monotonic score
+func TestSmokeScriptedRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("smoke test runs 10k ticks")
+	}
+
+	g := NewGame(DefaultConfig())
+	playerInput, ok := g.player.Input.(*PlayerInput)
+	if !ok {
+		t.Fatalf("player input is %T, want *PlayerInput", g.player.Input)
+	}
+	playerInput.SetScript(ReplayInput(smokeBotRecording(smokeTicks)))
+
+	lastScore := g.score
+	for tick := 0; tick < smokeTicks; tick++ {
+		if err := g.Step(1.0 / 60); err != nil {
+			t.Fatalf("tick %d: Step: %v", tick, err)
+		}
+
+		for _, entity := range g.world.AllEntities {
+			if !finite(entity.X) || !finite(entity.Y) || !finite(entity.VX) || !finite(entity.VY) {
+				t.Fatalf("tick %d: entity %d (type %v) has a non-finite position or velocity: (%v, %v) v=(%v, %v)",
+					tick, entity.ID, entity.Type, entity.X, entity.Y, entity.VX, entity.VY)
+			}
+		}
+		if count := len(g.world.AllEntities); count > smokeMaxEntities {
+			t.Fatalf("tick %d: %d entities, want at most %d", tick, count, smokeMaxEntities)
+		}
+		if g.score < lastScore {
+			t.Fatalf("tick %d: score dropped from %d to %d", tick, lastScore, g.score)
+		}
+		lastScore = g.score
+	}
+	t.Logf("%d ticks: score %d, wave %d, %d entities, player alive %v",
+		smokeTicks, g.score, g.waveNumber, len(g.world.AllEntities), g.player.Active && g.player.Health > 0)
+}
+
+// TestSmokeReplayMatchesRecording checks a recording of scripted input replays frame for frame
+func TestSmokeReplayMatchesRecording(t *testing.T) {
+	frames := smokeBotRecording(600)
+	input := NewPlayerInput()
+	input.SetScript(ReplayInput(frames))
+	input.StartRecording()
+	for range frames {
+		input.Update(1.0 / 60)
+	}
+	recorded := input.StopRecording()
+
+	if len(recorded) != len(frames) {
+		t.Fatalf("recorded %d frames, want %d", len(recorded), len(frames))
+	}
+	for i := range frames {
+		if recorded[i] != frames[i] {
+			t.Fatalf("frame %d: recorded %+v, want %+v", i, recorded[i], frames[i])
+		}
+	}
+}
+
+// TestSmokeSameSeedSameWorld runs the same seed and script twice and checks both worlds end up identical
+func TestSmokeSameSeedSameWorld(t *testing.T) {
+	const ticks = 1200
+	config := DefaultConfig()
+	config.RunSeed = 1
+	run := func() *Game {
+		g := NewGame(config)
+		g.player.Input.(*PlayerInput).SetScript(ReplayInput(smokeBotRecording(ticks)))
+		for tick := 0; tick < ticks; tick++ {
+			if err := g.Step(1.0 / 60); err != nil {
+				t.Fatalf("tick %d: Step: %v", tick, err)
+			}
+		}
+		return g
+	}
+
+	first, second := run(), run()
+	if divergence, diverged := DiffWorlds(first.world, second.world); diverged {
+		t.Fatalf("same seed diverged after %d ticks: %v", ticks, divergence)
+	}
+	if a, b := first.world.Checksum(), second.world.Checksum(); a != b {
+		t.Fatalf("checksums %016x and %016x differ for matching worlds", a, b)
+	}
+}
+
+// finite returns true if v is neither NaN nor infinite
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
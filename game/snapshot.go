@@ -0,0 +1,210 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SnapshotVersion is bumped whenever EntityState or SnapshotDelta change shape
+const SnapshotVersion = 2
+
+// EntityState is the replicated state of a single entity
+type EntityState struct {
+	ID       uint64
+	Type     EntityType
+	ShipType ShipType
+	Faction  Faction
+
+	X, Y     float64
+	VX, VY   float64
+	Rotation float64
+
+	Health    float64
+	MaxHealth float64
+	Radius    float64
+	Age       float64
+	Lifetime  float64
+
+	NoCollision bool
+
+	Indicator IndicatorKind // Destroyed indicators only
+}
+
+// SnapshotDelta describes world changes between two snapshots
+// A delta with BaseTick 0 is a full snapshot and replaces the receiving world's contents
+type SnapshotDelta struct {
+	Version  int
+	Tick     uint64 // Sequence number of this snapshot
+	BaseTick uint64 // Snapshot this delta applies on top of (0 = full snapshot)
+
+	Spawned   []EntityState // Entities that didn't exist in the base snapshot
+	Moved     []EntityState // Entities whose state changed since the base snapshot
+	Despawned []uint64      // IDs of entities removed since the base snapshot
+}
+
+// captureEntityState copies the replicated fields of an entity
+func captureEntityState(e *Entity) EntityState {
+	return EntityState{
+		ID:          e.ID,
+		Type:        e.Type,
+		ShipType:    e.ShipType,
+		Faction:     e.Faction,
+		X:           e.X,
+		Y:           e.Y,
+		VX:          e.VX,
+		VY:          e.VY,
+		Rotation:    e.Rotation,
+		Health:      e.Health,
+		MaxHealth:   e.MaxHealth,
+		Radius:      e.Radius,
+		Age:         e.Age,
+		Lifetime:    e.Lifetime,
+		NoCollision: e.NoCollision,
+		Indicator:   e.Indicator.Kind,
+	}
+}
+
+// applyEntityState copies replicated fields onto an entity
+func applyEntityState(e *Entity, state EntityState) {
+	e.ID = state.ID
+	e.Type = state.Type
+	e.ShipType = state.ShipType
+	e.Faction = state.Faction
+	e.X = state.X
+	e.Y = state.Y
+	e.VX = state.VX
+	e.VY = state.VY
+	e.Rotation = state.Rotation
+	e.Health = state.Health
+	e.MaxHealth = state.MaxHealth
+	e.Radius = state.Radius
+	e.Age = state.Age
+	e.Lifetime = state.Lifetime
+	e.NoCollision = state.NoCollision
+	e.Indicator.Kind = state.Indicator
+}
+
+// sameMotionState returns true if two states are equal apart from Age
+// Age changes every tick for every entity, so it alone doesn't count as movement
+func sameMotionState(a, b EntityState) bool {
+	a.Age = b.Age
+	return a == b
+}
+
+// Snapshot returns the changes since the previous Snapshot call
+// The first call returns every active entity as spawned
+func (w *World) Snapshot() SnapshotDelta {
+	delta := SnapshotDelta{
+		Version:  SnapshotVersion,
+		Tick:     w.snapshotTick + 1,
+		BaseTick: w.snapshotTick,
+	}
+
+	seen := make(map[uint64]struct{}, len(w.AllEntities)+len(w.FX))
+	for _, entities := range [...][]*Entity{w.AllEntities, w.FX} {
+		for _, entity := range entities {
+			if !entity.Active {
+				continue
+			}
+			state := captureEntityState(entity)
+			seen[state.ID] = struct{}{}
+
+			previous, existed := w.snapshotState[state.ID]
+			if !existed {
+				delta.Spawned = append(delta.Spawned, state)
+			} else if !sameMotionState(previous, state) {
+				delta.Moved = append(delta.Moved, state)
+			}
+			w.snapshotState[state.ID] = state
+		}
+	}
+
+	// Anything in the previous snapshot that's no longer active was despawned
+	for id := range w.snapshotState {
+		if _, ok := seen[id]; !ok {
+			delta.Despawned = append(delta.Despawned, id)
+			delete(w.snapshotState, id)
+		}
+	}
+	// Map iteration is random, keep the output canonical
+	sort.Slice(delta.Despawned, func(i, j int) bool { return delta.Despawned[i] < delta.Despawned[j] })
+
+	w.snapshotTick = delta.Tick
+	return delta
+}
+
+// FullSnapshot returns the complete world state without affecting Snapshot's delta tracking
+func (w *World) FullSnapshot() SnapshotDelta {
+	delta := SnapshotDelta{
+		Version:  SnapshotVersion,
+		Tick:     w.snapshotTick,
+		BaseTick: 0,
+		Spawned:  make([]EntityState, 0, len(w.AllEntities)+len(w.FX)),
+	}
+	for _, entities := range [...][]*Entity{w.AllEntities, w.FX} {
+		for _, entity := range entities {
+			if entity.Active {
+				delta.Spawned = append(delta.Spawned, captureEntityState(entity))
+			}
+		}
+	}
+	return delta
+}
+
+// ApplySnapshot applies a snapshot delta to this world
+// Entities created from snapshots have no input provider; the sender owns their behavior
+func (w *World) ApplySnapshot(delta SnapshotDelta) error {
+	if delta.Version != SnapshotVersion {
+		return fmt.Errorf("snapshot version %d not supported (want %d)", delta.Version, SnapshotVersion)
+	}
+
+	if delta.BaseTick == 0 {
+		// Full snapshot: drop everything that isn't part of it
+		keep := make(map[uint64]struct{}, len(delta.Spawned))
+		for _, state := range delta.Spawned {
+			keep[state.ID] = struct{}{}
+		}
+		for _, entities := range [...][]*Entity{w.AllEntities, w.FX} {
+			// Backwards, since unregistering shrinks the list
+			for i := len(entities) - 1; i >= 0; i-- {
+				entity := entities[i]
+				if _, ok := keep[entity.ID]; !ok {
+					entity.Active = false
+					w.UnregisterEntity(entity)
+				}
+			}
+		}
+	} else if delta.BaseTick != w.appliedTick {
+		return fmt.Errorf("snapshot base tick %d does not match last applied tick %d", delta.BaseTick, w.appliedTick)
+	}
+
+	for _, id := range delta.Despawned {
+		if entity := w.GetEntityByID(id); entity != nil {
+			entity.Active = false
+			w.UnregisterEntity(entity)
+		}
+	}
+
+	for _, state := range delta.Spawned {
+		w.applyEntitySnapshotState(state)
+	}
+	for _, state := range delta.Moved {
+		w.applyEntitySnapshotState(state)
+	}
+
+	w.appliedTick = delta.Tick
+	return nil
+}
+
+// applyEntitySnapshotState updates an existing entity or creates it if missing
+func (w *World) applyEntitySnapshotState(state EntityState) {
+	entity := w.GetEntityByID(state.ID)
+	if entity == nil {
+		entity = NewEntity(state.X, state.Y, state.Radius, state.Type, nil)
+		applyEntityState(entity, state)
+		w.RegisterEntity(entity)
+		return
+	}
+	applyEntityState(entity, state)
+	w.UpdateEntityCell(entity)
+}
@@ -0,0 +1,86 @@
+package game
+
+import "math"
+
+// WaveBudgetConfig controls how random endless waves are generated
+// Each wave gets a point budget that grows every wave and is spent on enemies by their Cost,
+// so difficulty growth is tuned here instead of by raw enemy counts
+type WaveBudgetConfig struct {
+	Base    float64 // Points for wave 1
+	PerWave float64 // Points added every wave
+
+	// MaxTypeShare caps the share of a wave's budget one enemy type may take (0-1)
+	MaxTypeShare float64
+
+	// MinVariety is the number of different enemy types every wave contains (when the budget allows)
+	MinVariety int
+}
+
+// DefaultWaveBudgetConfig returns budgets matching the old 10 + 1 per wave random waves on average
+func DefaultWaveBudgetConfig() WaveBudgetConfig {
+	return WaveBudgetConfig{
+		Base:         22.0,
+		PerWave:      2.5,
+		MaxTypeShare: 0.6,
+		MinVariety:   2,
+	}
+}
+
+// Budget returns the point budget for a 1-based wave number
+func (c WaveBudgetConfig) Budget(waveNumber int) int {
+	return int(math.Round(c.Base + c.PerWave*float64(waveNumber-1)))
+}
+
+// GenerateWave appends a random wave's spawn order to queue by spending the wave's budget
+// It first buys MinVariety different types, then weighted random types that fit the remaining budget
+// and their MaxTypeShare cap, until nothing more can be bought
+func (c WaveBudgetConfig) GenerateWave(queue []EnemyType, waveNumber int) []EnemyType {
+	budget := c.Budget(waveNumber)
+	maxTypePoints := max(int(float64(budget)*c.MaxTypeShare), 1)
+	remaining := budget
+	spent := make(map[EnemyType]int, len(EnemyTypes)) // Points spent per type
+	start := len(queue)
+
+	// buyable returns the types that fit the remaining budget and their share cap
+	candidates := make([]EnemyType, 0, len(EnemyTypes))
+	buyable := func(unusedOnly bool) []EnemyType {
+		candidates = candidates[:0]
+		for _, enemyType := range EnemyTypes {
+			cost := GetEnemyTypeConfig(enemyType).Cost
+			if cost <= 0 || cost > remaining || spent[enemyType]+cost > maxTypePoints || (unusedOnly && spent[enemyType] > 0) {
+				continue
+			}
+			candidates = append(candidates, enemyType)
+		}
+		return candidates
+	}
+	buy := func(enemyType EnemyType) {
+		cost := GetEnemyTypeConfig(enemyType).Cost
+		spent[enemyType] += cost
+		remaining -= cost
+		queue = append(queue, enemyType)
+	}
+
+	// Guaranteed variety: one each of MinVariety different types
+	for i := 0; i < c.MinVariety; i++ {
+		enemyType, ok := pickWeightedEnemyType(buyable(true))
+		if !ok {
+			break
+		}
+		buy(enemyType)
+	}
+
+	// Spend the rest
+	for {
+		enemyType, ok := pickWeightedEnemyType(buyable(false))
+		if !ok {
+			break
+		}
+		buy(enemyType)
+	}
+
+	// Mix the guaranteed picks into the rest of the wave
+	wave := queue[start:]
+	runRNG.Shuffle(len(wave), func(i, j int) { wave[i], wave[j] = wave[j], wave[i] })
+	return queue
+}
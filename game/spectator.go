@@ -0,0 +1,242 @@
+package game
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// websocketGUID is the fixed GUID from RFC 6455 used to compute the handshake accept key
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// spectatorClientBuffer is how many messages may queue for a client before it is dropped
+const spectatorClientBuffer = 32
+
+//go:embed spectator.html
+var spectatorViewerHTML []byte
+
+// SpectatorServer serves a web viewer and streams world snapshots to it over WebSocket
+// Publish must be called from the simulation goroutine; HTTP handlers run on their own goroutines
+type SpectatorServer struct {
+	mu      sync.Mutex
+	clients map[*spectatorClient]struct{}
+	mux     *http.ServeMux
+}
+
+// spectatorClient is a single connected viewer
+type spectatorClient struct {
+	conn     net.Conn
+	send     chan []byte
+	needFull bool // Client hasn't received a full snapshot yet
+	closed   bool
+}
+
+// NewSpectatorServer creates a spectator server serving the viewer at / and the stream at /ws
+func NewSpectatorServer() *SpectatorServer {
+	s := &SpectatorServer{
+		clients: make(map[*spectatorClient]struct{}),
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/", s.handleViewer)
+	s.mux.HandleFunc("/ws", s.handleWebSocket)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *SpectatorServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Publish sends the changes since the last publish to every viewer
+// Newly connected viewers get a full snapshot first so they can apply later deltas
+func (s *SpectatorServer) Publish(world *World) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Always advance the delta chain, even with nobody watching, so deltas stay small
+	delta, err := json.Marshal(world.Snapshot())
+	if err != nil {
+		log.Printf("spectator: failed to encode snapshot: %v", err)
+		return
+	}
+
+	var full []byte
+	for client := range s.clients {
+		message := delta
+		if client.needFull {
+			if full == nil {
+				full, err = json.Marshal(world.FullSnapshot())
+				if err != nil {
+					log.Printf("spectator: failed to encode full snapshot: %v", err)
+					return
+				}
+			}
+			message = full
+			client.needFull = false
+		}
+
+		// Never block the simulation on a slow viewer; drop it instead
+		select {
+		case client.send <- message:
+		default:
+			s.removeClientLocked(client)
+		}
+	}
+}
+
+// handleViewer serves the embedded HTML viewer
+func (s *SpectatorServer) handleViewer(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(spectatorViewerHTML)
+}
+
+// handleWebSocket upgrades the connection and registers the viewer
+func (s *SpectatorServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "expected websocket upgrade", http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+
+	// Complete the RFC 6455 handshake
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(hash[:])
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", accept)
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return
+	}
+
+	client := &spectatorClient{
+		conn:     conn,
+		send:     make(chan []byte, spectatorClientBuffer),
+		needFull: true,
+	}
+	s.mu.Lock()
+	s.clients[client] = struct{}{}
+	s.mu.Unlock()
+
+	go s.writeLoop(client)
+	go s.readLoop(client, rw.Reader)
+}
+
+// writeLoop sends queued messages to the viewer as WebSocket text frames
+func (s *SpectatorServer) writeLoop(client *spectatorClient) {
+	for message := range client.send {
+		if err := writeWebSocketFrame(client.conn, 0x1, message); err != nil {
+			s.removeClient(client)
+			return
+		}
+	}
+}
+
+// readLoop discards viewer messages and unregisters the viewer when it disconnects
+func (s *SpectatorServer) readLoop(client *spectatorClient, reader *bufio.Reader) {
+	defer s.removeClient(client)
+	for {
+		opcode, err := readWebSocketFrame(reader)
+		if err != nil || opcode == 0x8 { // 0x8 = close
+			return
+		}
+	}
+}
+
+// removeClient unregisters a viewer and closes its connection
+func (s *SpectatorServer) removeClient(client *spectatorClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeClientLocked(client)
+}
+
+// removeClientLocked unregisters a viewer; s.mu must be held
+func (s *SpectatorServer) removeClientLocked(client *spectatorClient) {
+	if client.closed {
+		return
+	}
+	client.closed = true
+	delete(s.clients, client)
+	close(client.send)
+	client.conn.Close()
+}
+
+// writeWebSocketFrame writes a single unmasked, unfragmented frame (server-to-client)
+func writeWebSocketFrame(w io.Writer, opcode byte, payload []byte) error {
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|opcode) // FIN + opcode
+
+	length := len(payload)
+	switch {
+	case length < 126:
+		header = append(header, byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readWebSocketFrame reads and discards a single client frame, returning its opcode
+func readWebSocketFrame(r *bufio.Reader) (byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return 0, err
+	}
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if masked {
+		length += 4 // Masking key
+	}
+	if _, err := io.CopyN(io.Discard, r, int64(length)); err != nil {
+		return 0, err
+	}
+	return opcode, nil
+}
@@ -0,0 +1,58 @@
+package game
+
+import "math"
+
+const (
+	// StationRadius is the collision radius of a derelict station
+	StationRadius = 70.0
+
+	// StationHealth is how much damage it takes to break a station open
+	StationHealth = 800.0
+
+	// StationLootPickups is the number of pickups a station's loot is split into when it breaks
+	StationLootPickups = 6
+
+	// StationSpinSpeed is the maximum tumble speed of a derelict station (radians per second)
+	StationSpinSpeed = 0.15
+)
+
+// StationData holds the state of a station
+type StationData struct {
+	Loot      int        // Score value released when the station is destroyed
+	Inventory []ShopItem // What the station sells to a docked player (see docking.go)
+}
+
+// NewStation creates a neutral derelict station holding loot (set Station.Inventory to make it a shop),
+// tumbling at a random angle and speed
+func NewStation(x, y float64, loot int) *Entity {
+	station := newStation(x, y, loot)
+	station.Rotation = runRNG.Float64() * 2 * math.Pi
+	station.AngularVelocity = (runRNG.Float64()*2 - 1) * StationSpinSpeed
+	return station
+}
+
+// newStation creates a neutral derelict station holding loot without rolling its tumble
+func newStation(x, y float64, loot int) *Entity {
+	station := NewEntity(x, y, StationRadius, EntityTypeStation, nil)
+	station.MaxHealth = StationHealth
+	station.Health = StationHealth
+	station.Faction = FactionNeutral
+	station.Station.Loot = loot
+	return station
+}
+
+// lootStation scatters a destroyed station's loot as pickups for the player
+func (g *Game) lootStation(station *Entity) {
+	if g.player == nil || !g.player.Active || station.Station.Loot <= 0 {
+		return
+	}
+	value := max(station.Station.Loot/StationLootPickups, 1)
+	for i := 0; i < StationLootPickups; i++ {
+		angle := float64(i) / StationLootPickups * 2 * math.Pi
+		g.spawnPickup(
+			station.X+math.Cos(angle)*station.Radius*0.5,
+			station.Y+math.Sin(angle)*station.Radius*0.5,
+			value, g.player, ScoreLoot,
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+// StatsPanelInterval is the time between two refreshes of the entity statistics panel (seconds)
+const StatsPanelInterval = 1.0
+
+// cellOccupancyBuckets are the lower bounds of the cell occupancy histogram's buckets (entities per cell)
+var cellOccupancyBuckets = [...]int{1, 2, 4, 8, 16, 32}
+
+// entityTypeNames and factionNames label the panel's rows
+var (
+	entityTypeNames = [entityTypeCount]string{"Player", "Enemy", "Projectile", "Indicator", "XP", "Rocket", "Asteroid", "Station", "Debris"}
+	factionNames    = [factionCount]string{"Player", "Enemy", "Neutral", "Independents"}
+)
+
+// EntityStats counts the live entities of the world at one moment
+type EntityStats struct {
+	Total    int
+	Types    [entityTypeCount]int
+	Ships    [ShipTypeCount]int // Players and enemies only
+	Factions [factionCount]int
+
+	// Projectile pool use (projectiles in flight out of the pool's capacity)
+	Projectiles        int
+	ProjectileCapacity int
+
+	// Occupied cells per cellOccupancyBuckets bucket, and the entities in the busiest cell
+	Cells       [len(cellOccupancyBuckets)]int
+	BusiestCell int
+}
+
+// collectEntityStats counts the world's active entities
+func collectEntityStats(world *World, projectiles, projectileCapacity int) EntityStats {
+	stats := EntityStats{Projectiles: projectiles, ProjectileCapacity: projectileCapacity}
+	for _, entity := range world.AllEntities {
+		if !entity.Active {
+			continue
+		}
+		stats.Total++
+		if entity.Type >= 0 && entity.Type < entityTypeCount {
+			stats.Types[entity.Type]++
+		}
+		if (entity.Type == EntityTypePlayer || entity.Type == EntityTypeEnemy) && entity.ShipType >= 0 && entity.ShipType < ShipTypeCount {
+			stats.Ships[entity.ShipType]++
+		}
+		if faction := GetEntityFaction(entity); faction >= 0 && faction < factionCount {
+			stats.Factions[faction]++
+		}
+	}
+
+	world.cells.eachOccupiedCell(func(cell *Cell) {
+		stats.BusiestCell = max(stats.BusiestCell, cell.Count)
+		bucket := 0
+		for bucket+1 < len(cellOccupancyBuckets) && cell.Count >= cellOccupancyBuckets[bucket+1] {
+			bucket++
+		}
+		stats.Cells[bucket]++
+	})
+
+	// Debris lives outside AllEntities (see debris.go)
+	stats.Types[EntityTypeDebris] = len(world.Debris)
+	stats.Total += len(world.Debris)
+	return stats
+}
+
+// raise raises every count to at least the other stats' count (used to keep peaks)
+func (s *EntityStats) raise(other EntityStats) {
+	s.Total = max(s.Total, other.Total)
+	for i := range s.Types {
+		s.Types[i] = max(s.Types[i], other.Types[i])
+	}
+	for i := range s.Ships {
+		s.Ships[i] = max(s.Ships[i], other.Ships[i])
+	}
+	for i := range s.Factions {
+		s.Factions[i] = max(s.Factions[i], other.Factions[i])
+	}
+	s.Projectiles = max(s.Projectiles, other.Projectiles)
+	s.ProjectileCapacity = max(s.ProjectileCapacity, other.ProjectileCapacity)
+	for i := range s.Cells {
+		s.Cells[i] = max(s.Cells[i], other.Cells[i])
+	}
+	s.BusiestCell = max(s.BusiestCell, other.BusiestCell)
+}
+
+// StatsPanel holds the counts shown by the entity statistics debug panel (F5, see DebugState.ShowStats)
+// It samples once per StatsPanelInterval even while hidden, so the peaks cover the whole run
+type StatsPanel struct {
+	Current EntityStats
+	Peak    EntityStats
+
+	timer float64
+}
+
+// NewStatsPanel creates an empty panel
+func NewStatsPanel() *StatsPanel {
+	return &StatsPanel{}
+}
+
+// Reset clears the counts and peaks (a new run)
+func (p *StatsPanel) Reset() {
+	p.Current = EntityStats{}
+	p.Peak = EntityStats{}
+	p.timer = 0
+}
+
+// Update refreshes the counts once per StatsPanelInterval
+func (p *StatsPanel) Update(deltaTime float64, world *World, projectiles, projectileCapacity int) {
+	p.timer -= deltaTime
+	if p.timer > 0 {
+		return
+	}
+	p.timer = StatsPanelInterval
+	p.Current = collectEntityStats(world, projectiles, projectileCapacity)
+	p.Peak.raise(p.Current)
+}
@@ -0,0 +1,117 @@
+package game
+
+import "math"
+
+const (
+	// SlowedAccelerationScale is how hard a slowed ship accelerates and turns, relative to normal
+	SlowedAccelerationScale = 0.5
+
+	// BurnTickInterval is how often a burning ship takes the burn damage built up since the last tick (seconds)
+	BurnTickInterval = 0.5
+)
+
+// StatusEffects holds the timed conditions affecting a ship
+type StatusEffects struct {
+	// Disabled is the time left the ship is knocked out by an EMP (seconds):
+	// AI ships drift dead in space and no ship can fire; the player's controls are scrambled
+	Disabled float64
+
+	// Invulnerable is the time left the ship can't be damaged (seconds), e.g. after respawning in place
+	Invulnerable float64
+
+	// Burning is the time left the ship is on fire (seconds), losing BurnDPS health per second
+	Burning float64
+	BurnDPS float64
+	// BurnSource is the hit that set the ship on fire, credited with the burn damage
+	BurnSource DamageRecord
+	burnDamage float64 // Burn damage built up since the last tick
+	burnTimer  float64 // Time since the last burn tick (seconds)
+
+	// Slowed is the time left the ship's engines are sapped (seconds): it accelerates and turns at SlowedAccelerationScale
+	Slowed float64
+}
+
+// Update counts the effects down
+func (s *StatusEffects) Update(deltaTime float64) {
+	s.Disabled = math.Max(s.Disabled-deltaTime, 0)
+	s.Invulnerable = math.Max(s.Invulnerable-deltaTime, 0)
+	s.Slowed = math.Max(s.Slowed-deltaTime, 0)
+	if s.Burning = math.Max(s.Burning-deltaTime, 0); s.Burning == 0 {
+		s.BurnDPS = 0
+		s.BurnSource = DamageRecord{}
+		s.burnDamage, s.burnTimer = 0, 0
+	}
+}
+
+// IsInvulnerable returns true while the ship can't be damaged
+func (s *StatusEffects) IsInvulnerable() bool {
+	return s.Invulnerable > 0
+}
+
+// Protect makes the ship invulnerable for duration, unless it already is for longer
+func (s *StatusEffects) Protect(duration float64) {
+	s.Invulnerable = math.Max(s.Invulnerable, duration)
+}
+
+// IsDisabled returns true while an EMP keeps the ship's systems down
+func (s *StatusEffects) IsDisabled() bool {
+	return s.Disabled > 0
+}
+
+// Disable knocks the ship's systems out for duration, unless they're already out for longer
+func (s *StatusEffects) Disable(duration float64) {
+	s.Disabled = math.Max(s.Disabled, duration)
+}
+
+// IsBurning returns true while the ship is on fire
+func (s *StatusEffects) IsBurning() bool {
+	return s.Burning > 0
+}
+
+// Ignite sets the ship on fire for duration at dps damage per second
+// A burning ship keeps the longer of the two fires and the hotter of the two rates; the latest hit takes the credit
+func (s *StatusEffects) Ignite(duration, dps float64, source DamageRecord) {
+	if duration <= 0 || dps <= 0 {
+		return
+	}
+	s.Burning = math.Max(s.Burning, duration)
+	s.BurnDPS = math.Max(s.BurnDPS, dps)
+	s.BurnSource = source
+}
+
+// Burn builds up the burn damage of deltaTime and returns it once a tick is due
+// (every BurnTickInterval, and when the fire goes out), 0 in between
+func (s *StatusEffects) Burn(deltaTime float64) float64 {
+	if !s.IsBurning() {
+		return 0
+	}
+	s.burnDamage += s.BurnDPS * math.Min(deltaTime, s.Burning)
+	s.burnTimer += deltaTime
+	if s.burnTimer < BurnTickInterval && s.Burning > deltaTime {
+		return 0
+	}
+	damage := s.burnDamage
+	s.burnDamage, s.burnTimer = 0, 0
+	return damage
+}
+
+// IsSlowed returns true while the ship's engines are sapped
+func (s *StatusEffects) IsSlowed() bool {
+	return s.Slowed > 0
+}
+
+// Slow saps the ship's engines for duration, unless they already are for longer
+func (s *StatusEffects) Slow(duration float64) {
+	s.Slowed = math.Max(s.Slowed, duration)
+}
+
+// disabledControls returns the control inputs a disabled ship actually gets:
+// AI ships get nothing, the player gets their controls reversed and stuttering
+func (e *Entity) disabledControls(rotation, thrust float64) (float64, float64) {
+	if _, isAI := e.Input.(*AIInput); isAI || e.Type != EntityTypePlayer {
+		return 0, 0
+	}
+	// Deterministic noise from the ship's age keeps scripted replays repeatable
+	jitter := math.Sin(e.Age*17) * math.Sin(e.Age*5.3)
+	return math.Max(-1, math.Min(1, -rotation+jitter)), thrust * math.Abs(math.Sin(e.Age*9))
+}
@@ -0,0 +1,48 @@
+package game
+
+import "image/color"
+
+const (
+	// StatusIconSize is the width and height of a status icon on screen (pixels)
+	StatusIconSize = 12
+
+	// statusIconSpacing is the gap between two icons in the row above a ship (pixels)
+	statusIconSpacing = 2
+)
+
+// StatusIcon identifies one of the icons shown above a ship for its active status effects
+type StatusIcon int
+
+const (
+	StatusIconBurning  StatusIcon = iota // On fire, taking damage over time
+	StatusIconSlowed                     // Engines sapped
+	StatusIconDisabled                   // Knocked out by an EMP
+	StatusIconShielded                   // Shield up or invulnerable
+	statusIconCount
+)
+
+// statusIconColors are the icons' ring colors
+var statusIconColors = [statusIconCount]color.RGBA{
+	StatusIconBurning:  {255, 130, 30, 255},
+	StatusIconSlowed:   {90, 140, 255, 255},
+	StatusIconDisabled: {160, 230, 255, 255},
+	StatusIconShielded: {120, 255, 200, 255},
+}
+
+// appendStatusIcons appends the icons of the entity's active status effects to icons, in display order
+// (the caller passes a reused buffer so drawing icons doesn't allocate every frame)
+func appendStatusIcons(icons []StatusIcon, entity *Entity) []StatusIcon {
+	if entity.Status.IsBurning() {
+		icons = append(icons, StatusIconBurning)
+	}
+	if entity.Status.IsSlowed() {
+		icons = append(icons, StatusIconSlowed)
+	}
+	if entity.Status.IsDisabled() {
+		icons = append(icons, StatusIconDisabled)
+	}
+	if entity.Shield > 0 || entity.Status.IsInvulnerable() {
+		icons = append(icons, StatusIconShielded)
+	}
+	return icons
+}
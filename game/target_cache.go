@@ -0,0 +1,64 @@
+package game
+
+// targetCache shares broad-phase spatial scans between targeting queries
+// Player turrets, AI ships and homing rockets all search for targets around themselves; with large cells
+// most of those searches cover the same cell rectangle, so the grid scan for a rectangle is done once and reused
+// Results are every active entity in the rectangle's cells (a superset of the circle): callers check exact distances
+// The cache empties itself whenever cell membership changes (see World.gridVersion)
+type targetCache struct {
+	version uint64 // World grid version the cached scans were taken at
+
+	regions map[targetRegion]int // Cell rectangle -> index into scans
+	scans   [][]*Entity          // Result buffers, reused across invalidations
+	used    int                  // Buffers in use since the last invalidation
+}
+
+// targetRegion is an inclusive rectangle of cell coordinates
+type targetRegion struct {
+	minX, minY, maxX, maxY int
+}
+
+// QueryTargetCandidates returns the active entities in the cells covering a circle, sharing the scan
+// with any other query over the same cells since the grid last changed
+// The returned slice is shared: callers must not modify it or keep it past the current tick
+func (w *World) QueryTargetCandidates(x, y, radius float64) []*Entity {
+	cache := &w.targetCache
+	if cache.regions == nil {
+		cache.regions = make(map[targetRegion]int, 64)
+	}
+	if cache.version != w.gridVersion {
+		clear(cache.regions)
+		cache.used = 0
+		cache.version = w.gridVersion
+	}
+
+	minCellX, minCellY := w.WorldToCell(x-radius, y-radius)
+	maxCellX, maxCellY := w.WorldToCell(x+radius, y+radius)
+	region := targetRegion{minCellX, minCellY, maxCellX, maxCellY}
+	if index, ok := cache.regions[region]; ok {
+		return cache.scans[index]
+	}
+
+	// Miss: scan the rectangle into the next free buffer
+	if cache.used == len(cache.scans) {
+		cache.scans = append(cache.scans, make([]*Entity, 0, 100))
+	}
+	scan := cache.scans[cache.used][:0]
+	for cellX := minCellX; cellX <= maxCellX; cellX++ {
+		for cellY := minCellY; cellY <= maxCellY; cellY++ {
+			cell := w.GetCell(cellX, cellY)
+			if cell == nil {
+				continue
+			}
+			for i := 0; i < cell.Count; i++ {
+				if entity := cell.Entities[i]; entity.Active {
+					scan = append(scan, entity)
+				}
+			}
+		}
+	}
+	cache.scans[cache.used] = scan
+	cache.regions[region] = cache.used
+	cache.used++
+	return scan
+}
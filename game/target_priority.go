@@ -0,0 +1,82 @@
+package game
+
+// TargetPriority selects how a player turret picks among the targets in range
+type TargetPriority int
+
+const (
+	TargetPriorityNearest       TargetPriority = iota // Closest target (the original rule)
+	TargetPriorityLowestHP                            // Finish off the weakest target, nearest on ties
+	TargetPriorityHighestThreat                       // Most damage per second heading our way, weighted by distance
+	TargetPriorityMissilesFirst                       // Suicide rockets and homing missiles before anything else
+	targetPriorityCount
+)
+
+// targetPriorityNames are the names shown in the loadout menu
+var targetPriorityNames = [targetPriorityCount]string{"Nearest", "Lowest HP", "Highest Threat", "Missiles First"}
+
+// String returns the priority's display name
+func (p TargetPriority) String() string {
+	if p < 0 || p >= targetPriorityCount {
+		return targetPriorityNames[TargetPriorityNearest]
+	}
+	return targetPriorityNames[p]
+}
+
+// Next returns the following priority, wrapping around (used to cycle it in the loadout menu)
+func (p TargetPriority) Next() TargetPriority {
+	return (p + 1) % targetPriorityCount
+}
+
+// threatFalloffDistanceSq is the squared distance at which a target's threat counts half
+const threatFalloffDistanceSq = 300.0 * 300.0
+
+// isMissileTarget returns true for suicide rocket ships and homing missiles
+func isMissileTarget(entity *Entity) bool {
+	return entity.Type == EntityTypeHomingRocket || entity.ShipType == ShipTypeHomingSuicide
+}
+
+// targetThreat estimates the damage per second a target can deal, from the damage its hits actually deal
+// Missiles deliver their whole impact damage at once, so they count like a gun dealing it every second
+func targetThreat(entity *Entity) float64 {
+	switch {
+	case entity.Type == EntityTypeHomingRocket:
+		return GetWeaponConfig(entity.Weapon).Damage
+	case entity.ShipType == ShipTypeHomingSuicide:
+		return GetWeaponConfig(WeaponTypeHomingMissile).Damage
+	}
+	threat := 0.0
+	for i := range entity.Turrets {
+		turret := &entity.Turrets[i]
+		if !turret.IsOperational() {
+			continue
+		}
+		threat += GetWeaponConfig(turret.Mount.WeaponType).DPS()
+	}
+	return threat
+}
+
+// better returns true if candidate is a better target than best under this priority
+// Distances are squared and already include any targeting penalties
+func (p TargetPriority) better(candidate *Entity, candidateDistanceSq float64, best *Entity, bestDistanceSq float64) bool {
+	if best == nil {
+		return true
+	}
+	switch p {
+	case TargetPriorityLowestHP:
+		if candidate.Health != best.Health {
+			return candidate.Health < best.Health
+		}
+	case TargetPriorityHighestThreat:
+		// Threat per unit of distance: a gunship far away can matter less than a rocket close by
+		candidateThreat := targetThreat(candidate) / (1 + candidateDistanceSq/threatFalloffDistanceSq)
+		bestThreat := targetThreat(best) / (1 + bestDistanceSq/threatFalloffDistanceSq)
+		if candidateThreat != bestThreat {
+			return candidateThreat > bestThreat
+		}
+	case TargetPriorityMissilesFirst:
+		if candidateMissile, bestMissile := isMissileTarget(candidate), isMissileTarget(best); candidateMissile != bestMissile {
+			return candidateMissile
+		}
+	}
+	return candidateDistanceSq < bestDistanceSq
+}
@@ -0,0 +1,80 @@
+package game
+
+import "math"
+
+// TractorConfig configures a continuous cone-shaped tow beam
+// The beam pulls pickups toward the turret and pushes ships and asteroids away, lighter ones harder
+type TractorConfig struct {
+	Range     float64 // Beam length (pixels)
+	HalfAngle float64 // Half of the cone's opening angle (radians)
+	Pull      float64 // Acceleration toward the turret applied to pickups (pixels per second squared)
+	Push      float64 // Acceleration away from the turret for a radius-10 body (pixels per second squared)
+
+	// Energy: the beam drains Drain per second while firing and recharges Recharge per second otherwise
+	Energy   float64
+	Drain    float64
+	Recharge float64
+}
+
+// tractorReferenceRadius is the body radius that receives the full Push acceleration
+// Acceleration scales with (reference / radius)^2 so big ships and rocks barely move
+const tractorReferenceRadius = 10.0
+
+// updateTractors runs a player ship's tractor turrets for this tick: fires while the beam key is held
+// and energy lasts, recharges otherwise
+func (g *Game) updateTractors(entity *Entity, playerInput *PlayerInput, deltaTime float64) {
+	firing := playerInput.ShouldFireTractor() && !entity.Status.IsDisabled()
+	for i := range entity.Turrets {
+		turret := &entity.Turrets[i]
+		tractor := GetWeaponConfig(turret.Mount.WeaponType).Tractor
+		if tractor == nil {
+			continue
+		}
+
+		turret.BeamActive = firing && turret.IsOperational() && turret.Energy > 0
+		if !turret.BeamActive {
+			turret.Energy = math.Min(turret.Energy+tractor.Recharge*deltaTime, tractor.Energy)
+			continue
+		}
+		turret.Energy = math.Max(turret.Energy-tractor.Drain*deltaTime, 0)
+
+		turretX, turretY := turret.WorldPosition(entity)
+		g.applyTractorBeam(entity, turretX, turretY, turret.BarrelRotation(entity), tractor, deltaTime)
+	}
+}
+
+// applyTractorBeam accelerates every entity inside the beam cone
+func (g *Game) applyTractorBeam(owner *Entity, x, y, rotation float64, tractor *TractorConfig, deltaTime float64) {
+	dirX, dirY := math.Cos(rotation), math.Sin(rotation)
+	cosHalfAngle := math.Cos(tractor.HalfAngle)
+
+	g.tractorCandidates = g.world.AppendEntitiesInRadius(g.tractorCandidates[:0], x, y, tractor.Range)
+	for _, entity := range g.tractorCandidates {
+		if entity == owner || !entity.Active || entity.Health <= 0 {
+			continue
+		}
+
+		// Inside the cone: within range and within HalfAngle of the beam direction
+		dx := entity.X - x
+		dy := entity.Y - y
+		distance := math.Sqrt(dx*dx + dy*dy)
+		if distance == 0 || distance > tractor.Range || (dx*dirX+dy*dirY)/distance < cosHalfAngle {
+			continue
+		}
+		awayX, awayY := dx/distance, dy/distance
+
+		switch entity.Type {
+		case EntityTypeXP:
+			entity.VX -= awayX * tractor.Pull * deltaTime
+			entity.VY -= awayY * tractor.Pull * deltaTime
+		case EntityTypeEnemy, EntityTypeAsteroid, EntityTypeHomingRocket:
+			if GetEntityFaction(entity) == GetEntityFaction(owner) {
+				continue // Don't shove allies around
+			}
+			scale := tractorReferenceRadius / math.Max(entity.Radius, 1)
+			push := tractor.Push * scale * scale * deltaTime
+			entity.VX += awayX * push
+			entity.VY += awayY * push
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package game
+
+import "math"
+
+const (
+	// TrailLength is the number of breadcrumbs kept per entity
+	TrailLength = 16
+
+	// TrailSampleInterval is the time between breadcrumb samples in seconds
+	TrailSampleInterval = 0.1
+
+	// PredictedTrailDuration is how far ahead predicted trails look in seconds
+	PredictedTrailDuration = 1.5
+
+	// PredictedTrailSteps is the number of points in a predicted trail
+	PredictedTrailSteps = 12
+)
+
+// Trail stores recent positions of an entity in a fixed-size ring buffer
+type Trail struct {
+	Points [TrailLength][2]float64 // Ring buffer of past positions
+	Head   int                     // Index where the next point will be written
+	Count  int                     // Number of valid points in the buffer
+
+	// Time accumulated since the last sample
+	sampleTimer float64
+}
+
+// Record adds a breadcrumb if enough time has passed since the last sample
+func (t *Trail) Record(x, y, deltaTime float64) {
+	t.sampleTimer += deltaTime
+	if t.Count > 0 && t.sampleTimer < TrailSampleInterval {
+		return
+	}
+	t.sampleTimer = 0
+
+	t.Points[t.Head] = [2]float64{x, y}
+	t.Head = (t.Head + 1) % TrailLength
+	if t.Count < TrailLength {
+		t.Count++
+	}
+}
+
+// Point returns the i-th breadcrumb, where 0 is the oldest
+func (t *Trail) Point(i int) (float64, float64) {
+	start := (t.Head - t.Count + TrailLength) % TrailLength
+	p := t.Points[(start+i)%TrailLength]
+	return p[0], p[1]
+}
+
+// shouldTrackTrail returns true if breadcrumbs should be recorded for an entity
+// Only enemies and homing rockets get trails; projectiles are too numerous
+func shouldTrackTrail(entity *Entity) bool {
+	return entity.Type == EntityTypeEnemy || entity.Type == EntityTypeHomingRocket
+}
+
+// updateTrail records the entity's current position in its trail
+func updateTrail(entity *Entity, deltaTime float64) {
+	if entity.Trail == nil {
+		entity.Trail = &Trail{}
+	}
+	entity.Trail.Record(entity.X, entity.Y, deltaTime)
+}
+
+// PredictFuturePath fills out with the entity's predicted positions over duration seconds
+// Homing rockets are simulated accelerating towards their target's extrapolated position,
+// all other entities are extrapolated along their current velocity
+// Returns the filled slice (reuses out's backing array)
+func PredictFuturePath(entity *Entity, duration float64, steps int, out [][2]float64) [][2]float64 {
+	out = out[:0]
+	if steps <= 0 {
+		return out
+	}
+
+	stepTime := duration / float64(steps)
+	x, y := entity.X, entity.Y
+	vx, vy := entity.VX, entity.VY
+
+	// Homing rockets steer, so simulate their acceleration towards the target
+	var target *Entity
+	if entity.Type == EntityTypeHomingRocket {
+		if aiInput, ok := entity.Input.(*AIInput); ok && aiInput.TargetEntity != nil && aiInput.TargetEntity.Active {
+			target = aiInput.TargetEntity
+		}
+	}
+	rocketConfig := GetHomingRocketConfig()
+
+	elapsed := 0.0
+	for i := 0; i < steps; i++ {
+		elapsed += stepTime
+
+		if target != nil {
+			// Assume the target keeps moving in a straight line
+			targetX := target.X + target.VX*elapsed
+			targetY := target.Y + target.VY*elapsed
+			dirX, dirY := CalculateInterceptDirection(
+				x, y, vx, vy,
+				targetX, targetY,
+				target.VX, target.VY,
+				rocketConfig.Acceleration,
+				stepTime,
+			)
+			vx += dirX * rocketConfig.Acceleration * stepTime
+			vy += dirY * rocketConfig.Acceleration * stepTime
+
+			// Respect the global speed limit like Entity.Update does
+			speed := math.Sqrt(vx*vx + vy*vy)
+			if speed > MaxEntitySpeed {
+				vx *= MaxEntitySpeed / speed
+				vy *= MaxEntitySpeed / speed
+			}
+		}
+
+		x += vx * stepTime
+		y += vy * stepTime
+		out = append(out, [2]float64{x, y})
+	}
+
+	return out
+}
+
+const (
+	// PlayerPathDuration is how far ahead the player path preview looks in seconds
+	PlayerPathDuration = 3.0
+
+	// PlayerPathPoints is the number of dots in the player path preview
+	PlayerPathPoints = 30
+
+	// playerPathSimStep is the physics step used for the preview
+	// Friction is scaled by step length (StepFriction), so the preview matches any simulation tick rate
+	playerPathSimStep = 1.0 / 60.0
+)
+
+// PredictShipPath fills out with the predicted positions of a ship over duration seconds,
+// assuming thrust and rotation inputs are held constant. Mirrors the ship physics in Entity.Update
+// Returns the filled slice (reuses out's backing array)
+func PredictShipPath(entity *Entity, thrustInput, rotationInput, duration float64, points int, out [][2]float64) [][2]float64 {
+	out = out[:0]
+	if points <= 0 {
+		return out
+	}
+
+	shipConfig := GetShipTypeConfig(entity.ShipType)
+	x, y := entity.X, entity.Y
+	vx, vy := entity.VX, entity.VY
+	rotation := entity.Rotation
+	angularVelocity := entity.AngularVelocity
+
+	totalSteps := int(duration / playerPathSimStep)
+	stepsPerPoint := max(1, totalSteps/points)
+
+	for step := 1; step <= totalSteps; step++ {
+		// Angular velocity
+		if math.Abs(rotationInput) > 0.01 {
+			angularVelocity += rotationInput * shipConfig.AngularAcceleration * playerPathSimStep
+			angularVelocity = math.Max(-shipConfig.MaxAngularSpeed, math.Min(angularVelocity, shipConfig.MaxAngularSpeed))
+		} else {
+			angularVelocity *= StepFriction(0.9999, playerPathSimStep)
+		}
+		rotation += angularVelocity * playerPathSimStep
+
+		// Thrust along the ship's forward direction
+		if math.Abs(thrustInput) > 0.01 {
+			acceleration := thrustInput * shipConfig.Acceleration * playerPathSimStep
+			vx += math.Cos(rotation) * acceleration
+			vy += math.Sin(rotation) * acceleration
+		}
+
+		// Friction and speed limit
+		friction := StepFriction(shipConfig.Friction, playerPathSimStep)
+		vx *= friction
+		vy *= friction
+		speed := math.Sqrt(vx*vx + vy*vy)
+		if speed > MaxEntitySpeed {
+			vx *= MaxEntitySpeed / speed
+			vy *= MaxEntitySpeed / speed
+		}
+
+		x += vx * playerPathSimStep
+		y += vy * playerPathSimStep
+
+		if step%stepsPerPoint == 0 && len(out) < points {
+			out = append(out, [2]float64{x, y})
+		}
+	}
+
+	return out
+}
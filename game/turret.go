@@ -0,0 +1,316 @@
+package game
+
+import "math"
+
+const (
+	// TurretHitRadius is how close a hit must land to a mount to damage that turret
+	TurretHitRadius = 10.0
+
+	// TurretDestroyChance is the chance a hit near a mount destroys the turret
+	TurretDestroyChance = 0.25
+
+	// PlayerTurretTurnRate is the player's turret traverse speed in radians per second (faster than ship)
+	PlayerTurretTurnRate = 8.0
+
+	// AITurretTurnRate is the AI turret traverse speed in radians per second
+	// Slower than the player's so enemy aim can be read and dodged
+	AITurretTurnRate = 4.0
+
+	// disarmedTargetPenalty scales the distance of targets with no working weapons
+	// so turrets prefer ships that can still shoot back
+	disarmedTargetPenalty = 4.0
+)
+
+// TurretState is the per-entity runtime state of a single turret mount
+// The mount is copied from the ship config so individual turrets can change without affecting other ships
+type TurretState struct {
+	// Mount configuration (copied from ShipTypeConfig.TurretMounts at spawn)
+	Mount TurretMountPoint
+
+	// Current world-space barrel rotation in radians
+	Rotation float64
+
+	// Whether Rotation has been set by targeting (otherwise the barrel follows ship facing)
+	Aimed bool
+
+	// Current target of this turret
+	Target TurretTarget
+
+	// Time since this turret last fired
+	TimeSinceShot float64
+
+	// Whether this turret has fired yet (a fresh turret can fire immediately)
+	HasFired bool
+
+	// Remaining shots (-1 means unlimited)
+	Ammo int
+
+	// Destroyed by subsystem damage (stays destroyed until the ship respawns)
+	Destroyed bool
+
+	// Tractor beams only: remaining energy and whether the beam fired this tick
+	Energy     float64
+	BeamActive bool
+}
+
+// TurretTarget contains target information for a single turret
+type TurretTarget struct {
+	TargetX, TargetY float64
+	HasTarget        bool
+}
+
+// NewTurretStates creates turret state for every mount of a ship type
+func NewTurretStates(shipType ShipType) []TurretState {
+	shipConfig := GetShipTypeConfig(shipType)
+	turrets := make([]TurretState, len(shipConfig.TurretMounts))
+	for i, mount := range shipConfig.TurretMounts {
+		turrets[i] = TurretState{
+			Mount: mount,
+			Ammo:  -1, // Unlimited
+		}
+		if tractor := GetWeaponConfig(mount.WeaponType).Tractor; tractor != nil {
+			turrets[i].Energy = tractor.Energy
+		}
+	}
+	return turrets
+}
+
+// IsOperational returns true if the turret is mounted, intact and has ammo left
+func (t *TurretState) IsOperational() bool {
+	return t.Mount.Active && !t.Destroyed && t.Ammo != 0
+}
+
+// WorldPosition returns the turret's world position given the owning entity's transform
+func (t *TurretState) WorldPosition(entity *Entity) (x, y float64) {
+	cosRot := math.Cos(entity.Rotation)
+	sinRot := math.Sin(entity.Rotation)
+
+	// Transform mount offset from ship-local to world coordinates
+	mountX := t.Mount.OffsetX*cosRot - t.Mount.OffsetY*sinRot
+	mountY := t.Mount.OffsetX*sinRot + t.Mount.OffsetY*cosRot
+	return entity.X + mountX, entity.Y + mountY
+}
+
+// BarrelRotation returns where the barrel points: the tracked rotation, or ship facing + mount angle
+// The tracked rotation is kept inside the firing arc as the ship turns under it
+func (t *TurretState) BarrelRotation(entity *Entity) float64 {
+	if t.Aimed {
+		if t.Mount.HasArc() {
+			return entity.Rotation + t.Mount.ArcMin + t.Mount.arcOffset(t.Rotation-entity.Rotation-t.Mount.ArcMin)
+		}
+		return t.Rotation
+	}
+	return entity.Rotation + t.Mount.Angle
+}
+
+// HasArc returns true if the mount has a limited firing arc
+func (m *TurretMountPoint) HasArc() bool {
+	return m.ArcMin != 0 || m.ArcMax != 0
+}
+
+// arcWidth returns how wide the firing arc is (radians, 0 to 2π)
+func (m *TurretMountPoint) arcWidth() float64 {
+	width := math.Mod(m.ArcMax-m.ArcMin, 2*math.Pi)
+	if width < 0 {
+		width += 2 * math.Pi
+	}
+	return width
+}
+
+// arcOffset maps an angle measured from ArcMin to its position in the arc (0 to arcWidth),
+// clamping angles outside the arc to the nearer edge
+func (m *TurretMountPoint) arcOffset(angle float64) float64 {
+	offset := math.Mod(angle, 2*math.Pi)
+	if offset < 0 {
+		offset += 2 * math.Pi
+	}
+	width := m.arcWidth()
+	if offset <= width {
+		return offset
+	}
+	if offset-width < 2*math.Pi-offset {
+		return width
+	}
+	return 0
+}
+
+// InArc returns true if a world-space rotation is inside the turret's firing arc (always without an arc)
+func (t *TurretState) InArc(entity *Entity, rotation float64) bool {
+	if !t.Mount.HasArc() {
+		return true
+	}
+	offset := math.Mod(rotation-entity.Rotation-t.Mount.ArcMin, 2*math.Pi)
+	if offset < 0 {
+		offset += 2 * math.Pi
+	}
+	return offset <= t.Mount.arcWidth()
+}
+
+// TurnTowards turns the barrel toward a world-space rotation at turnRate, staying inside the firing arc
+// Within an arc the barrel sweeps through the cone, never across the blind side
+func (t *TurretState) TurnTowards(entity *Entity, rotation, turnRate, deltaTime float64) {
+	current := t.BarrelRotation(entity)
+	if !t.Mount.HasArc() {
+		t.SetRotation(RotateTowardsTarget(current, rotation, turnRate, deltaTime))
+		return
+	}
+	start := entity.Rotation + t.Mount.ArcMin
+	from := t.Mount.arcOffset(current - start)
+	to := t.Mount.arcOffset(rotation - start)
+	step := turnRate * deltaTime
+	t.SetRotation(start + from + math.Max(-step, math.Min(to-from, step)))
+}
+
+// SetRotation sets the tracked barrel rotation
+func (t *TurretState) SetRotation(rotation float64) {
+	t.Rotation = rotation
+	t.Aimed = true
+}
+
+// IsReady returns true if the turret's weapon is off cooldown
+func (t *TurretState) IsReady() bool {
+	return GetWeaponConfig(t.Mount.WeaponType).CanShoot(t.TimeSinceShot, t.HasFired)
+}
+
+// turretReady returns true if the entity's turret i may fire now
+// Every turret keeps its own cooldown; on ships with StaggeredTurrets, turrets sharing a weapon also
+// wait until Cooldown/n has passed since any of them fired, so n guns take turns instead of firing in volleys
+func (e *Entity) turretReady(i int) bool {
+	turret := &e.Turrets[i]
+	if !turret.IsReady() {
+		return false
+	}
+	if !GetShipTypeConfig(e.ShipType).StaggeredTurrets {
+		return true
+	}
+
+	// Count the working turrets with this weapon and find the most recent shot among the others
+	siblings := 0
+	lastSiblingShot := math.MaxFloat64
+	for j := range e.Turrets {
+		other := &e.Turrets[j]
+		if other.Mount.WeaponType != turret.Mount.WeaponType || !other.IsOperational() {
+			continue
+		}
+		siblings++
+		if j != i && other.HasFired {
+			lastSiblingShot = math.Min(lastSiblingShot, other.TimeSinceShot)
+		}
+	}
+	return lastSiblingShot >= GetWeaponConfig(turret.Mount.WeaponType).Cooldown/float64(siblings)
+}
+
+// RecordShot resets the cooldown and consumes ammo after the turret fires
+func (t *TurretState) RecordShot() {
+	t.TimeSinceShot = 0.0
+	t.HasFired = true
+	if t.Ammo > 0 {
+		t.Ammo--
+	}
+}
+
+// updateTurrets advances turret cooldown timers
+func (e *Entity) updateTurrets(deltaTime float64) {
+	for i := range e.Turrets {
+		e.Turrets[i].TimeSinceShot += deltaTime
+	}
+}
+
+// FirstOperationalTurret returns the entity's first working turret (nil if none)
+func (e *Entity) FirstOperationalTurret() *TurretState {
+	for i := range e.Turrets {
+		if e.Turrets[i].IsOperational() {
+			return &e.Turrets[i]
+		}
+	}
+	return nil
+}
+
+// IsDisarmed returns true if the entity had turrets but none of them work anymore
+func (e *Entity) IsDisarmed() bool {
+	return len(e.Turrets) > 0 && e.FirstOperationalTurret() == nil
+}
+
+// damageTurretNearHit rolls to destroy the intact turret closest to a hit point
+// Only ships with DestructibleTurrets are affected; returns the destroyed turret or nil
+func damageTurretNearHit(entity *Entity, hitX, hitY float64) *TurretState {
+	if len(entity.Turrets) == 0 || !GetShipTypeConfig(entity.ShipType).DestructibleTurrets {
+		return nil
+	}
+
+	// Find the nearest intact turret within range of the hit
+	var nearest *TurretState
+	nearestDistanceSq := TurretHitRadius * TurretHitRadius
+	for i := range entity.Turrets {
+		turret := &entity.Turrets[i]
+		if !turret.Mount.Active || turret.Destroyed {
+			continue
+		}
+		turretX, turretY := turret.WorldPosition(entity)
+		dx := hitX - turretX
+		dy := hitY - turretY
+		if distanceSq := dx*dx + dy*dy; distanceSq <= nearestDistanceSq {
+			nearestDistanceSq = distanceSq
+			nearest = turret
+		}
+	}
+
+	if nearest == nil || runRNG.Float64() >= TurretDestroyChance {
+		return nil
+	}
+	nearest.Destroyed = true
+	nearest.Target = TurretTarget{}
+	return nearest
+}
+
+// turretAimOffset returns where to aim on target relative to its center: the intact turret nearest to (fromX, fromY)
+// on ships with destructible turrets, so the AI disarms big ships first, or the center otherwise
+func turretAimOffset(target *Entity, fromX, fromY float64) (float64, float64) {
+	if len(target.Turrets) == 0 || !GetShipTypeConfig(target.ShipType).DestructibleTurrets {
+		return 0, 0
+	}
+	offsetX, offsetY := 0.0, 0.0
+	nearestDistanceSq := math.Inf(1)
+	for i := range target.Turrets {
+		turret := &target.Turrets[i]
+		if !turret.Mount.Active || turret.Destroyed {
+			continue
+		}
+		x, y := turret.WorldPosition(target)
+		if distanceSq := (x-fromX)*(x-fromX) + (y-fromY)*(y-fromY); distanceSq < nearestDistanceSq {
+			nearestDistanceSq = distanceSq
+			offsetX, offsetY = x-target.X, y-target.Y
+		}
+	}
+	return offsetX, offsetY
+}
+
+// updateAITurrets turns an AI ship's turrets toward its target at the AI turret turn rate
+// Without a target (or with the target outside a turret's firing arc), turrets return to their rest angle
+// Turrets keep tracking a target behind an ally but only take it as their target (and fire) with a clear shot
+func updateAITurrets(entity, target *Entity, world *World, deltaTime float64) {
+	for i := range entity.Turrets {
+		turret := &entity.Turrets[i]
+		if !turret.IsOperational() {
+			continue
+		}
+
+		desiredRotation := entity.Rotation + turret.Mount.Angle
+		turret.Target = TurretTarget{}
+		if target != nil && target.Active {
+			turretX, turretY := turret.WorldPosition(entity)
+			predictedX, predictedY := CalculatePredictiveAim(turretX, turretY, target)
+			offsetX, offsetY := turretAimOffset(target, turretX, turretY)
+			predictedX += offsetX
+			predictedY += offsetY
+			if aim := math.Atan2(predictedY-turretY, predictedX-turretX); turret.InArc(entity, aim) {
+				desiredRotation = aim
+				if world.HasLineOfSight(entity, target, turretX, turretY, predictedX, predictedY) {
+					turret.Target = TurretTarget{TargetX: predictedX, TargetY: predictedY, HasTarget: true}
+				} // Otherwise don't fire through allies
+			}
+		}
+
+		turret.TurnTowards(entity, desiredRotation, AITurretTurnRate, deltaTime)
+	}
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// TestStaggeredTurretsTakeTurns fires the gunship's two broadside guns as soon as they're ready
+// and checks they alternate, half a cooldown apart, instead of firing in volleys
+func TestStaggeredTurretsTakeTurns(t *testing.T) {
+	ship := NewEntityWithShipType(0, 0, EntityTypeEnemy, ShipTypeGunship, nil)
+	if !GetShipTypeConfig(ship.ShipType).StaggeredTurrets {
+		t.Fatal("gunship doesn't stagger its turrets")
+	}
+	guns := []int{0, 2} // The broadside mounts, which share the bullet
+	for _, i := range guns {
+		if ship.Turrets[i].Mount.WeaponType != WeaponTypeBullet {
+			t.Fatalf("turret %d doesn't fire bullets", i)
+		}
+	}
+
+	const deltaTime = 1.0 / 128
+	cooldown := GetWeaponConfig(WeaponTypeBullet).Cooldown
+	type shot struct {
+		turret int
+		time   float64
+	}
+	var shots []shot
+	for tick := 0; tick < 256; tick++ {
+		ship.updateTurrets(deltaTime)
+		for _, i := range guns {
+			if ship.turretReady(i) {
+				ship.Turrets[i].RecordShot()
+				shots = append(shots, shot{i, float64(tick) * deltaTime})
+			}
+		}
+	}
+
+	if len(shots) < 10 {
+		t.Fatalf("only %d shots fired", len(shots))
+	}
+	for k := 1; k < len(shots); k++ {
+		previous, current := shots[k-1], shots[k]
+		if current.turret == previous.turret {
+			t.Fatalf("shot %d: turret %d fired twice in a row", k, current.turret)
+		}
+		if gap := current.time - previous.time; math.Abs(gap-cooldown/2) > deltaTime {
+			t.Fatalf("shot %d: %.4fs after the previous one, want %.4fs", k, gap, cooldown/2)
+		}
+	}
+}
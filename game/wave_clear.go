@@ -0,0 +1,145 @@
+package game
+
+import "math"
+
+const (
+	// XPVacuumDuration is how long a wave-clear vacuum takes to pull every orb to its collector (seconds)
+	XPVacuumDuration = 1.5
+
+	// WaveClearPopupDuration is how long the wave-clear bonus popup stays on screen (seconds)
+	WaveClearPopupDuration = 3.0
+
+	// WaveClearBonusPerWave is the most each part of the bonus (time, damage) is worth, per wave number
+	WaveClearBonusPerWave = 50
+
+	// WaveClearParTime is how long after the wave's last spawn the time bonus takes to run out (seconds)
+	WaveClearParTime = 30.0
+)
+
+// WaveClearBonus is the bonus awarded for clearing a wave, shown in the wave-clear popup
+type WaveClearBonus struct {
+	Wave        int
+	Time        float64 // Seconds from the wave's start to its last kill
+	DamageTaken float64 // Hull the player lost during the wave
+	TimeBonus   int
+	DamageBonus int
+	Age         float64 // Seconds the popup has been shown
+}
+
+// Total returns the whole bonus
+func (b *WaveClearBonus) Total() int {
+	return b.TimeBonus + b.DamageBonus
+}
+
+// WaveClear tracks the current wave's enemies, time and damage taken until every one of its enemies is destroyed
+type WaveClear struct {
+	enemies     []uint64 // IDs of the enemies the wave spawned
+	time        float64
+	damageTaken float64
+	lastHealth  float64
+	cleared     bool
+
+	// The last wave's bonus, while its popup is shown (nil otherwise)
+	Popup *WaveClearBonus
+}
+
+// NewWaveClear creates a wave-clear tracker
+func NewWaveClear() *WaveClear {
+	return &WaveClear{enemies: make([]uint64, 0, 64)}
+}
+
+// Start begins tracking a new wave (the previous wave's popup stays up)
+func (w *WaveClear) Start() {
+	w.enemies = w.enemies[:0]
+	w.time = 0
+	w.damageTaken = 0
+	w.lastHealth = 0
+	w.cleared = false
+}
+
+// Reset starts over and hides the popup (a new run)
+func (w *WaveClear) Reset() {
+	w.Start()
+	w.Popup = nil
+}
+
+// track adds the enemies among newly registered entities to the wave
+func (w *WaveClear) track(spawned []*Entity) {
+	for _, entity := range spawned {
+		if entity.Type == EntityTypeEnemy {
+			w.enemies = append(w.enemies, entity.ID)
+		}
+	}
+}
+
+// Update counts the wave's time and the hull the player loses, and ages the popup
+func (w *WaveClear) Update(deltaTime float64, player *Entity) {
+	if w.Popup != nil {
+		if w.Popup.Age += deltaTime; w.Popup.Age >= WaveClearPopupDuration {
+			w.Popup = nil
+		}
+	}
+	if w.cleared || player == nil || !player.Active {
+		return
+	}
+	w.time += deltaTime
+	if player.Health < w.lastHealth {
+		w.damageTaken += w.lastHealth - player.Health
+	}
+	w.lastHealth = player.Health
+}
+
+// checkCleared returns true once, when the wave has spawned all its enemies and none of them is left
+func (w *WaveClear) checkCleared(world *World, allSpawned bool) bool {
+	if w.cleared || !allSpawned || len(w.enemies) == 0 {
+		return false
+	}
+	for _, id := range w.enemies {
+		if enemy := world.GetEntityByID(id); enemy != nil && enemy.Active && enemy.Health > 0 {
+			return false
+		}
+	}
+	w.cleared = true
+	return true
+}
+
+// bonus works out the bonus for a cleared wave: full time bonus when the last enemy falls as it spawns, running out
+// over WaveClearParTime, and full damage bonus without a scratch, running out at the player's maximum hull
+func (w *WaveClear) bonus(wave int, spawnTime float64, maxHealth float64) *WaveClearBonus {
+	base := float64(WaveClearBonusPerWave * wave)
+	timeShare := 1 - math.Max(w.time-spawnTime, 0)/WaveClearParTime
+	damageShare := 1.0
+	if maxHealth > 0 {
+		damageShare = 1 - w.damageTaken/maxHealth
+	}
+	return &WaveClearBonus{
+		Wave:        wave,
+		Time:        w.time,
+		DamageTaken: w.damageTaken,
+		TimeBonus:   int(base * math.Max(timeShare, 0)),
+		DamageBonus: int(base * math.Max(damageShare, 0)),
+	}
+}
+
+// updateWaveClear awards the wave-clear bonus and pulls every remaining orb in once the current wave is destroyed
+func (g *Game) updateWaveClear() {
+	if !g.waveClear.checkCleared(g.world, g.enemiesSpawnedThisWave >= g.enemiesPerWave) {
+		return
+	}
+	if g.player == nil || !g.player.Active {
+		return
+	}
+
+	spawnTime := float64(g.enemiesPerWave) * g.waveSpawnInterval
+	bonus := g.waveClear.bonus(g.waveNumber, spawnTime, g.player.MaxHealth)
+	g.addScore(ScoreWaveClear, bonus.Total())
+	g.credits += bonus.Total()
+	g.waveClear.Popup = bonus
+
+	// XP vacuum: every orb still flying heads for its collector and arrives within XPVacuumDuration
+	for _, entity := range g.world.AllEntities {
+		if entity.Type == EntityTypeXP && entity.Active && entity.Health > 0 {
+			entity.Pickup.Vacuum = XPVacuumDuration
+		}
+	}
+}
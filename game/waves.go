@@ -0,0 +1,189 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// DefaultWaveSpawnInterval is the time between enemy spawns within a wave (seconds)
+	DefaultWaveSpawnInterval = 0.1
+
+	// DefaultWaveCooldown is the time between the end of a wave's spawning and the next wave (seconds)
+	DefaultWaveCooldown = 5.0
+)
+
+// WaveGroup is a number of enemies of one type within a wave
+type WaveGroup struct {
+	Enemy string // Enemy type name (e.g. "Shooter")
+	Count int
+}
+
+// WaveDefinition describes the composition and pacing of one wave
+type WaveDefinition struct {
+	Name          string
+	Groups        []WaveGroup // Spawned in order
+	SpawnInterval float64     // Seconds between spawns within the wave (0 = DefaultWaveSpawnInterval)
+	Cooldown      float64     // Seconds after the wave finishes spawning before the next one (0 = DefaultWaveCooldown)
+}
+
+// WaveSet is an ordered list of waves, loaded from a JSON file edited with cmd/waveedit
+// After the last wave, the last wave repeats with EndlessGrowth more enemies per group each time
+type WaveSet struct {
+	Waves         []WaveDefinition
+	EndlessGrowth int
+}
+
+// WaveStats is the expected difficulty of a wave
+type WaveStats struct {
+	Enemies  int
+	TotalHP  float64 // Sum of enemy health
+	DPS      float64 // Sum of sustained turret damage per second if every enemy fires continuously
+	Duration float64 // Seconds to spawn the whole wave
+}
+
+// LoadWaveSet reads and validates a wave set file
+func LoadWaveSet(path string) (*WaveSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wave set: %w", err)
+	}
+	var waveSet WaveSet
+	if err := json.Unmarshal(data, &waveSet); err != nil {
+		return nil, fmt.Errorf("failed to parse wave set: %w", err)
+	}
+	if err := waveSet.Validate(); err != nil {
+		return nil, err
+	}
+	return &waveSet, nil
+}
+
+// SaveWaveSet writes a wave set as indented JSON
+func SaveWaveSet(path string, waveSet *WaveSet) error {
+	data, err := json.MarshalIndent(waveSet, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode wave set: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write wave set: %w", err)
+	}
+	return nil
+}
+
+// DefaultWaveSet returns a starter wave set that ramps up like the built-in random waves
+func DefaultWaveSet() *WaveSet {
+	return &WaveSet{
+		Waves: []WaveDefinition{
+			{Name: "Scouts", Groups: []WaveGroup{{Enemy: "Rocket", Count: 10}}},
+			{Name: "First Shooters", Groups: []WaveGroup{{Enemy: "Rocket", Count: 7}, {Enemy: "Shooter", Count: 4}}},
+			{Name: "Mixed", Groups: []WaveGroup{{Enemy: "Rocket", Count: 6}, {Enemy: "Shooter", Count: 4}, {Enemy: "ShooterTwin", Count: 2}}},
+		},
+		EndlessGrowth: 1,
+	}
+}
+
+// Validate checks that every wave has enemies and only uses known enemy types
+func (s *WaveSet) Validate() error {
+	if len(s.Waves) == 0 {
+		return errors.New("wave set has no waves")
+	}
+	for i, wave := range s.Waves {
+		if wave.EnemyCount() == 0 {
+			return fmt.Errorf("wave %d (%s) has no enemies", i+1, wave.Name)
+		}
+		for _, group := range wave.Groups {
+			if _, ok := GetEnemyTypeByName(group.Enemy); !ok {
+				return fmt.Errorf("wave %d (%s) uses unknown enemy %q", i+1, wave.Name, group.Enemy)
+			}
+			if group.Count < 0 {
+				return fmt.Errorf("wave %d (%s) has a negative %s count", i+1, wave.Name, group.Enemy)
+			}
+		}
+	}
+	return nil
+}
+
+// Wave returns the definition of a 1-based wave number, extending past the end with EndlessGrowth
+func (s *WaveSet) Wave(waveNumber int) WaveDefinition {
+	if waveNumber <= len(s.Waves) {
+		return s.Waves[max(waveNumber, 1)-1]
+	}
+
+	// Past the end: repeat the last wave with extra enemies in every group
+	last := s.Waves[len(s.Waves)-1]
+	extra := (waveNumber - len(s.Waves)) * s.EndlessGrowth
+	wave := last
+	wave.Groups = make([]WaveGroup, len(last.Groups))
+	for i, group := range last.Groups {
+		group.Count += extra
+		wave.Groups[i] = group
+	}
+	return wave
+}
+
+// EnemyCount returns the total number of enemies in the wave
+func (w WaveDefinition) EnemyCount() int {
+	count := 0
+	for _, group := range w.Groups {
+		count += group.Count
+	}
+	return count
+}
+
+// SpawnOrder appends the wave's enemy types in spawn order to order
+func (w WaveDefinition) SpawnOrder(order []EnemyType) []EnemyType {
+	for _, group := range w.Groups {
+		enemyType, ok := GetEnemyTypeByName(group.Enemy)
+		if !ok {
+			continue
+		}
+		for i := 0; i < group.Count; i++ {
+			order = append(order, enemyType)
+		}
+	}
+	return order
+}
+
+// Pacing returns the wave's spawn interval and cooldown with defaults applied
+func (w WaveDefinition) Pacing() (spawnInterval, cooldown float64) {
+	spawnInterval, cooldown = w.SpawnInterval, w.Cooldown
+	if spawnInterval <= 0 {
+		spawnInterval = DefaultWaveSpawnInterval
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultWaveCooldown
+	}
+	return spawnInterval, cooldown
+}
+
+// Stats estimates the wave's difficulty from the current ship and weapon configs
+func (w WaveDefinition) Stats() WaveStats {
+	var stats WaveStats
+	spawns := 0
+	for _, group := range w.Groups {
+		enemyType, ok := GetEnemyTypeByName(group.Enemy)
+		if !ok {
+			continue
+		}
+		shipConfig := GetShipTypeConfig(GetEnemyTypeConfig(enemyType).ShipType)
+		dps := 0.0
+		for _, mount := range shipConfig.TurretMounts {
+			if !mount.Active {
+				continue
+			}
+			dps += GetWeaponConfig(mount.WeaponType).DPS()
+		}
+
+		// Swarms spawn GroupSize ships per enemy
+		ships := group.Count * max(GetEnemyTypeConfig(enemyType).GroupSize, 1)
+		spawns += group.Count
+		stats.Enemies += ships
+		stats.TotalHP += shipConfig.Health * float64(ships)
+		stats.DPS += dps * float64(ships)
+	}
+	spawnInterval, _ := w.Pacing()
+	stats.Duration = spawnInterval * float64(spawns)
+	return stats
+}
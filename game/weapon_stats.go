@@ -0,0 +1,73 @@
+package game
+
+// WeaponStat holds one weapon's statistics for a run
+type WeaponStat struct {
+	Weapon     WeaponType
+	ShotsFired int
+	Hits       int
+	Damage     float64
+	Kills      int
+}
+
+// Accuracy returns the fraction of shots that hit (0 if nothing was fired)
+func (s WeaponStat) Accuracy() float64 {
+	if s.ShotsFired == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.ShotsFired)
+}
+
+// WeaponStats tracks per-weapon statistics for one ship's run, fed from the event bus
+type WeaponStats struct {
+	stats []WeaponStat // In order of first use
+}
+
+// NewWeaponStats creates an empty stats tracker
+func NewWeaponStats() *WeaponStats {
+	return &WeaponStats{}
+}
+
+// Reset clears all statistics (a new run)
+func (s *WeaponStats) Reset() {
+	s.stats = s.stats[:0]
+}
+
+// Record updates the statistics of the event's weapon
+func (s *WeaponStats) Record(event Event) {
+	stat := s.stat(event.Weapon)
+	switch event.Type {
+	case EventShotFired:
+		stat.ShotsFired++
+	case EventDamage:
+		if !event.Burn {
+			stat.Hits++ // Burn ticks add damage, not hits
+		}
+		stat.Damage += event.Amount
+	case EventKill:
+		stat.Kills++
+	}
+}
+
+// Stats returns the statistics of every weapon used this run, in order of first use
+func (s *WeaponStats) Stats() []WeaponStat {
+	return s.stats
+}
+
+// stat returns the entry for a weapon, adding it on first use
+func (s *WeaponStats) stat(weaponType WeaponType) *WeaponStat {
+	for i := range s.stats {
+		if s.stats[i].Weapon == weaponType {
+			return &s.stats[i]
+		}
+	}
+	s.stats = append(s.stats, WeaponStat{Weapon: weaponType})
+	return &s.stats[len(s.stats)-1]
+}
+
+// recordPlayerWeaponEvent feeds the player's own shots, hits and kills into the run's weapon stats
+func (g *Game) recordPlayerWeaponEvent(event Event) {
+	if !event.IsFrom(g.player) {
+		return
+	}
+	g.weaponStats.Record(event)
+}
@@ -1,65 +1,196 @@
 package game
 
-// WeaponType defines different types of weapons
+// WeaponType identifies a weapon in the weapon registry
+// Built-in weapons use the constants below; mods and scripts allocate new IDs with NewWeaponType
 type WeaponType int
 
 const (
 	WeaponTypeBullet WeaponType = iota
 	WeaponTypeHomingMissile
 	WeaponTypeNone
+	WeaponTypeTractor
+	WeaponTypeEMP
+	WeaponTypeArc
+	weaponTypeBuiltinCount // First ID handed out by NewWeaponType
 )
 
+// WeaponSpawnFunc spawns whatever a weapon fires from a turret barrel
+type WeaponSpawnFunc func(g *Game, spawnX, spawnY, rotation float64, owner *Entity, weaponConfig WeaponConfig)
+
+// WeaponUpdateFunc runs every tick for each live projectile fired by a weapon (after movement)
+type WeaponUpdateFunc func(g *Game, projectile *Entity, deltaTime float64)
+
+// WeaponDetonateFunc runs when a missile fired by a weapon is destroyed (impact, timeout or shot down)
+type WeaponDetonateFunc func(g *Game, missile *Entity)
+
+// WeaponHitFunc runs when a projectile fired by a weapon hits a target (after the hit's damage)
+type WeaponHitFunc func(g *Game, projectile, target *Entity)
+
 // WeaponConfig holds configuration for each weapon type
 type WeaponConfig struct {
 	Type            WeaponType
-	Damage          float64
+	Name            string
+	Damage          float64 // Dealt by each hit (bullets) or impact (missiles)
 	ProjectileSpeed float64
 	Cooldown        float64
 	Radius          float64 // For projectiles
 	InitialVelocity float64 // For homing missiles (launch speed)
-	Lifetime        float64 // For homing missiles (time before auto-detonation in seconds)
+	Lifetime        float64 // Seconds before the projectile expires (0 = no limit); homing missiles auto-detonate
+	MaxRange        float64 // Distance a bullet travels before despawning (0 = no limit)
+	TracerFade      bool    // Fade the projectile out as it nears expiry
+
+	// Status effects on hit: set the target on fire (BurnDPS damage per second for BurnDuration seconds)
+	// and sap its engines for SlowDuration seconds (0 = none)
+	BurnDPS      float64
+	BurnDuration float64
+	SlowDuration float64
 
 	// Targeting configuration
 	TargetEntityTypes    []EntityType // Whitelist of entity types this weapon can target (empty = all)
 	TargetShipTypes      []ShipType   // Whitelist of ship types this weapon can target (empty = all)
 	BlacklistEntityTypes []EntityType // Blacklist of entity types this weapon cannot target
 	BlacklistShipTypes   []ShipType   // Blacklist of ship types this weapon cannot target
+
+	// Continuous cone beam instead of projectiles (nil for projectile weapons)
+	Tractor *TractorConfig
+
+	// Behavior delegates
+	Spawn    WeaponSpawnFunc    // Spawns the projectile (nil = plain bullet)
+	Update   WeaponUpdateFunc   // Optional per-projectile update (nil = no extra behavior)
+	Detonate WeaponDetonateFunc // Optional blast when a missile goes off (nil = no extra behavior)
+	Hit      WeaponHitFunc      // Optional effect when a projectile hits (nil = no extra behavior)
+}
+
+// Weapon registry (populated with built-in weapons at init, extended by mods and scripts)
+// Registration is not synchronized; register weapons before the game loop starts or from the game goroutine
+var (
+	weaponRegistry = make(map[WeaponType]WeaponConfig)
+	nextWeaponType = weaponTypeBuiltinCount
+)
+
+// RegisterWeapon adds or replaces the weapon with the given ID
+func RegisterWeapon(id WeaponType, config WeaponConfig) {
+	config.Type = id
+	weaponRegistry[id] = config
+	if id >= nextWeaponType {
+		nextWeaponType = id + 1
+	}
+}
+
+// NewWeaponType allocates an unused weapon ID for a weapon defined outside the core game
+func NewWeaponType() WeaponType {
+	id := nextWeaponType
+	nextWeaponType++
+	return id
+}
+
+// LookupWeapon returns the registered config for a weapon type and whether it exists
+func LookupWeapon(weaponType WeaponType) (WeaponConfig, bool) {
+	config, ok := weaponRegistry[weaponType]
+	return config, ok
+}
+
+// GetWeaponTypeByName returns the registered weapon with the given name
+func GetWeaponTypeByName(name string) (WeaponType, bool) {
+	for id, config := range weaponRegistry {
+		if config.Name == name {
+			return id, true
+		}
+	}
+	return 0, false
 }
 
 // GetWeaponConfig returns configuration for a weapon type
+// Unknown weapon types fall back to the bullet
 func GetWeaponConfig(weaponType WeaponType) WeaponConfig {
-	switch weaponType {
-	case WeaponTypeBullet:
-		return WeaponConfig{
-			Type:                 WeaponTypeBullet,
-			Damage:               10.0,
-			ProjectileSpeed:      500.0,
-			Cooldown:             0.1,
-			Radius:               2.5,
-			InitialVelocity:      0.0,                                                                            // Not used for bullets
-			Lifetime:             0.0,                                                                            // No lifetime limit for bullets
-			TargetEntityTypes:    []EntityType{EntityTypeEnemy},                                                  // Only target enemies
-			TargetShipTypes:      []ShipType{},                                                                   // All ship types allowed
-			BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator}, // Don't target projectiles, XP, or indicators
-			BlacklistShipTypes:   []ShipType{},                                                                   // No blacklisted ship types
-		}
-	case WeaponTypeHomingMissile:
-		return WeaponConfig{
-			Type:                 WeaponTypeHomingMissile,
-			Damage:               30.0, // Damage when homing enemy hits
-			ProjectileSpeed:      0.0,  // Not used for homing missiles
-			Cooldown:             1.0,
-			Radius:               0.0,                                                                                                    // Not used for homing missiles
-			InitialVelocity:      150.0,                                                                                                  // Launch speed for homing enemy
-			Lifetime:             5.0,                                                                                                    // Auto-detonate after 5 seconds
-			TargetEntityTypes:    []EntityType{EntityTypeEnemy},                                                                          // Only target enemies
-			TargetShipTypes:      []ShipType{ShipTypePlayer, ShipTypeShooter},                                                            // Only target real ships (not rockets)
-			BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator, EntityTypeHomingRocket}, // Don't target projectiles, XP, indicators, or homing rockets
-			BlacklistShipTypes:   []ShipType{},                                                                                           // No blacklisted ship types (using entity type blacklist instead)
+	if config, ok := weaponRegistry[weaponType]; ok {
+		return config
+	}
+	return weaponRegistry[WeaponTypeBullet]
+}
+
+// init registers the built-in weapons
+func init() {
+	RegisterWeapon(WeaponTypeBullet, WeaponConfig{
+		Name:                 "Bullet",
+		Damage:               25.0,
+		ProjectileSpeed:      500.0,
+		Cooldown:             0.1,
+		Radius:               2.5,
+		InitialVelocity:      0.0,                                                                            // Not used for bullets
+		Lifetime:             4.0,                                                                            // Despawn after 4 seconds at most
+		MaxRange:             1500.0,                                                                         // Despawn after flying 1500 pixels
+		TracerFade:           true,                                                                           // Fade out near max range
+		TargetEntityTypes:    []EntityType{EntityTypeEnemy},                                                  // Only target enemies
+		TargetShipTypes:      []ShipType{},                                                                   // All ship types allowed
+		BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator}, // Don't target projectiles, XP, or indicators
+		BlacklistShipTypes:   []ShipType{},                                                                   // No blacklisted ship types
+		Spawn:                (*Game).spawnBullet,
+	})
+	RegisterWeapon(WeaponTypeHomingMissile, WeaponConfig{
+		Name:                 "Homing Missile",
+		Damage:               50.0, // Damage when homing enemy hits
+		ProjectileSpeed:      0.0,  // Not used for homing missiles
+		Cooldown:             1.0,
+		Radius:               0.0,                                                                                                    // Not used for homing missiles
+		InitialVelocity:      150.0,                                                                                                  // Launch speed for homing enemy
+		Lifetime:             5.0,                                                                                                    // Auto-detonate after 5 seconds
+		TargetEntityTypes:    []EntityType{EntityTypeEnemy},                                                                          // Only target enemies
+		TargetShipTypes:      []ShipType{ShipTypePlayer, ShipTypeShooter},                                                            // Only target real ships (not rockets)
+		BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator, EntityTypeHomingRocket}, // Don't target projectiles, XP, indicators, or homing rockets
+		BlacklistShipTypes:   []ShipType{},                                                                                           // No blacklisted ship types (using entity type blacklist instead)
+		Spawn:                (*Game).spawnHomingMissile,
+	})
+	RegisterWeapon(WeaponTypeEMP, WeaponConfig{
+		Name:                 "EMP Missile",
+		Damage:               50.0, // Impact damage; the blast disables instead of destroying
+		Cooldown:             6.0,
+		InitialVelocity:      150.0,                                                                                                  // Launch speed
+		Lifetime:             4.0,                                                                                                    // Goes off after 4 seconds
+		TargetEntityTypes:    []EntityType{EntityTypeEnemy, EntityTypePlayer},                                                        // Ships only
+		BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator, EntityTypeHomingRocket}, // Don't waste it on missiles
+		Spawn:                (*Game).spawnHomingMissile,
+		Detonate:             (*Game).detonateEMP,
+	})
+	RegisterWeapon(WeaponTypeArc, WeaponConfig{
+		Name:                 "Arc Caster",
+		Damage:               25.0, // Damage of the hit; jumps deal less (see ArcJumpShare)
+		ProjectileSpeed:      600.0,
+		Cooldown:             0.6,
+		Radius:               3.0,
+		Lifetime:             2.0,                                                                            // Despawn after 2 seconds at most
+		MaxRange:             900.0,                                                                          // Shorter reach than bullets
+		TargetEntityTypes:    []EntityType{EntityTypeEnemy},                                                  // Only target enemies
+		BlacklistEntityTypes: []EntityType{EntityTypeProjectile, EntityTypeXP, EntityTypeDestroyedIndicator}, // Don't target projectiles, XP, or indicators
+		Spawn:                (*Game).spawnBullet,
+		Hit:                  (*Game).chainArc,
+	})
+	RegisterWeapon(WeaponTypeTractor, WeaponConfig{
+		Name:              "Tractor Beam",
+		TargetEntityTypes: []EntityType{EntityTypeXP}, // Never auto-targets (the player fires it by holding T)
+		Tractor: &TractorConfig{
+			Range:     300.0,
+			HalfAngle: 0.35,   // ~40 degree cone
+			Pull:      1200.0, // Pickups
+			Push:      900.0,  // Light ships; heavier ones get less
+			Energy:    3.0,    // Seconds of continuous beam
+			Drain:     1.0,
+			Recharge:  0.5,
+		},
+	})
+}
+
+// ProjectileLifetime returns how long a projectile fired at the given speed lives:
+// the shorter of Lifetime and the time to cover MaxRange (0 means no limit)
+func (wc WeaponConfig) ProjectileLifetime(speed float64) float64 {
+	lifetime := wc.Lifetime
+	if wc.MaxRange > 0 && speed > 0 {
+		rangeTime := wc.MaxRange / speed
+		if lifetime == 0 || rangeTime < lifetime {
+			lifetime = rangeTime
 		}
-	default:
-		return GetWeaponConfig(WeaponTypeBullet)
 	}
+	return lifetime
 }
 
 // CanShoot checks if a weapon is ready to fire based on time since last shot
@@ -71,3 +202,12 @@ func (wc WeaponConfig) CanShoot(timeSinceLastShot float64, hasBeenFired bool) bo
 	}
 	return timeSinceLastShot >= wc.Cooldown
 }
+
+// DPS returns the damage per second of one turret firing the weapon off cooldown, one hit (Damage) per shot
+// It's the same damage collisions deal, so estimates can't drift from the real hits
+func (wc WeaponConfig) DPS() float64 {
+	if wc.Cooldown <= 0 {
+		return 0
+	}
+	return wc.Damage / wc.Cooldown
+}
@@ -2,8 +2,8 @@ package game
 
 // World manages the spatial partitioning grid and entity registration
 type World struct {
-	// Preallocated 2D grid of cells
-	Cells [][]*Cell
+	// Sparse grid of cells: only regions containing entities are allocated (see cell_grid.go)
+	cells cellGrid
 
 	// Configuration
 	Config Config
@@ -11,35 +11,72 @@ type World struct {
 	// All entities in the world (for iteration)
 	AllEntities []*Entity
 
+	// Visual-only FX entities (destroyed indicators), oldest first
+	// Not in cells or AllEntities; see fx.go
+	FX CappedList
+
+	// Wreckage drifting where ships were destroyed, oldest first: visual only, apart from salvage
+	// Not in cells or AllEntities; see debris.go
+	Debris CappedList
+
+	// Shrinking safe zone (nil unless Config.SafeZone is set)
+	SafeZone *SafeZone
+
+	// Player's standing with the factions that take sides (see reputation.go)
+	Reputation Reputation
+
 	// Entity pool for reuse
 	EntityPool []*Entity
 	PoolIndex  int
+
+	// Entity lookup by ID and the next ID to hand out
+	entitiesByID map[uint64]*Entity
+	nextEntityID uint64
+
+	// State as of the last Snapshot call (used to compute deltas)
+	snapshotState map[uint64]EntityState
+	snapshotTick  uint64
+
+	// Tick of the last snapshot applied with ApplySnapshot
+	appliedTick uint64
+
+	// Bumped whenever an entity enters or leaves a cell (invalidates targetCache)
+	gridVersion uint64
+
+	// Shared broad-phase scans for targeting queries
+	targetCache targetCache
 }
 
-// NewWorld creates a new world with preallocated cells
+// NewWorld creates a new world with an empty grid
+// Cells are allocated as entities enter them, so the world size doesn't affect memory
 func NewWorld(config Config) *World {
-	cellCountX := config.CellCountX()
-	cellCountY := config.CellCountY()
-
-	// Preallocate 2D grid
-	cells := make([][]*Cell, cellCountX)
-	for x := 0; x < cellCountX; x++ {
-		cells[x] = make([]*Cell, cellCountY)
-		for y := 0; y < cellCountY; y++ {
-			// Preallocate each cell with initial capacity
-			cells[x][y] = NewCell(100)
-		}
+	// Safe zone starts centered on the world center
+	var safeZone *SafeZone
+	if config.SafeZone {
+		safeZone = NewSafeZone(config.WorldMinX+config.WorldWidth/2, config.WorldMinY+config.WorldHeight/2, SafeZoneInitialRadius)
 	}
 
 	return &World{
-		Cells:       cells,
+		cells:       newCellGrid(),
+		SafeZone:    safeZone,
 		Config:      config,
 		AllEntities: make([]*Entity, 0, 10000),
+		FX:          make([]*Entity, 0, MaxFXEntities),
+		Debris:      make([]*Entity, 0, MaxDebris),
 		EntityPool:  make([]*Entity, 0, 1000),
 		PoolIndex:   0,
+
+		entitiesByID:  make(map[uint64]*Entity, 10000),
+		nextEntityID:  1,
+		snapshotState: make(map[uint64]EntityState),
 	}
 }
 
+// GetEntityByID returns the registered entity with the given ID, or nil
+func (w *World) GetEntityByID(id uint64) *Entity {
+	return w.entitiesByID[id]
+}
+
 // WorldToCell converts world coordinates to cell coordinates
 func (w *World) WorldToCell(x, y float64) (int, int) {
 	// Offset by world origin to convert to cell coordinates
@@ -57,26 +94,49 @@ func (w *World) WorldToCell(x, y float64) (int, int) {
 }
 
 // GetCell returns the cell at the given cell coordinates
+// Returns nil outside the world and for cells that have never held an entity
 func (w *World) GetCell(cellX, cellY int) *Cell {
 	if cellX < 0 || cellX >= w.Config.CellCountX() ||
 		cellY < 0 || cellY >= w.Config.CellCountY() {
 		return nil
 	}
-	return w.Cells[cellX][cellY]
+	return w.cells.cell(cellX, cellY)
+}
+
+// AllocatedCellChunks returns the number of grid chunks currently allocated (for stats and benchmarks)
+func (w *World) AllocatedCellChunks() int {
+	return len(w.cells.chunks)
 }
 
 // RegisterEntity adds an entity to the world and assigns it to the correct cell
 func (w *World) RegisterEntity(entity *Entity) {
+	// Assign a unique ID (entities restored from snapshots keep theirs)
+	if entity.ID == 0 {
+		entity.ID = w.nextEntityID
+		w.nextEntityID++
+	} else if entity.ID >= w.nextEntityID {
+		w.nextEntityID = entity.ID + 1
+	}
+	w.entitiesByID[entity.ID] = entity
+
+	// FX entities stay out of the spatial grid
+	if isFXEntity(entity) {
+		w.FX.add(w, entity, MaxFXEntities)
+		return
+	}
+	if entity.Type == EntityTypeDebris {
+		w.Debris.add(w, entity, MaxDebris)
+		return
+	}
+
 	// Calculate cell coordinates
 	cellX, cellY := w.WorldToCell(entity.X, entity.Y)
 	entity.CellX = cellX
 	entity.CellY = cellY
 
 	// Add to cell
-	cell := w.GetCell(cellX, cellY)
-	if cell != nil {
-		cell.AddEntity(entity)
-	}
+	w.cells.add(cellX, cellY, entity)
+	w.gridVersion++
 
 	// Add to all entities list
 	w.AllEntities = append(w.AllEntities, entity)
@@ -84,12 +144,24 @@ func (w *World) RegisterEntity(entity *Entity) {
 
 // UnregisterEntity removes an entity from the world
 func (w *World) UnregisterEntity(entity *Entity) {
-	// Remove from cell
-	cell := w.GetCell(entity.CellX, entity.CellY)
-	if cell != nil {
-		cell.RemoveEntity(entity)
+	// Remove from ID lookup
+	if w.entitiesByID[entity.ID] == entity {
+		delete(w.entitiesByID, entity.ID)
 	}
 
+	if isFXEntity(entity) {
+		w.FX.remove(entity)
+		return
+	}
+	if entity.Type == EntityTypeDebris {
+		w.Debris.remove(entity)
+		return
+	}
+
+	// Remove from cell
+	w.cells.remove(entity.CellX, entity.CellY, entity)
+	w.gridVersion++
+
 	// Remove from all entities list
 	for i, e := range w.AllEntities {
 		if e == entity {
@@ -102,23 +174,21 @@ func (w *World) UnregisterEntity(entity *Entity) {
 
 // UpdateEntityCell updates an entity's cell membership if it moved
 func (w *World) UpdateEntityCell(entity *Entity) {
+	if isFXEntity(entity) {
+		return // Not in the grid
+	}
+
 	newCellX, newCellY := w.WorldToCell(entity.X, entity.Y)
 
 	// If entity moved to a different cell, update cell membership
 	if newCellX != entity.CellX || newCellY != entity.CellY {
-		// Remove from old cell
-		oldCell := w.GetCell(entity.CellX, entity.CellY)
-		if oldCell != nil {
-			oldCell.RemoveEntity(entity)
-		}
-
-		// Add to new cell
+		// Add to the new cell before leaving the old one, so a chunk isn't released and reallocated
+		// when the entity moves between two of its cells
+		w.cells.add(newCellX, newCellY, entity)
+		w.cells.remove(entity.CellX, entity.CellY, entity)
 		entity.CellX = newCellX
 		entity.CellY = newCellY
-		newCell := w.GetCell(newCellX, newCellY)
-		if newCell != nil {
-			newCell.AddEntity(entity)
-		}
+		w.gridVersion++
 	}
 }
 
@@ -152,7 +222,12 @@ func (w *World) GetCellsForEntity(entity *Entity) []*Cell {
 
 // GetEntitiesInRadius returns all entities within a radius of a point
 func (w *World) GetEntitiesInRadius(x, y, radius float64) []*Entity {
-	entities := make([]*Entity, 0, 100)
+	return w.AppendEntitiesInRadius(make([]*Entity, 0, 100), x, y, radius)
+}
+
+// AppendEntitiesInRadius appends all active entities within a radius of a point to entities
+// Pass a reused buffer (buf[:0]) to query without allocating
+func (w *World) AppendEntitiesInRadius(entities []*Entity, x, y, radius float64) []*Entity {
 
 	// Get cells that might contain entities in radius
 	minCellX, minCellY := w.WorldToCell(x-radius, y-radius)
@@ -0,0 +1,198 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// MapCellSize is the side length of the squares the world map records exploration in (pixels)
+	MapCellSize = 2048.0
+
+	// MapRevealRadius is how far around the player the map records what's there (pixels)
+	MapRevealRadius = 1500.0
+
+	// MapScanInterval is the time between two map scans around the player (seconds)
+	MapScanInterval = 0.5
+
+	// WorldMapPanSpeed is the map pan speed in screen pixels per second
+	WorldMapPanSpeed = 800.0
+
+	// WorldMapZoomSpeed is the map zoom rate multiplier per second (the mouse wheel zooms in steps)
+	WorldMapZoomSpeed = 1.5
+
+	// WorldMapMinScale and WorldMapMaxScale bound the map zoom (screen pixels per world pixel)
+	WorldMapMinScale = 0.001
+	WorldMapMaxScale = 0.2
+
+	// WaypointArrivalDistance is how close the player must get for a waypoint to clear itself (pixels)
+	WaypointArrivalDistance = 300.0
+)
+
+// MapCell identifies a square of the world map by its column and row, counted from the world origin
+type MapCell struct {
+	X, Y int
+}
+
+// KnownStation is what the map remembers about a station the player has seen
+type KnownStation struct {
+	X, Y float64
+	Shop bool // Has something to sell
+}
+
+// Waypoint is a navigation target set on the world map
+type Waypoint struct {
+	X, Y float64
+}
+
+// WorldMap holds what the player has discovered and the state of the full-screen map
+// Controls: M toggles, WASD/arrows pan, Q/E or the mouse wheel zoom, click sets a waypoint, right click clears it
+type WorldMap struct {
+	Open bool // Map is on screen and the simulation is paused
+
+	// World position at the center of the screen, and the zoom (screen pixels per world pixel)
+	CenterX, CenterY float64
+	Scale            float64
+
+	Explored map[MapCell]bool
+	Stations map[uint64]KnownStation
+	Enemies  map[MapCell]int // Enemy ships counted in each cell the last time the player saw it
+
+	// Time until the next scan
+	scanTimer float64
+}
+
+// NewWorldMap creates an empty, closed world map
+func NewWorldMap() *WorldMap {
+	return &WorldMap{
+		Scale:    0.02,
+		Explored: make(map[MapCell]bool, 64),
+		Stations: make(map[uint64]KnownStation, 16),
+		Enemies:  make(map[MapCell]int, 64),
+	}
+}
+
+// MapCellAt returns the map cell containing a world position
+func MapCellAt(x, y float64) MapCell {
+	return MapCell{X: int(math.Floor(x / MapCellSize)), Y: int(math.Floor(y / MapCellSize))}
+}
+
+// Toggle opens the map centered on the player (or where it was last looking), or closes it
+func (m *WorldMap) Toggle(player *Entity) {
+	m.Open = !m.Open
+	if m.Open && player != nil && player.Active {
+		m.CenterX = player.X
+		m.CenterY = player.Y
+	}
+}
+
+// ScreenToWorld converts a position on the open map to world coordinates
+func (m *WorldMap) ScreenToWorld(sx, sy, screenWidth, screenHeight float64) (float64, float64) {
+	return m.CenterX + (sx-screenWidth/2)/m.Scale, m.CenterY + (sy-screenHeight/2)/m.Scale
+}
+
+// WorldToScreen converts world coordinates to a position on the open map
+func (m *WorldMap) WorldToScreen(x, y, screenWidth, screenHeight float64) (float64, float64) {
+	return (x-m.CenterX)*m.Scale + screenWidth/2, (y-m.CenterY)*m.Scale + screenHeight/2
+}
+
+// scan records the cells, stations and enemy ships around the player
+// Cells are overwritten on every visit, so enemy counts show where enemies were when last seen
+func (m *WorldMap) scan(world *World, player *Entity) {
+	minCell := MapCellAt(player.X-MapRevealRadius, player.Y-MapRevealRadius)
+	maxCell := MapCellAt(player.X+MapRevealRadius, player.Y+MapRevealRadius)
+	for x := minCell.X; x <= maxCell.X; x++ {
+		for y := minCell.Y; y <= maxCell.Y; y++ {
+			cell := MapCell{x, y}
+			m.Explored[cell] = true
+			delete(m.Enemies, cell)
+		}
+	}
+
+	// Forget stations that were destroyed since they were last seen
+	for id, station := range m.Stations {
+		dx := station.X - player.X
+		dy := station.Y - player.Y
+		if dx*dx+dy*dy <= MapRevealRadius*MapRevealRadius && world.GetEntityByID(id) == nil {
+			delete(m.Stations, id)
+		}
+	}
+
+	for _, entity := range world.GetEntitiesInRadius(player.X, player.Y, MapRevealRadius) {
+		if !entity.Active || entity.Health <= 0 {
+			continue
+		}
+		switch {
+		case entity.Type == EntityTypeStation:
+			m.Stations[entity.ID] = KnownStation{X: entity.X, Y: entity.Y, Shop: len(entity.Station.Inventory) > 0}
+		case entity.Type == EntityTypeEnemy && entity.Faction == FactionEnemy && !entity.Cloak.Hidden():
+			m.Enemies[MapCellAt(entity.X, entity.Y)]++
+		}
+	}
+}
+
+// updateWorldMap scans around the player every MapScanInterval and clears a reached waypoint
+func (g *Game) updateWorldMap(deltaTime float64) {
+	if g.player == nil || !g.player.Active {
+		return
+	}
+
+	g.worldMap.scanTimer -= deltaTime
+	if g.worldMap.scanTimer <= 0 {
+		g.worldMap.scanTimer = MapScanInterval
+		g.worldMap.scan(g.world, g.player)
+	}
+
+	if waypoint := g.waypoint; waypoint != nil {
+		dx := waypoint.X - g.player.X
+		dy := waypoint.Y - g.player.Y
+		if dx*dx+dy*dy <= WaypointArrivalDistance*WaypointArrivalDistance {
+			g.waypoint = nil
+		}
+	}
+}
+
+// updateWorldMapInput pans and zooms the open map and sets or clears the waypoint
+func (g *Game) updateWorldMapInput(deltaTime float64) {
+	m := g.worldMap
+
+	// Pan in screen space so speed feels the same at every zoom level
+	pan := WorldMapPanSpeed * deltaTime / m.Scale
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
+		m.CenterX -= pan
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
+		m.CenterX += pan
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) || ebiten.IsKeyPressed(ebiten.KeyW) {
+		m.CenterY -= pan
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) || ebiten.IsKeyPressed(ebiten.KeyS) {
+		m.CenterY += pan
+	}
+
+	// Zoom (E in, Q out, or the wheel)
+	if ebiten.IsKeyPressed(ebiten.KeyE) {
+		m.Scale *= 1 + WorldMapZoomSpeed*deltaTime
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyQ) {
+		m.Scale /= 1 + WorldMapZoomSpeed*deltaTime
+	}
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		m.Scale *= math.Pow(1.2, wheel)
+	}
+	m.Scale = math.Max(WorldMapMinScale, math.Min(m.Scale, WorldMapMaxScale))
+
+	screenWidth := float64(g.config.ScreenWidth)
+	screenHeight := float64(g.config.ScreenHeight)
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		cursorX, cursorY := ebiten.CursorPosition()
+		x, y := m.ScreenToWorld(float64(cursorX), float64(cursorY), screenWidth, screenHeight)
+		g.waypoint = &Waypoint{X: x, Y: y}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		g.waypoint = nil
+	}
+}
@@ -0,0 +1,217 @@
+package game
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+const (
+	benchmarkHugeWorldSize      = 1000000.0 // A 1,000,000-unit square world
+	benchmarkWorldEntities      = 5000
+	benchmarkWorldPopulatedArea = 40000.0 // Entities spread over this square around the center
+	benchmarkWorldQueryRadius   = 1500.0
+)
+
+// hugeWorldConfig returns the default config with a 1,000,000-unit square world
+func hugeWorldConfig() Config {
+	config := DefaultConfig()
+	config.WorldMinX = -benchmarkHugeWorldSize / 2
+	config.WorldMinY = -benchmarkHugeWorldSize / 2
+	config.WorldWidth = benchmarkHugeWorldSize
+	config.WorldHeight = benchmarkHugeWorldSize
+	return config
+}
+
+// denseCells is the old fully preallocated grid, kept as the benchmark baseline
+type denseCells [][]*Cell
+
+// newDenseCells preallocates every cell of the world like NewWorld used to
+func newDenseCells(config Config) denseCells {
+	cells := make(denseCells, config.CellCountX())
+	for x := range cells {
+		cells[x] = make([]*Cell, config.CellCountY())
+		for y := range cells[x] {
+			cells[x][y] = NewCell(100)
+		}
+	}
+	return cells
+}
+
+// getCell is the old World.GetCell
+func (d denseCells) getCell(config Config, cellX, cellY int) *Cell {
+	if cellX < 0 || cellX >= config.CellCountX() ||
+		cellY < 0 || cellY >= config.CellCountY() {
+		return nil
+	}
+	return d[cellX][cellY]
+}
+
+// appendEntitiesInRadius is World.AppendEntitiesInRadius over the dense grid
+func (d denseCells) appendEntitiesInRadius(w *World, entities []*Entity, x, y, radius float64) []*Entity {
+	minCellX, minCellY := w.WorldToCell(x-radius, y-radius)
+	maxCellX, maxCellY := w.WorldToCell(x+radius, y+radius)
+	for cellX := minCellX; cellX <= maxCellX; cellX++ {
+		for cellY := minCellY; cellY <= maxCellY; cellY++ {
+			cell := d.getCell(w.Config, cellX, cellY)
+			if cell == nil {
+				continue
+			}
+			for i := 0; i < cell.Count; i++ {
+				entity := cell.Entities[i]
+				dx := entity.X - x
+				dy := entity.Y - y
+				if entity.Active && dx*dx+dy*dy <= radius*radius {
+					entities = append(entities, entity)
+				}
+			}
+		}
+	}
+	return entities
+}
+
+// populateBenchmarkWorld registers entities around the world center (into dense too, if given)
+// and returns query points among them
+func populateBenchmarkWorld(world *World, dense denseCells) [][2]float64 {
+	rng := rand.New(rand.NewSource(1))
+	centerX := world.Config.WorldMinX + world.Config.WorldWidth/2
+	centerY := world.Config.WorldMinY + world.Config.WorldHeight/2
+	points := make([][2]float64, 0, 256)
+	for i := 0; i < benchmarkWorldEntities; i++ {
+		x := centerX + (rng.Float64()-0.5)*benchmarkWorldPopulatedArea
+		y := centerY + (rng.Float64()-0.5)*benchmarkWorldPopulatedArea
+		entity := NewEntity(x, y, 5, EntityTypeEnemy, nil)
+		world.RegisterEntity(entity)
+		if dense != nil {
+			dense[entity.CellX][entity.CellY].AddEntity(entity)
+		}
+		if len(points) < cap(points) {
+			points = append(points, [2]float64{x, y})
+		}
+	}
+	return points
+}
+
+// heapInUse returns the live heap size after a full collection
+func heapInUse() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapInuse
+}
+
+// BenchmarkHugeWorldMemoryDense measures the old preallocated grid for a 1,000,000-unit world
+func BenchmarkHugeWorldMemoryDense(b *testing.B) {
+	config := hugeWorldConfig()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		before := heapInUse()
+		world := NewWorld(config)
+		dense := newDenseCells(config)
+		populateBenchmarkWorld(world, dense)
+		b.ReportMetric(float64(int64(heapInUse())-int64(before))/(1<<20), "MB-live")
+		runtime.KeepAlive(dense)
+	}
+}
+
+// BenchmarkHugeWorldMemorySparse measures the sparse grid for the same world and population
+func BenchmarkHugeWorldMemorySparse(b *testing.B) {
+	config := hugeWorldConfig()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		before := heapInUse()
+		world := NewWorld(config)
+		populateBenchmarkWorld(world, nil)
+		b.ReportMetric(float64(int64(heapInUse())-int64(before))/(1<<20), "MB-live")
+		b.ReportMetric(float64(world.AllocatedCellChunks()), "chunks")
+		runtime.KeepAlive(world)
+	}
+}
+
+// BenchmarkHugeWorldQueryDense is the radius query over the old preallocated grid
+func BenchmarkHugeWorldQueryDense(b *testing.B) {
+	config := hugeWorldConfig()
+	world := NewWorld(config)
+	dense := newDenseCells(config)
+	points := populateBenchmarkWorld(world, dense)
+	buf := make([]*Entity, 0, 256)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		point := points[n%len(points)]
+		buf = dense.appendEntitiesInRadius(world, buf[:0], point[0], point[1], benchmarkWorldQueryRadius)
+	}
+}
+
+// BenchmarkHugeWorldQuerySparse is the same radius query over the sparse grid
+func BenchmarkHugeWorldQuerySparse(b *testing.B) {
+	world := NewWorld(hugeWorldConfig())
+	points := populateBenchmarkWorld(world, nil)
+	buf := make([]*Entity, 0, 256)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		point := points[n%len(points)]
+		buf = world.AppendEntitiesInRadius(buf[:0], point[0], point[1], benchmarkWorldQueryRadius)
+	}
+}
+
+// BenchmarkHugeWorldCellMoves moves entities across cell and chunk borders in the sparse grid
+func BenchmarkHugeWorldCellMoves(b *testing.B) {
+	world := NewWorld(hugeWorldConfig())
+	populateBenchmarkWorld(world, nil)
+	entities := world.AllEntities
+	step := world.Config.CellSize
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		entity := entities[n%len(entities)]
+		if n/len(entities)%2 == 0 {
+			entity.X += step
+		} else {
+			entity.X -= step
+		}
+		world.UpdateEntityCell(entity)
+	}
+}
+
+// TestSparseGridQueriesMatchBruteForce moves and removes entities across chunk borders
+// and checks radius queries still find exactly the entities in range
+func TestSparseGridQueriesMatchBruteForce(t *testing.T) {
+	world := NewWorld(hugeWorldConfig())
+	points := populateBenchmarkWorld(world, nil)
+	rng := rand.New(rand.NewSource(2))
+
+	for round := 0; round < 20; round++ {
+		// Scatter some entities (many into new chunks) and remove a few
+		for i := 0; i < 200; i++ {
+			entity := world.AllEntities[rng.Intn(len(world.AllEntities))]
+			entity.X += (rng.Float64() - 0.5) * 40000
+			entity.Y += (rng.Float64() - 0.5) * 40000
+			world.UpdateEntityCell(entity)
+		}
+		for i := 0; i < 20; i++ {
+			world.UnregisterEntity(world.AllEntities[rng.Intn(len(world.AllEntities))])
+		}
+
+		for _, point := range points[:32] {
+			found := world.GetEntitiesInRadius(point[0], point[1], benchmarkWorldQueryRadius)
+			want := 0
+			for _, entity := range world.AllEntities {
+				dx := entity.X - point[0]
+				dy := entity.Y - point[1]
+				if dx*dx+dy*dy <= benchmarkWorldQueryRadius*benchmarkWorldQueryRadius {
+					want++
+				}
+			}
+			if len(found) != want {
+				t.Fatalf("round %d: query at (%.0f, %.0f) found %d entities, want %d", round, point[0], point[1], len(found), want)
+			}
+		}
+	}
+
+	// Emptying the world releases every chunk
+	for len(world.AllEntities) > 0 {
+		world.UnregisterEntity(world.AllEntities[0])
+	}
+	if chunks := world.AllocatedCellChunks(); chunks != 0 {
+		t.Fatalf("%d chunks allocated in an empty world, want 0", chunks)
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import "math"
+
+const (
+	// XPMergeInterval is the time between two passes merging crowded XP orbs (seconds)
+	XPMergeInterval = 0.5
+
+	// XPMergeCellSize is the side length of the squares orbs are counted in (pixels)
+	XPMergeCellSize = 128.0
+
+	// XPMergeThreshold merges the orbs of a square once more than this many are in it
+	XPMergeThreshold = 8
+
+	// XPOrbRadius is the radius of a single orb; merged orbs grow with the square root of the orbs they hold
+	XPOrbRadius = 2.0
+
+	// XPOrbMaxRadius caps the radius of merged orbs
+	XPOrbMaxRadius = 8.0
+)
+
+// xpMergeKey groups orbs that may merge: same square, same collector and same score source,
+// so the merged orb is collected by the same player and credited the same way
+type xpMergeKey struct {
+	cellX, cellY int
+	target       *Entity
+	source       ScoreSource
+}
+
+// XPMerger merges nearby XP orbs into bigger, more valuable ones when too many crowd together
+// Large waves leave hundreds of orbs behind; merging bounds the number of XP entities without changing
+// the total value waiting to be collected
+type XPMerger struct {
+	timer  float64
+	groups map[xpMergeKey][]*Entity
+}
+
+// NewXPMerger creates an XP merger
+func NewXPMerger() *XPMerger {
+	return &XPMerger{groups: make(map[xpMergeKey][]*Entity, 64)}
+}
+
+// Update merges crowded orbs once per XPMergeInterval
+func (m *XPMerger) Update(deltaTime float64, world *World) {
+	m.timer -= deltaTime
+	if m.timer > 0 {
+		return
+	}
+	m.timer = XPMergeInterval
+
+	for key, orbs := range m.groups {
+		m.groups[key] = orbs[:0]
+	}
+	for _, entity := range world.AllEntities {
+		if entity.Type != EntityTypeXP || !entity.Active || entity.Health <= 0 {
+			continue
+		}
+		key := xpMergeKey{
+			cellX:  int(math.Floor(entity.X / XPMergeCellSize)),
+			cellY:  int(math.Floor(entity.Y / XPMergeCellSize)),
+			target: entity.Pickup.Target,
+			source: entity.Pickup.Source,
+		}
+		m.groups[key] = append(m.groups[key], entity)
+	}
+	for key, orbs := range m.groups {
+		if len(orbs) > XPMergeThreshold {
+			mergeXPOrbs(orbs)
+		} else if len(orbs) == 0 {
+			delete(m.groups, key) // Forget squares the orbs have left
+		}
+	}
+}
+
+// mergeXPOrbs folds the orbs into the first one, placed at their value-weighted center
+// The others are removed by the entity update loop like collected orbs
+func mergeXPOrbs(orbs []*Entity) {
+	merged := orbs[0]
+	var value, count int
+	var x, y float64
+	for _, orb := range orbs {
+		weight := float64(orb.Pickup.Value)
+		x += orb.X * weight
+		y += orb.Y * weight
+		value += orb.Pickup.Value
+		count += max(orb.Pickup.Orbs, 1)
+		if orb != merged {
+			orb.Health = 0
+		}
+	}
+	if value > 0 {
+		merged.X = x / float64(value)
+		merged.Y = y / float64(value)
+	}
+	merged.Pickup.Value = value
+	merged.Pickup.Orbs = count
+	merged.Radius = xpOrbRadius(count)
+}
+
+// xpOrbRadius returns the radius of an orb holding count orbs
+func xpOrbRadius(count int) float64 {
+	return math.Min(XPOrbRadius*math.Sqrt(float64(max(count, 1))), XPOrbMaxRadius)
+}
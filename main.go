@@ -1,11 +1,11 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net/http"
-	"os"
 	"runtime"
-	_ "net/http/pprof"
+	"time"
 
 	"billionslike3/game"
 
@@ -13,32 +13,199 @@ import (
 )
 
 func main() {
-	// Tune GC for better performance in games
-	// Set GOGC to 100 (default) but ensure we're using the latest GC
-	// For games, we want lower latency, so we can set a lower GOGC if needed
-	// But for now, keep default and monitor
-	
+	arena := flag.Bool("arena", false, "run a headless bot arena instead of the desktop game")
+	spectateAddr := flag.String("spectate", "localhost:8090", "address of the arena spectator web viewer")
+	modsDir := flag.String("mods", "", "directory to load mods from (default: mods folder in the user config directory)")
+	tps := flag.Int("tps", game.DefaultSimulationTPS, "simulation ticks per second (e.g. 30 on weak hardware, 120 for smoother motion)")
+	friendlyFire := flag.String("friendly-fire", "off", "friendly fire between allies: off, reduced or full")
+	difficulty := flag.String("difficulty", "normal", "enemy AI skill: easy, normal or hard")
+	safeZone := flag.Bool("safe-zone", false, "shrinking safe zone: ships outside the circle take damage over time")
+	sectors := flag.Bool("sectors", false, "procedurally populate the regions the player explores with asteroids, stations and patrols")
+	seed := flag.Int64("seed", 0, "seed for generated sectors (0 = random)")
+	runSeed := flag.Int64("run-seed", 0, "seed for the run's spawns, loot and AI (0 = random), e.g. to replay a run shown on the death screen")
+	checksumEvery := flag.Int("checksum-every", 0, "debug: log the world checksum every N ticks to compare runs for determinism (0 = off)")
+	gogc := flag.Int("gogc", 0, "fixed GC target percentage (0 = tune automatically from frame times, -1 = GC off)")
+	memoryLimit := flag.Int("memory-limit", 0, "fixed soft memory limit in MB (0 = tune automatically)")
+	pprof := flag.Bool("pprof", false, "development: serve profiling endpoints (net/http/pprof) from startup; F11 toggles them in game")
+	pprofAddr := flag.String("pprof-addr", game.DefaultPprofAddr, "address (host:port) of the pprof server; keep it on localhost, it has no authentication")
+	display := flag.String("display", "", "display mode: windowed, borderless or fullscreen (default: as last time)")
+	monitor := flag.Int("monitor", -1, "monitor to open the window on, 0 = primary (default: as last time)")
+	fps := flag.String("fps", "", "frame rate cap: vsync, 30, 60, 120 or unlimited (default: as last time)")
+	batterySaver := flag.String("battery-saver", "", "on: drop to 10 TPS while the window is unfocused, off: keep running at full rate (default: as last time)")
+	respawn := flag.String("respawn", "restart", "on death: restart (new run from wave 1) or in-place (respawn in the same run, losing a share of the score)")
+	lives := flag.Int("lives", 0, "ships per run before the continue screen (0 = off, dying ends the run)")
+	healthBars := flag.String("health-bars", "", "health bars over ships: on-damage, always or never (default: as last time)")
+	bloom := flag.String("bloom", "", "glow on bright parts of the world: off, low or high (default: as last time)")
+	lighting := flag.String("lighting", "", "on: darken space and light it around projectiles, engines and explosions, off: flat lighting (default: as last time)")
+	lowEnd := flag.String("low-end", "", "on: skip post-processing such as bloom on weak hardware, off: use it as configured (default: as last time)")
+	coop := flag.Bool("coop", false, "local co-op: a second player on a gamepad, with the window split in two")
+	mission := flag.String("mission", "none", "mission objective: none, escort (see a convoy to its destination) or defend (keep a station alive for 5 waves)")
+	wavesFile := flag.String("waves", "", "wave set file to play instead of endless random waves (edit with cmd/waveedit)")
+	assetsDir := flag.String("assets-dir", "", "development: hot-reload sprites from this directory (e.g. game/assets)")
+	flag.Parse()
+
 	// Set minimum number of OS threads to match CPU count for better parallelism
 	// This helps with GC and game loop parallelism
 	runtime.GOMAXPROCS(runtime.NumCPU())
-	
-	log.Printf("GC tuning: GOGC=%s, GOMAXPROCS=%d\n", 
-		os.Getenv("GOGC"), runtime.GOMAXPROCS(0))
-	
-	// Start pprof HTTP server in a goroutine for profiling
-	go func() {
-		log.Println("Starting pprof server on http://localhost:6060")
-		log.Println(http.ListenAndServe("localhost:6060", nil))
-	}()
+
+	// Load persisted user settings (accessibility options); defaults are used on failure
+	if err := game.LoadSettings(); err != nil {
+		log.Printf("Using default settings: %v\n", err)
+	}
+
+	// Load mods before any game state is created so their weapons and ship patches apply everywhere
+	mods := loadMods(*modsDir)
 
 	config := game.DefaultConfig()
+	friendlyFireMode, err := game.ParseFriendlyFireMode(*friendlyFire)
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.FriendlyFire = friendlyFireMode
+	if config.Difficulty, err = game.ParseDifficulty(*difficulty); err != nil {
+		log.Fatal(err)
+	}
+	config.SimulationTPS = *tps
+	config.SafeZone = *safeZone
+	config.Sectors.Enabled = *sectors
+	config.Sectors.Seed = *seed
+	config.RunSeed = *runSeed
+	config.SplitScreen = *coop
+	config.Lives = *lives
+	config.ChecksumInterval = *checksumEvery
+	config.Pprof = *pprof
+	config.PprofAddr = *pprofAddr
+	config.GC = game.GCConfig{GOGC: *gogc, MemoryLimitMB: *memoryLimit}
+	if config.Mission, err = game.ParseMissionType(*mission); err != nil {
+		log.Fatal(err)
+	}
+	if config.Respawn.Mode, err = game.ParseRespawnMode(*respawn); err != nil {
+		log.Fatal(err)
+	}
+
+	if *arena {
+		runArena(config, *spectateAddr)
+		return
+	}
+
 	g := game.NewGame(config)
+	g.SetMods(mods)
+	g.EnableCheckpointFile()
+	if *wavesFile != "" {
+		waveSet, err := game.LoadWaveSet(*wavesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.SetWaveSet(waveSet)
+	}
+	if *assetsDir != "" {
+		log.Printf("Watching %s for asset changes\n", *assetsDir)
+		g.WatchAssets(*assetsDir)
+	}
+
+	if *healthBars != "" {
+		if game.GetSettings().HealthBars, err = game.ParseHealthBarMode(*healthBars); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	ebiten.SetWindowSize(config.ScreenWidth, config.ScreenHeight)
+	if *bloom != "" {
+		if game.GetSettings().Bloom, err = game.ParseBloomQuality(*bloom); err != nil {
+			log.Fatal(err)
+		}
+	}
+	switch *lighting {
+	case "":
+	case "on":
+		game.GetSettings().Lighting = true
+	case "off":
+		game.GetSettings().Lighting = false
+	default:
+		log.Fatalf("unknown lighting setting %q (use on or off)", *lighting)
+	}
+	switch *lowEnd {
+	case "":
+	case "on":
+		game.GetSettings().LowEnd = true
+	case "off":
+		game.GetSettings().LowEnd = false
+	default:
+		log.Fatalf("unknown low-end setting %q (use on or off)", *lowEnd)
+	}
+
+	// Flags change the remembered display settings
+	displaySettings := &game.GetSettings().Display
+	if *display != "" {
+		if displaySettings.Mode, err = game.ParseDisplayMode(*display); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *monitor >= 0 {
+		displaySettings.Monitor = *monitor
+	}
+	if *fps != "" {
+		if displaySettings.FrameRateCap, err = game.ParseFrameRateCap(*fps); err != nil {
+			log.Fatal(err)
+		}
+	}
+	switch *batterySaver {
+	case "":
+	case "on":
+		displaySettings.BatterySaver = true
+	case "off":
+		displaySettings.BatterySaver = false
+	default:
+		log.Fatalf("unknown battery saver setting %q (use on or off)", *batterySaver)
+	}
+	game.ApplyDisplaySettings(*displaySettings, config.ScreenWidth, config.ScreenHeight)
+	game.ApplyFrameRateCap(displaySettings.FrameRateCap)
+	ebiten.SetWindowClosingHandled(true)
 	ebiten.SetWindowTitle("Space Shooter")
 	ebiten.SetWindowResizable(true)
+	ebiten.SetTPS(config.TickRate())
 
 	if err := ebiten.RunGame(g); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// loadMods loads mods from dir (or the default mods directory), logging rather than failing on errors
+func loadMods(dir string) []*game.Mod {
+	if dir == "" {
+		defaultDir, err := game.DefaultModsDir()
+		if err != nil {
+			log.Printf("Mods disabled: %v\n", err)
+			return nil
+		}
+		dir = defaultDir
+	}
+
+	mods, err := game.LoadMods(dir)
+	if err != nil {
+		log.Printf("Failed to load mods: %v\n", err)
+	}
+	return mods
+}
+
+// runArena runs the bot arena without a window, streaming snapshots to web spectators
+func runArena(config game.Config, spectateAddr string) {
+	config.Mode = game.GameModeArena
+	g := game.NewGame(config)
+
+	spectator := game.NewSpectatorServer()
+	g.SetSpectator(spectator)
+	go func() {
+		log.Printf("Arena spectator viewer on http://%s\n", spectateAddr)
+		log.Fatal(http.ListenAndServe(spectateAddr, spectator))
+	}()
+
+	// Fixed-rate simulation at the configured tick rate
+	tickRate := config.TickRate()
+	ticker := time.NewTicker(time.Second / time.Duration(tickRate))
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := g.Step(1.0 / float64(tickRate)); err != nil {
+			log.Fatal(err)
+		}
+	}
+}